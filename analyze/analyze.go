@@ -0,0 +1,230 @@
+// Package analyze derives user models from a user's memories for profile
+// dashboards. Topics clusters the memories with k-means over their
+// embeddings and labels each cluster, by an LLM or by its most frequent
+// words; BuildProfile sorts them into a structured profile citing the
+// memories behind each entry.
+package analyze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+const (
+	// DefaultPageSize is the number of memories fetched per GetAll request
+	DefaultPageSize = 100
+	// DefaultMaxIterations bounds the k-means refinement rounds
+	DefaultMaxIterations = 50
+	// DefaultLabelWords is the number of words in a default topic label
+	DefaultLabelWords = 3
+)
+
+// ErrMissingEmbeddings is returned when no Embedder is set and the API did
+// not return an embedding for every memory
+var ErrMissingEmbeddings = errors.New("memories have no embeddings; set an Embedder")
+
+// Embedder computes the embeddings of texts, one per text in order
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// EmbedderFunc adapts a function to the Embedder interface
+type EmbedderFunc func(ctx context.Context, texts []string) ([][]float64, error)
+
+// Embed implements Embedder
+func (f EmbedderFunc) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	return f(ctx, texts)
+}
+
+// Labeler names the topic shared by the memories of a cluster, typically by
+// prompting an LLM
+type Labeler interface {
+	Label(ctx context.Context, memories []client.Memory) (string, error)
+}
+
+// LabelerFunc adapts a function to the Labeler interface
+type LabelerFunc func(ctx context.Context, memories []client.Memory) (string, error)
+
+// Label implements Labeler
+func (f LabelerFunc) Label(ctx context.Context, memories []client.Memory) (string, error) {
+	return f(ctx, memories)
+}
+
+// Options configures Topics
+type Options struct {
+	Clusters      int      // Optional: number of topics, about the square root of half the memories when zero
+	Embedder      Embedder // Optional: embeddings are requested from the API when nil
+	Labeler       Labeler  // Optional: FrequentWords when nil
+	PageSize      int      // Optional: memories per GetAll page, DefaultPageSize when zero
+	MaxIterations int      // Optional: DefaultMaxIterations when zero
+}
+
+// Topic is one cluster of memories
+type Topic struct {
+	Label    string
+	Memories []client.Memory // Closest to the centroid first
+	Centroid []float64
+}
+
+// TopicMap is the topics of a user's memories, largest first
+type TopicMap struct {
+	UserID string
+	Total  int // Memories clustered
+	Topics []Topic
+}
+
+// Topics pulls the memories of userID, clusters them by embedding and labels
+// each cluster
+func Topics(ctx context.Context, c client.Client, userID string, options Options) (*TopicMap, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, client.NewValidationError("user_id", "user_id is required")
+	}
+
+	memories, err := fetch(ctx, c, userID, options.PageSize, options.Embedder == nil)
+	if err != nil {
+		return nil, err
+	}
+	topics := &TopicMap{UserID: userID, Total: len(memories)}
+	if len(memories) == 0 {
+		return topics, nil
+	}
+	vectors, err := embeddings(ctx, memories, options.Embedder)
+	if err != nil {
+		return nil, err
+	}
+
+	k := options.Clusters
+	if k <= 0 {
+		k = max(1, int(math.Round(math.Sqrt(float64(len(memories))/2))))
+	}
+	maxIterations := options.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = DefaultMaxIterations
+	}
+	assignments, centroids := kmeans(vectors, k, maxIterations)
+
+	members := make([][]int, len(centroids))
+	for i, cluster := range assignments {
+		members[cluster] = append(members[cluster], i)
+	}
+	labeler := options.Labeler
+	if labeler == nil {
+		labeler = FrequentWords
+	}
+	for cluster, indexes := range members {
+		if len(indexes) == 0 {
+			continue
+		}
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return distance(vectors[indexes[a]], centroids[cluster]) < distance(vectors[indexes[b]], centroids[cluster])
+		})
+		topic := Topic{Centroid: centroids[cluster]}
+		for _, i := range indexes {
+			topic.Memories = append(topic.Memories, memories[i])
+		}
+		if topic.Label, err = labeler.Label(ctx, topic.Memories); err != nil {
+			return nil, fmt.Errorf("labeling topic: %w", err)
+		}
+		topics.Topics = append(topics.Topics, topic)
+	}
+	sort.SliceStable(topics.Topics, func(a, b int) bool {
+		return len(topics.Topics[a].Memories) > len(topics.Topics[b].Memories)
+	})
+	return topics, nil
+}
+
+// fetch pages through the memories of userID, with their embeddings if
+// includeEmbeddings is set
+func fetch(ctx context.Context, c client.Client, userID string, pageSize int, includeEmbeddings bool) ([]client.Memory, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	var all []client.Memory
+	for page := 1; ; page++ {
+		opts := client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID, Page: &page, PageSize: &pageSize}}
+		if includeEmbeddings {
+			opts.IncludeEmbeddings = &includeEmbeddings
+		}
+		memories, err := c.GetAll(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, memories...)
+		if len(memories) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// embeddings returns the vector of each memory, from embedder or, when nil,
+// from the memories themselves
+func embeddings(ctx context.Context, memories []client.Memory, embedder Embedder) ([][]float64, error) {
+	var vectors [][]float64
+	if embedder == nil {
+		for _, memory := range memories {
+			if len(memory.Embedding) == 0 {
+				return nil, ErrMissingEmbeddings
+			}
+			vectors = append(vectors, memory.Embedding)
+		}
+	} else {
+		texts := make([]string, len(memories))
+		for i, memory := range memories {
+			texts[i] = memory.Text()
+		}
+		var err error
+		if vectors, err = embedder.Embed(ctx, texts); err != nil {
+			return nil, fmt.Errorf("embedding memories: %w", err)
+		}
+		if len(vectors) != len(memories) {
+			return nil, fmt.Errorf("embedder returned %d embeddings for %d memories", len(vectors), len(memories))
+		}
+	}
+	for i, vector := range vectors {
+		if len(vector) != len(vectors[0]) {
+			return nil, fmt.Errorf("embedding of memory %s has %d dimensions, want %d", memories[i].ID, len(vector), len(vectors[0]))
+		}
+	}
+	return vectors, nil
+}
+
+// stopWords are left out of FrequentWords labels
+var stopWords = map[string]bool{
+	"about": true, "also": true, "been": true, "does": true, "from": true,
+	"have": true, "likes": true, "that": true, "their": true, "they": true,
+	"this": true, "user": true, "wants": true, "were": true, "when": true,
+	"with": true, "would": true,
+}
+
+// FrequentWords labels a cluster with its DefaultLabelWords most frequent
+// words of four or more letters, ties broken alphabetically
+var FrequentWords Labeler = LabelerFunc(func(ctx context.Context, memories []client.Memory) (string, error) {
+	counts := make(map[string]int)
+	for _, memory := range memories {
+		for _, word := range strings.FieldsFunc(strings.ToLower(memory.Text()), func(r rune) bool {
+			return !unicode.IsLetter(r)
+		}) {
+			if len(word) >= 4 && !stopWords[word] {
+				counts[word]++
+			}
+		}
+	}
+	words := make([]string, 0, len(counts))
+	for word := range counts {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if counts[words[i]] != counts[words[j]] {
+			return counts[words[i]] > counts[words[j]]
+		}
+		return words[i] < words[j]
+	})
+	return strings.Join(words[:min(DefaultLabelWords, len(words))], ", "), nil
+})
@@ -0,0 +1,82 @@
+package analyze_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/murilopl/go-mem0/analyze"
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+func TestTopics(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := context.Background()
+	userID := "alice"
+	for _, text := range []string{
+		"Drinks green tea every morning", "Prefers oolong tea", "Brews tea at work",
+		"Plays chess online", "Studies chess openings",
+	} {
+		if _, err := c.Add(ctx, []client.Message{{Role: "user", Content: text}}, client.MemoryOptions{UserID: &userID}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	embedder := analyze.EmbedderFunc(func(ctx context.Context, texts []string) ([][]float64, error) {
+		vectors := make([][]float64, len(texts))
+		for i, text := range texts {
+			vectors[i] = []float64{0, 1}
+			if strings.Contains(text, "tea") {
+				vectors[i] = []float64{1, float64(i) / 100}
+			}
+		}
+		return vectors, nil
+	})
+	topics, err := analyze.Topics(ctx, c, userID, analyze.Options{Clusters: 2, Embedder: embedder, PageSize: 2})
+	if err != nil {
+		t.Fatalf("Topics() error = %v", err)
+	}
+	if topics.Total != 5 || len(topics.Topics) != 2 {
+		t.Fatalf("topics = %+v, want 5 memories in 2 topics", topics)
+	}
+	if len(topics.Topics[0].Memories) != 3 || topics.Topics[0].Label != "brews, drinks, every" {
+		t.Errorf("first topic = %q with %d memories, want the 3 tea memories", topics.Topics[0].Label, len(topics.Topics[0].Memories))
+	}
+	if topics.Topics[1].Label != "chess, online, openings" {
+		t.Errorf("second topic label = %q", topics.Topics[1].Label)
+	}
+
+	// Without an Embedder the API's embeddings are used
+	topics, err = analyze.Topics(ctx, c, userID, analyze.Options{})
+	if err != nil {
+		t.Fatalf("Topics() with API embeddings error = %v", err)
+	}
+	clustered := 0
+	for _, topic := range topics.Topics {
+		clustered += len(topic.Memories)
+	}
+	if clustered != 5 {
+		t.Errorf("%d memories clustered, want 5", clustered)
+	}
+}
+
+func TestTopicsMissingEmbeddings(t *testing.T) {
+	text := "Likes tea"
+	mock := &clienttest.MockClient{
+		GetAllFunc: func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+			return []client.Memory{{ID: "mem-1", Memory: &text}}, nil
+		},
+	}
+	if _, err := analyze.Topics(context.Background(), mock, "alice", analyze.Options{}); !errors.Is(err, analyze.ErrMissingEmbeddings) {
+		t.Errorf("Topics() error = %v, want ErrMissingEmbeddings", err)
+	}
+}
@@ -0,0 +1,83 @@
+package analyze
+
+import "math"
+
+// kmeans partitions vectors into k clusters and returns the cluster of each
+// vector and the centroids. Centroids start from the first vector and then
+// the vector farthest from those already chosen, so the result is
+// deterministic.
+func kmeans(vectors [][]float64, k, maxIterations int) ([]int, [][]float64) {
+	k = min(k, len(vectors))
+	centroids := make([][]float64, 0, k)
+	centroids = append(centroids, clone(vectors[0]))
+	for len(centroids) < k {
+		farthest, farthestDistance := 0, -1.0
+		for i, vector := range vectors {
+			if d := distance(vector, centroids[nearest(vector, centroids)]); d > farthestDistance {
+				farthest, farthestDistance = i, d
+			}
+		}
+		centroids = append(centroids, clone(vectors[farthest]))
+	}
+
+	assignments := make([]int, len(vectors))
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		changed := iteration == 0
+		for i, vector := range vectors {
+			if cluster := nearest(vector, centroids); cluster != assignments[i] {
+				assignments[i] = cluster
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		counts := make([]int, k)
+		sums := make([][]float64, k)
+		for i := range sums {
+			sums[i] = make([]float64, len(vectors[0]))
+		}
+		for i, vector := range vectors {
+			counts[assignments[i]]++
+			for j, v := range vector {
+				sums[assignments[i]][j] += v
+			}
+		}
+		for i := range centroids {
+			if counts[i] == 0 {
+				continue // keep the centroid of an emptied cluster
+			}
+			for j := range sums[i] {
+				sums[i][j] /= float64(counts[i])
+			}
+			centroids[i] = sums[i]
+		}
+	}
+	return assignments, centroids
+}
+
+// nearest returns the index of the centroid closest to vector
+func nearest(vector []float64, centroids [][]float64) int {
+	best, bestDistance := 0, math.Inf(1)
+	for i, centroid := range centroids {
+		if d := distance(vector, centroid); d < bestDistance {
+			best, bestDistance = i, d
+		}
+	}
+	return best
+}
+
+// distance returns the squared Euclidean distance between a and b
+func distance(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum
+}
+
+func clone(vector []float64) []float64 {
+	return append([]float64(nil), vector...)
+}
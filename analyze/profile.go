@@ -0,0 +1,191 @@
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// Default profile section names
+const (
+	SectionPreferences = "preferences"
+	SectionConstraints = "constraints"
+	SectionFacts       = "facts"
+)
+
+// ProfileSection is one part of a profile schema
+type ProfileSection struct {
+	Name        string   // Key of the section in Profile.Sections
+	Description string   // What belongs in the section, for an Extractor's prompt
+	Keywords    []string // Words that place a memory here with KeywordExtractor; a section without keywords takes the memories no other section matched
+}
+
+// ProfileSchema lists the sections of a profile
+type ProfileSchema struct {
+	Sections []ProfileSection
+}
+
+// DefaultProfileSchema sorts memories into preferences, constraints and
+// other facts
+var DefaultProfileSchema = ProfileSchema{Sections: []ProfileSection{
+	{
+		Name:        SectionConstraints,
+		Description: "Restrictions the user must not break, such as allergies, diets, budgets and accessibility needs",
+		Keywords:    []string{"allergic", "allergy", "avoid", "budget", "can't", "cannot", "intolerant", "must", "never", "vegan", "vegetarian"},
+	},
+	{
+		Name:        SectionPreferences,
+		Description: "Likes, dislikes and preferred ways of doing things",
+		Keywords:    []string{"dislike", "dislikes", "enjoy", "enjoys", "favorite", "favourite", "hate", "hates", "like", "likes", "love", "loves", "prefer", "prefers"},
+	},
+	{
+		Name:        SectionFacts,
+		Description: "Other facts about the user, such as where they live and what they do",
+	},
+}}
+
+// Validate checks that sections are named and unique
+func (s ProfileSchema) Validate() error {
+	if len(s.Sections) == 0 {
+		return client.NewValidationError("sections", "at least one section is required")
+	}
+	seen := make(map[string]bool)
+	for _, section := range s.Sections {
+		if strings.TrimSpace(section.Name) == "" {
+			return client.NewValidationError("sections", "section name cannot be empty")
+		}
+		if seen[section.Name] {
+			return client.NewValidationError("sections", fmt.Sprintf("duplicate section %q", section.Name))
+		}
+		seen[section.Name] = true
+	}
+	return nil
+}
+
+// ProfileEntry is one statement of a profile and the memories it rests on
+type ProfileEntry struct {
+	Text      string   `json:"text"`
+	MemoryIDs []string `json:"memory_ids"`
+}
+
+// Profile is a structured model of a user built from their memories
+type Profile struct {
+	UserID      string                    `json:"user_id"`
+	Sections    map[string][]ProfileEntry `json:"sections"` // Keyed by section name; every schema section is present
+	GeneratedAt time.Time                 `json:"generated_at"`
+}
+
+// Extractor sorts memories into the sections of a schema, typically by
+// prompting an LLM with the section descriptions. Each entry must cite the
+// IDs of the memories it was drawn from.
+type Extractor interface {
+	Extract(ctx context.Context, memories []client.Memory, schema ProfileSchema) (map[string][]ProfileEntry, error)
+}
+
+// ExtractorFunc adapts a function to the Extractor interface
+type ExtractorFunc func(ctx context.Context, memories []client.Memory, schema ProfileSchema) (map[string][]ProfileEntry, error)
+
+// Extract implements Extractor
+func (f ExtractorFunc) Extract(ctx context.Context, memories []client.Memory, schema ProfileSchema) (map[string][]ProfileEntry, error) {
+	return f(ctx, memories, schema)
+}
+
+// KeywordExtractor places each memory, verbatim, in the first section with a
+// keyword among its words, or else in the first section without keywords
+var KeywordExtractor Extractor = ExtractorFunc(func(ctx context.Context, memories []client.Memory, schema ProfileSchema) (map[string][]ProfileEntry, error) {
+	entries := make(map[string][]ProfileEntry)
+	for _, memory := range memories {
+		text := memory.Text()
+		if section, ok := keywordSection(text, schema); ok {
+			entries[section] = append(entries[section], ProfileEntry{Text: text, MemoryIDs: []string{memory.ID}})
+		}
+	}
+	return entries, nil
+})
+
+// keywordSection returns the section text belongs in, if any
+func keywordSection(text string, schema ProfileSchema) (string, bool) {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r != '\'' && !('a' <= r && r <= 'z')
+	}) {
+		words[word] = true
+	}
+	fallback, hasFallback := "", false
+	for _, section := range schema.Sections {
+		if len(section.Keywords) == 0 && !hasFallback {
+			fallback, hasFallback = section.Name, true
+		}
+		for _, keyword := range section.Keywords {
+			if words[strings.ToLower(keyword)] {
+				return section.Name, true
+			}
+		}
+	}
+	return fallback, hasFallback
+}
+
+// ProfileOptions configures BuildProfile
+type ProfileOptions struct {
+	Extractor Extractor // Optional: KeywordExtractor when nil
+	PageSize  int       // Optional: memories per GetAll page, DefaultPageSize when zero
+}
+
+// BuildProfile pulls the memories of userID and sorts them into the sections
+// of schema. Citations of memories the user does not have are dropped, as
+// are entries left without citations and sections not in schema, so every
+// entry can be traced back to the user's memories.
+func BuildProfile(ctx context.Context, c client.Client, userID string, schema ProfileSchema, options ProfileOptions) (*Profile, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, client.NewValidationError("user_id", "user_id is required")
+	}
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+
+	memories, err := fetch(ctx, c, userID, options.PageSize, false)
+	if err != nil {
+		return nil, err
+	}
+	profile := &Profile{UserID: userID, Sections: make(map[string][]ProfileEntry), GeneratedAt: time.Now().UTC()}
+	for _, section := range schema.Sections {
+		profile.Sections[section.Name] = []ProfileEntry{}
+	}
+	if len(memories) == 0 {
+		return profile, nil
+	}
+
+	extractor := options.Extractor
+	if extractor == nil {
+		extractor = KeywordExtractor
+	}
+	extracted, err := extractor.Extract(ctx, memories, schema)
+	if err != nil {
+		return nil, fmt.Errorf("extracting profile: %w", err)
+	}
+
+	known := make(map[string]bool, len(memories))
+	for _, memory := range memories {
+		known[memory.ID] = true
+	}
+	for name, entries := range extracted {
+		if _, ok := profile.Sections[name]; !ok {
+			continue
+		}
+		for _, entry := range entries {
+			var cited []string
+			for _, id := range entry.MemoryIDs {
+				if known[id] {
+					cited = append(cited, id)
+				}
+			}
+			if len(cited) > 0 && strings.TrimSpace(entry.Text) != "" {
+				profile.Sections[name] = append(profile.Sections[name], ProfileEntry{Text: entry.Text, MemoryIDs: cited})
+			}
+		}
+	}
+	return profile, nil
+}
@@ -0,0 +1,81 @@
+package analyze_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/murilopl/go-mem0/analyze"
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+func TestBuildProfile(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := context.Background()
+	userID := "alice"
+	for _, text := range []string{"Loves Italian food", "Is allergic to peanuts", "Lives in Lisbon"} {
+		if _, err := c.Add(ctx, []client.Message{{Role: "user", Content: text}}, client.MemoryOptions{UserID: &userID}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	ids := map[string]string{}
+	for _, memory := range srv.Memories() {
+		ids[memory.Text()] = memory.ID
+	}
+
+	profile, err := analyze.BuildProfile(ctx, c, userID, analyze.DefaultProfileSchema, analyze.ProfileOptions{})
+	if err != nil {
+		t.Fatalf("BuildProfile() error = %v", err)
+	}
+	for section, text := range map[string]string{
+		analyze.SectionPreferences: "Loves Italian food",
+		analyze.SectionConstraints: "Is allergic to peanuts",
+		analyze.SectionFacts:       "Lives in Lisbon",
+	} {
+		entries := profile.Sections[section]
+		if len(entries) != 1 || entries[0].Text != text || entries[0].MemoryIDs[0] != ids[text] {
+			t.Errorf("%s = %+v, want %q citing %s", section, entries, text, ids[text])
+		}
+	}
+
+	// Entries citing unknown memories and unknown sections are dropped
+	extractor := analyze.ExtractorFunc(func(ctx context.Context, memories []client.Memory, schema analyze.ProfileSchema) (map[string][]analyze.ProfileEntry, error) {
+		return map[string][]analyze.ProfileEntry{
+			analyze.SectionPreferences: {
+				{Text: "Enjoys Mediterranean cuisine", MemoryIDs: []string{ids["Loves Italian food"], "mem-404"}},
+				{Text: "Likes sushi", MemoryIDs: []string{"mem-404"}},
+			},
+			"hobbies": {{Text: "Surfs", MemoryIDs: []string{ids["Lives in Lisbon"]}}},
+		}, nil
+	})
+	profile, err = analyze.BuildProfile(ctx, c, userID, analyze.DefaultProfileSchema, analyze.ProfileOptions{Extractor: extractor})
+	if err != nil {
+		t.Fatalf("BuildProfile() with extractor error = %v", err)
+	}
+	preferences := profile.Sections[analyze.SectionPreferences]
+	if len(preferences) != 1 || len(preferences[0].MemoryIDs) != 1 || preferences[0].MemoryIDs[0] != ids["Loves Italian food"] {
+		t.Errorf("preferences = %+v, want only the cited entry with its known citation", preferences)
+	}
+	if _, ok := profile.Sections["hobbies"]; ok {
+		t.Error("section outside the schema was kept")
+	}
+	if facts, ok := profile.Sections[analyze.SectionFacts]; !ok || len(facts) != 0 {
+		t.Errorf("facts = %v, want an empty section", facts)
+	}
+}
+
+func TestBuildProfileValidation(t *testing.T) {
+	schema := analyze.ProfileSchema{Sections: []analyze.ProfileSection{{Name: "a"}, {Name: "a"}}}
+	_, err := analyze.BuildProfile(context.Background(), nil, "alice", schema, analyze.ProfileOptions{})
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("BuildProfile() error = %v, want a ValidationError for the duplicate section", err)
+	}
+}
@@ -0,0 +1,304 @@
+// Package audit records every write made through a client.Client. Each Add,
+// Update, Delete, revert, batch, user deletion and project settings call
+// emits a Record of who made it, what it touched, when, and a hash of its
+// payload to a Sink, such as a file, a Kafka topic or a webhook.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// Operations reported in Record.Operation
+const (
+	OperationAdd         = "add"
+	OperationUpdate      = "update"
+	OperationRevert      = "revert"
+	OperationDelete      = "delete"
+	OperationDeleteAll   = "delete_all"
+	OperationBatchUpdate = "batch_update"
+	OperationBatchDelete = "batch_delete"
+	OperationDeleteUser  = "delete_user"
+	OperationDeleteUsers = "delete_users"
+	OperationSetProject  = "set_project"
+)
+
+// Record describes one write. The payload itself is not recorded, only its
+// SHA-256 hash, so records hold no memory content.
+type Record struct {
+	Time        time.Time `json:"time"`
+	Actor       string    `json:"actor,omitempty"`
+	Operation   string    `json:"operation"`
+	MemoryIDs   []string  `json:"memory_ids,omitempty"`
+	UserID      string    `json:"user_id,omitempty"`
+	AgentID     string    `json:"agent_id,omitempty"`
+	AppID       string    `json:"app_id,omitempty"`
+	RunID       string    `json:"run_id,omitempty"`
+	Entity      string    `json:"entity,omitempty"` // DeleteUser: entity type and numeric ID, e.g. "user/42"
+	PayloadHash string    `json:"payload_hash"`
+	Error       string    `json:"error,omitempty"` // Empty when the call succeeded
+}
+
+// Sink receives audit records
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(ctx context.Context, record Record) error
+
+// Write implements Sink
+func (f SinkFunc) Write(ctx context.Context, record Record) error {
+	return f(ctx, record)
+}
+
+type actorKey struct{}
+
+// WithActor returns a context whose writes are attributed to actor, such as
+// an end user or service account
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor set by WithActor, or the empty string
+func ActorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// DefaultSinkTimeout bounds each sink write when Options.SinkTimeout is zero
+const DefaultSinkTimeout = 10 * time.Second
+
+// Options configures an auditing Client
+type Options struct {
+	Sink        Sink                             // Required
+	Actor       func(ctx context.Context) string // Optional: ActorFrom when nil
+	OnError     func(error)                      // Optional: called when the sink fails; the write is not affected
+	SinkTimeout time.Duration                    // Optional: DefaultSinkTimeout when zero
+}
+
+// Client is a client.Client that emits a Record after every write. Reads
+// pass through unrecorded.
+type Client struct {
+	client.Client
+	sink        Sink
+	actor       func(ctx context.Context) string
+	onError     func(error)
+	sinkTimeout time.Duration
+}
+
+var _ client.Client = (*Client)(nil)
+
+// New wraps c so that its writes are audited to options.Sink
+func New(c client.Client, options Options) *Client {
+	actor := options.Actor
+	if actor == nil {
+		actor = ActorFrom
+	}
+	sinkTimeout := options.SinkTimeout
+	if sinkTimeout <= 0 {
+		sinkTimeout = DefaultSinkTimeout
+	}
+	return &Client{Client: c, sink: options.Sink, actor: actor, onError: options.OnError, sinkTimeout: sinkTimeout}
+}
+
+// Add adds memories and records their IDs
+func (c *Client) Add(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+	memories, err := c.Client.Add(ctx, messages, options...)
+	c.emit(ctx, addRecord(messages, options, memories), err)
+	return memories, err
+}
+
+// AddWithGraph adds memories and records their IDs
+func (c *Client) AddWithGraph(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) (*client.AddResult, error) {
+	result, err := c.Client.AddWithGraph(ctx, messages, options...)
+	var memories []client.Memory
+	if result != nil {
+		memories = result.Results
+	}
+	c.emit(ctx, addRecord(messages, options, memories), err)
+	return result, err
+}
+
+// AddWithResponse adds memories and records their IDs
+func (c *Client) AddWithResponse(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, *client.Response, error) {
+	memories, response, err := c.Client.AddWithResponse(ctx, messages, options...)
+	c.emit(ctx, addRecord(messages, options, memories), err)
+	return memories, response, err
+}
+
+// Update updates a memory and records it
+func (c *Client) Update(ctx context.Context, memoryID, message string) ([]client.Memory, error) {
+	memories, err := c.Client.Update(ctx, memoryID, message)
+	c.emit(ctx, Record{Operation: OperationUpdate, MemoryIDs: []string{memoryID}, PayloadHash: hash(memoryID, message)}, err)
+	return memories, err
+}
+
+// UpdateMemory updates a memory and records it
+func (c *Client) UpdateMemory(ctx context.Context, memoryID string, request client.UpdateRequest) ([]client.Memory, error) {
+	memories, err := c.Client.UpdateMemory(ctx, memoryID, request)
+	c.emit(ctx, Record{Operation: OperationUpdate, MemoryIDs: []string{memoryID}, PayloadHash: hash(memoryID, request)}, err)
+	return memories, err
+}
+
+// UpdateWithMetadata updates a memory and records it
+func (c *Client) UpdateWithMetadata(ctx context.Context, memoryID, message string, metadata map[string]interface{}) ([]client.Memory, error) {
+	memories, err := c.Client.UpdateWithMetadata(ctx, memoryID, message, metadata)
+	c.emit(ctx, Record{Operation: OperationUpdate, MemoryIDs: []string{memoryID}, PayloadHash: hash(memoryID, message, metadata)}, err)
+	return memories, err
+}
+
+// UpdateWithResponse updates a memory and records it
+func (c *Client) UpdateWithResponse(ctx context.Context, memoryID, message string) ([]client.Memory, *client.Response, error) {
+	memories, response, err := c.Client.UpdateWithResponse(ctx, memoryID, message)
+	c.emit(ctx, Record{Operation: OperationUpdate, MemoryIDs: []string{memoryID}, PayloadHash: hash(memoryID, message)}, err)
+	return memories, response, err
+}
+
+// PatchMetadata patches a memory's metadata and records it
+func (c *Client) PatchMetadata(ctx context.Context, memoryID string, patch map[string]interface{}) ([]client.Memory, error) {
+	memories, err := c.Client.PatchMetadata(ctx, memoryID, patch)
+	c.emit(ctx, Record{Operation: OperationUpdate, MemoryIDs: []string{memoryID}, PayloadHash: hash(memoryID, patch)}, err)
+	return memories, err
+}
+
+// RevertMemory reverts a memory and records it
+func (c *Client) RevertMemory(ctx context.Context, memoryID, historyID string) ([]client.Memory, error) {
+	memories, err := c.Client.RevertMemory(ctx, memoryID, historyID)
+	c.emit(ctx, Record{Operation: OperationRevert, MemoryIDs: []string{memoryID}, PayloadHash: hash(memoryID, historyID)}, err)
+	return memories, err
+}
+
+// Delete deletes a memory and records it
+func (c *Client) Delete(ctx context.Context, memoryID string) (*client.MessageResponse, error) {
+	result, err := c.Client.Delete(ctx, memoryID)
+	c.emit(ctx, Record{Operation: OperationDelete, MemoryIDs: []string{memoryID}, PayloadHash: hash(memoryID)}, err)
+	return result, err
+}
+
+// DeleteWithResponse deletes a memory and records it
+func (c *Client) DeleteWithResponse(ctx context.Context, memoryID string) (*client.MessageResponse, *client.Response, error) {
+	result, response, err := c.Client.DeleteWithResponse(ctx, memoryID)
+	c.emit(ctx, Record{Operation: OperationDelete, MemoryIDs: []string{memoryID}, PayloadHash: hash(memoryID)}, err)
+	return result, response, err
+}
+
+// DeleteAll deletes the memories matching options and records the scope
+func (c *Client) DeleteAll(ctx context.Context, options ...client.MemoryOptions) (*client.DeleteAllResult, error) {
+	result, err := c.Client.DeleteAll(ctx, options...)
+	record := Record{Operation: OperationDeleteAll, PayloadHash: hash(options)}
+	if len(options) > 0 {
+		setEntities(&record, options[0].UserID, options[0].AgentID, options[0].AppID, options[0].RunID)
+	}
+	c.emit(ctx, record, err)
+	return result, err
+}
+
+// BatchUpdate updates memories and records their IDs
+func (c *Client) BatchUpdate(ctx context.Context, memories []client.MemoryUpdateBody, opts ...client.BatchOption) (*client.BatchResult, error) {
+	result, err := c.Client.BatchUpdate(ctx, memories, opts...)
+	ids := make([]string, len(memories))
+	for i, memory := range memories {
+		ids[i] = memory.MemoryID
+	}
+	c.emit(ctx, Record{Operation: OperationBatchUpdate, MemoryIDs: ids, PayloadHash: hash(memories)}, err)
+	return result, err
+}
+
+// BatchDelete deletes memories and records their IDs
+func (c *Client) BatchDelete(ctx context.Context, memoryIDs []string, opts ...client.BatchOption) (*client.BatchResult, error) {
+	result, err := c.Client.BatchDelete(ctx, memoryIDs, opts...)
+	c.emit(ctx, Record{Operation: OperationBatchDelete, MemoryIDs: memoryIDs, PayloadHash: hash(memoryIDs)}, err)
+	return result, err
+}
+
+// DeleteUser deletes an entity and records it
+func (c *Client) DeleteUser(ctx context.Context, data client.DeleteUserData) (*client.MessageResponse, error) {
+	result, err := c.Client.DeleteUser(ctx, data)
+	entityType := data.EntityType
+	if entityType == "" {
+		entityType = client.EntityUser
+	}
+	record := Record{Operation: OperationDeleteUser, Entity: fmt.Sprintf("%s/%d", entityType, data.EntityID), PayloadHash: hash(data)}
+	c.emit(ctx, record, err)
+	return result, err
+}
+
+// DeleteUsers deletes entities and records the one named, if any
+func (c *Client) DeleteUsers(ctx context.Context, params ...client.DeleteUsersParams) (*client.DeleteUsersResult, error) {
+	result, err := c.Client.DeleteUsers(ctx, params...)
+	record := Record{Operation: OperationDeleteUsers, PayloadHash: hash(params)}
+	if len(params) > 0 {
+		setEntities(&record, params[0].UserID, params[0].AgentID, params[0].AppID, params[0].RunID)
+	}
+	c.emit(ctx, record, err)
+	return result, err
+}
+
+// SetCustomCategories replaces the project's categories and records it
+func (c *Client) SetCustomCategories(ctx context.Context, categories []client.Category) error {
+	err := c.Client.SetCustomCategories(ctx, categories)
+	c.emit(ctx, Record{Operation: OperationSetProject, PayloadHash: hash(categories)}, err)
+	return err
+}
+
+// UpdateProject changes the project's settings and records it
+func (c *Client) UpdateProject(ctx context.Context, payload client.PromptUpdatePayload) (*client.MessageResponse, error) {
+	result, err := c.Client.UpdateProject(ctx, payload)
+	c.emit(ctx, Record{Operation: OperationSetProject, PayloadHash: hash(payload)}, err)
+	return result, err
+}
+
+// addRecord builds the record of an Add call
+func addRecord(messages []client.Message, options []client.MemoryOptions, memories []client.Memory) Record {
+	record := Record{Operation: OperationAdd, PayloadHash: hash(messages, options)}
+	for _, memory := range memories {
+		record.MemoryIDs = append(record.MemoryIDs, memory.ID)
+	}
+	if len(options) > 0 {
+		setEntities(&record, options[0].UserID, options[0].AgentID, options[0].AppID, options[0].RunID)
+	}
+	return record
+}
+
+// setEntities copies the entity IDs that are set into record
+func setEntities(record *Record, userID, agentID, appID, runID *string) {
+	for _, field := range []struct {
+		dest *string
+		id   *string
+	}{{&record.UserID, userID}, {&record.AgentID, agentID}, {&record.AppID, appID}, {&record.RunID, runID}} {
+		if field.id != nil {
+			*field.dest = *field.id
+		}
+	}
+}
+
+// emit completes record and writes it to the sink. A write that went through
+// must be recorded even if the caller's context was cancelled meanwhile, so
+// the sink gets a context that keeps ctx's values but not its cancellation,
+// bounded by the sink timeout instead.
+func (c *Client) emit(ctx context.Context, record Record, err error) {
+	record.Time = time.Now().UTC()
+	record.Actor = c.actor(ctx)
+	if err != nil {
+		record.Error = err.Error()
+	}
+	sinkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.sinkTimeout)
+	defer cancel()
+	if sinkErr := c.sink.Write(sinkCtx, record); sinkErr != nil && c.onError != nil {
+		c.onError(sinkErr)
+	}
+}
+
+// hash returns the hex SHA-256 of the JSON encoding of values
+func hash(values ...interface{}) string {
+	h := sha256.New()
+	json.NewEncoder(h).Encode(values)
+	return hex.EncodeToString(h.Sum(nil))
+}
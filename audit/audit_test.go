@@ -0,0 +1,230 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/audit"
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// recorder is a Sink that keeps the records it receives
+type recorder struct {
+	records []audit.Record
+}
+
+func (r *recorder) Write(ctx context.Context, record audit.Record) error {
+	r.records = append(r.records, record)
+	return nil
+}
+
+func TestClientRecordsWrites(t *testing.T) {
+	mock := &clienttest.MockClient{
+		AddFunc: func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+			return []client.Memory{{ID: "mem-1"}, {ID: "mem-2"}}, nil
+		},
+		DeleteFunc: func(ctx context.Context, memoryID string) (*client.MessageResponse, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	sink := &recorder{}
+	audited := audit.New(mock, audit.Options{Sink: sink})
+	ctx := audit.WithActor(context.Background(), "support@example.com")
+
+	messages := []client.Message{{Role: "user", Content: "I like tea"}}
+	audited.Add(ctx, messages, client.MemoryOptions{UserID: strPtr("alex")})
+	audited.Update(ctx, "mem-1", "I like coffee")
+	audited.Delete(ctx, "mem-2")
+	audited.BatchDelete(ctx, []string{"mem-3", "mem-4"})
+	audited.DeleteUsers(ctx, client.DeleteUsersParams{AgentID: strPtr("planner")})
+	audited.DeleteUser(ctx, client.DeleteUserData{EntityID: 42})
+	audited.Get(ctx, "mem-1")
+	audited.Search(ctx, "tea")
+
+	want := []struct {
+		operation string
+		memoryIDs string
+	}{
+		{audit.OperationAdd, "mem-1,mem-2"},
+		{audit.OperationUpdate, "mem-1"},
+		{audit.OperationDelete, "mem-2"},
+		{audit.OperationBatchDelete, "mem-3,mem-4"},
+		{audit.OperationDeleteUsers, ""},
+		{audit.OperationDeleteUser, ""},
+	}
+	if len(sink.records) != len(want) {
+		t.Fatalf("records = %+v, want %d writes and no reads", sink.records, len(want))
+	}
+	for i, w := range want {
+		record := sink.records[i]
+		if record.Operation != w.operation || strings.Join(record.MemoryIDs, ",") != w.memoryIDs {
+			t.Errorf("records[%d] = %s %v, want %s %s", i, record.Operation, record.MemoryIDs, w.operation, w.memoryIDs)
+		}
+		if record.Actor != "support@example.com" || record.Time.IsZero() || len(record.PayloadHash) != 64 {
+			t.Errorf("records[%d] = %+v, want actor, time and payload hash", i, record)
+		}
+	}
+	if sink.records[0].UserID != "alex" || sink.records[4].AgentID != "planner" || sink.records[5].Entity != "user/42" {
+		t.Errorf("records = %+v, want the entities recorded", sink.records)
+	}
+	if sink.records[2].Error != "boom" || sink.records[1].Error != "" {
+		t.Errorf("records = %+v, want only the delete to fail", sink.records)
+	}
+
+	audited.Update(ctx, "mem-1", "I like coffee")
+	audited.Update(ctx, "mem-1", "I like cocoa")
+	if n := len(sink.records); sink.records[n-2].PayloadHash != sink.records[1].PayloadHash || sink.records[n-1].PayloadHash == sink.records[1].PayloadHash {
+		t.Error("PayloadHash should identify the payload")
+	}
+}
+
+func TestClientRecordsEveryWrite(t *testing.T) {
+	sink := &recorder{}
+	audited := audit.New(&clienttest.MockClient{}, audit.Options{Sink: sink})
+	ctx := context.Background()
+
+	messages := []client.Message{{Role: "user", Content: "I like tea"}}
+	audited.AddWithResponse(ctx, messages, client.MemoryOptions{UserID: strPtr("alex")})
+	audited.UpdateWithMetadata(ctx, "mem-1", "I like coffee", map[string]interface{}{"mood": "calm"})
+	audited.UpdateWithResponse(ctx, "mem-1", "I like cocoa")
+	audited.PatchMetadata(ctx, "mem-1", map[string]interface{}{"mood": "happy"})
+	audited.RevertMemory(ctx, "mem-1", "hist-1")
+	audited.DeleteWithResponse(ctx, "mem-1")
+	audited.SetCustomCategories(ctx, []client.Category{{Name: "drinks"}})
+	audited.UpdateProject(ctx, client.PromptUpdatePayload{})
+	audited.GetWithResponse(ctx, "mem-1")
+	audited.SearchCategories(ctx, "tea", []string{"drinks"})
+
+	want := []string{
+		audit.OperationAdd,
+		audit.OperationUpdate,
+		audit.OperationUpdate,
+		audit.OperationUpdate,
+		audit.OperationRevert,
+		audit.OperationDelete,
+		audit.OperationSetProject,
+		audit.OperationSetProject,
+	}
+	var got []string
+	for _, record := range sink.records {
+		got = append(got, record.Operation)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("operations = %v, want %v", got, want)
+	}
+}
+
+func TestSinkErrorsDoNotFailWrites(t *testing.T) {
+	var reported error
+	audited := audit.New(&clienttest.MockClient{}, audit.Options{
+		Sink: audit.SinkFunc(func(ctx context.Context, record audit.Record) error {
+			return errors.New("sink down")
+		}),
+		OnError: func(err error) { reported = err },
+	})
+
+	if _, err := audited.Delete(context.Background(), "mem-1"); err != nil {
+		t.Errorf("Delete() error = %v, want nil", err)
+	}
+	if reported == nil || reported.Error() != "sink down" {
+		t.Errorf("OnError got %v, want the sink error", reported)
+	}
+}
+
+func TestCancelledContextIsRecorded(t *testing.T) {
+	var sinkErr error
+	audited := audit.New(&clienttest.MockClient{}, audit.Options{
+		Sink: audit.SinkFunc(func(ctx context.Context, record audit.Record) error {
+			sinkErr = ctx.Err()
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("sink context has no deadline")
+			}
+			if audit.ActorFrom(ctx) != "alex" {
+				t.Errorf("sink context actor = %q, want the caller's", audit.ActorFrom(ctx))
+			}
+			return nil
+		}),
+		SinkTimeout: time.Minute,
+	})
+
+	ctx, cancel := context.WithCancel(audit.WithActor(context.Background(), "alex"))
+	cancel()
+	audited.Delete(ctx, "mem-1")
+	if sinkErr != nil {
+		t.Errorf("sink context error = %v, want it alive after the caller cancelled", sinkErr)
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := audit.NewWriterSink(&buf)
+	ctx := context.Background()
+	sink.Write(ctx, audit.Record{Operation: audit.OperationAdd, MemoryIDs: []string{"mem-1"}})
+	sink.Write(ctx, audit.Record{Operation: audit.OperationDelete, MemoryIDs: []string{"mem-1"}})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("output = %q, want two lines", buf.String())
+	}
+	var record audit.Record
+	if err := json.Unmarshal([]byte(lines[1]), &record); err != nil || record.Operation != audit.OperationDelete {
+		t.Errorf("line 2 = %s, want the delete record", lines[1])
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received audit.Record
+	var token string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&received)
+		if received.Operation == audit.OperationDeleteAll {
+			http.Error(w, "rejected", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	sink := audit.WebhookSink{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer secret"}}
+	ctx := context.Background()
+	if err := sink.Write(ctx, audit.Record{Operation: audit.OperationAdd, Actor: "alex"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if received.Operation != audit.OperationAdd || received.Actor != "alex" || token != "Bearer secret" {
+		t.Errorf("webhook received %+v with token %q", received, token)
+	}
+	if err := sink.Write(ctx, audit.Record{Operation: audit.OperationDeleteAll}); err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Errorf("Write() error = %v, want status 400", err)
+	}
+}
+
+type producerFunc func(ctx context.Context, key, value []byte) error
+
+func (f producerFunc) Produce(ctx context.Context, key, value []byte) error {
+	return f(ctx, key, value)
+}
+
+func TestProducerSink(t *testing.T) {
+	var key, value []byte
+	sink := audit.ProducerSink{Producer: producerFunc(func(ctx context.Context, k, v []byte) error {
+		key, value = k, v
+		return nil
+	})}
+	if err := sink.Write(context.Background(), audit.Record{Operation: audit.OperationUpdate, Actor: "alex"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if string(key) != "alex" || !strings.Contains(string(value), `"operation":"update"`) {
+		t.Errorf("produced %s = %s", key, value)
+	}
+}
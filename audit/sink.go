@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// WriterSink writes records to an io.Writer, such as an append-only file, as
+// JSON Lines. It is safe for concurrent use.
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink returns a sink that writes one JSON record per line to w
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// Write implements Sink
+func (s *WriterSink) Write(ctx context.Context, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// WebhookSink posts each record as JSON to a URL
+type WebhookSink struct {
+	URL        string
+	Headers    map[string]string // Optional: e.g. an Authorization header
+	HTTPClient *http.Client      // http.DefaultClient when nil
+}
+
+// Write implements Sink
+func (s WebhookSink) Write(ctx context.Context, record Record) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create audit webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.Headers {
+		req.Header.Set(key, value)
+	}
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("audit webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		text, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("audit webhook request failed (status %d): %s", resp.StatusCode, strings.TrimSpace(string(text)))
+	}
+	return nil
+}
+
+// Producer is the part of a message queue client that ProducerSink needs.
+// Adapt a Kafka client with a few lines, e.g. for segmentio/kafka-go:
+//
+//	type kafkaProducer struct{ *kafka.Writer }
+//
+//	func (p kafkaProducer) Produce(ctx context.Context, key, value []byte) error {
+//		return p.WriteMessages(ctx, kafka.Message{Key: key, Value: value})
+//	}
+type Producer interface {
+	Produce(ctx context.Context, key, value []byte) error
+}
+
+// ProducerSink publishes each record as JSON, keyed by its actor so that one
+// actor's records stay ordered within a partition
+type ProducerSink struct {
+	Producer Producer
+}
+
+// Write implements Sink
+func (s ProducerSink) Write(ctx context.Context, record Record) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	if err := s.Producer.Produce(ctx, []byte(record.Actor), value); err != nil {
+		return fmt.Errorf("failed to publish audit record: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Package bridge republishes memory changes onto a message broker such as
+// Kafka or NATS, so downstream services can react to them. Events come from
+// Mem0 webhooks, through Handler, or from polling, through Poll, and are
+// published as versioned Message JSON keyed by memory ID.
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// Schema identifies the layout of Message. It changes only when a field is
+// removed or changes meaning.
+const Schema = "mem0.memory_event.v1"
+
+// DefaultTopicPrefix is prepended to the event name to form the topic when
+// Options.Topic is nil, e.g. "mem0.memory_add"
+const DefaultTopicPrefix = "mem0."
+
+// Message is the published form of a memory change
+type Message struct {
+	Schema string `json:"schema"`
+	client.MemoryEvent
+}
+
+// Publisher is the part of a broker client that Bridge needs. Adapt a Kafka
+// or NATS client with a few lines, e.g. for segmentio/kafka-go:
+//
+//	type kafkaPublisher struct{ *kafka.Writer }
+//
+//	func (p kafkaPublisher) Publish(ctx context.Context, topic string, key, value []byte) error {
+//		return p.WriteMessages(ctx, kafka.Message{Topic: topic, Key: key, Value: value})
+//	}
+//
+// or for nats.go, where subjects take the place of topics:
+//
+//	type natsPublisher struct{ *nats.Conn }
+//
+//	func (p natsPublisher) Publish(ctx context.Context, subject string, key, value []byte) error {
+//		return p.Conn.Publish(subject, value)
+//	}
+type Publisher interface {
+	Publish(ctx context.Context, topic string, key, value []byte) error
+}
+
+// Options configures a Bridge
+type Options struct {
+	Publisher Publisher                       // Required
+	Topic     func(client.MemoryEvent) string // Optional: DefaultTopicPrefix + event name when nil
+	Events    []client.WebhookEvent           // Optional: events to publish, all when empty
+	OnError   func(client.MemoryEvent, error) // Optional: called when publishing fails
+}
+
+// Bridge publishes memory events to a broker
+type Bridge struct {
+	publisher Publisher
+	topic     func(client.MemoryEvent) string
+	events    map[client.WebhookEvent]bool
+	onError   func(client.MemoryEvent, error)
+}
+
+// New returns a Bridge that publishes through options.Publisher
+func New(options Options) *Bridge {
+	topic := options.Topic
+	if topic == nil {
+		topic = func(event client.MemoryEvent) string {
+			return DefaultTopicPrefix + string(event.Event)
+		}
+	}
+	var events map[client.WebhookEvent]bool
+	if len(options.Events) > 0 {
+		events = make(map[client.WebhookEvent]bool, len(options.Events))
+		for _, event := range options.Events {
+			events[event] = true
+		}
+	}
+	return &Bridge{publisher: options.Publisher, topic: topic, events: events, onError: options.OnError}
+}
+
+// Publish publishes one event, unless Options.Events excludes it
+func (b *Bridge) Publish(ctx context.Context, event client.MemoryEvent) error {
+	if b.events != nil && !b.events[event.Event] {
+		return nil
+	}
+	value, err := json.Marshal(Message{Schema: Schema, MemoryEvent: event})
+	if err != nil {
+		return fmt.Errorf("failed to encode memory event: %w", err)
+	}
+	if err := b.publisher.Publish(ctx, b.topic(event), []byte(event.Memory.ID), value); err != nil {
+		return fmt.Errorf("failed to publish %s of memory %s: %w", event.Event, event.Memory.ID, err)
+	}
+	return nil
+}
+
+// Handler returns an http.Handler to register as the Mem0 webhook URL. Each
+// delivery is published before it is acknowledged; a failed publish is
+// answered with 502 Bad Gateway so that the delivery is retried.
+func (b *Bridge) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, client.MaxWebhookBodySize))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		event, err := client.ParseWebhook(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := b.Publish(r.Context(), event); err != nil {
+			b.report(event, err)
+			http.Error(w, "failed to publish event", http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// Poll watches the memories matching options with client.WatchMemories and
+// publishes every change, until ctx is cancelled or a poll fails. Publish
+// failures are passed to Options.OnError and do not stop polling.
+func (b *Bridge) Poll(ctx context.Context, c client.Client, options client.WatchOptions) error {
+	return client.WatchMemories(ctx, c, options, func(event client.MemoryEvent) {
+		if err := b.Publish(ctx, event); err != nil {
+			b.report(event, err)
+		}
+	})
+}
+
+// report passes a publish failure to OnError
+func (b *Bridge) report(event client.MemoryEvent, err error) {
+	if b.onError != nil {
+		b.onError(event, err)
+	}
+}
@@ -0,0 +1,140 @@
+package bridge_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/bridge"
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+// published is one message sent to a fakePublisher
+type published struct {
+	topic string
+	key   string
+	msg   bridge.Message
+}
+
+type fakePublisher struct {
+	mu       sync.Mutex
+	messages []published
+	err      error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, topic string, key, value []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	var msg bridge.Message
+	if err := json.Unmarshal(value, &msg); err != nil {
+		return err
+	}
+	p.messages = append(p.messages, published{topic: topic, key: string(key), msg: msg})
+	return nil
+}
+
+func (p *fakePublisher) snapshot() []published {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]published(nil), p.messages...)
+}
+
+func TestHandlerPublishesWebhooks(t *testing.T) {
+	publisher := &fakePublisher{}
+	b := bridge.New(bridge.Options{Publisher: publisher})
+	handler := b.Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/",
+		strings.NewReader(`{"event_details": {"id": "mem-1", "data": {"memory": "Name is Alex"}, "event": "ADD"}}`)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delivery status = %d, want 204", rec.Code)
+	}
+
+	messages := publisher.snapshot()
+	if len(messages) != 1 {
+		t.Fatalf("published %d messages, want 1", len(messages))
+	}
+	got := messages[0]
+	if got.topic != "mem0.memory_add" || got.key != "mem-1" || got.msg.Schema != bridge.Schema ||
+		got.msg.Event != client.WebhookEventMemoryAdded || got.msg.Memory.Text() != "Name is Alex" {
+		t.Errorf("published %+v", got)
+	}
+
+	var reported error
+	publisher.err = errors.New("broker down")
+	b = bridge.New(bridge.Options{Publisher: publisher, OnError: func(event client.MemoryEvent, err error) { reported = err }})
+	rec = httptest.NewRecorder()
+	b.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/",
+		strings.NewReader(`{"event_details": {"id": "mem-1", "event": "DELETE"}}`)))
+	if rec.Code != http.StatusBadGateway || reported == nil {
+		t.Errorf("failed publish = %d, reported %v; want 502 and OnError", rec.Code, reported)
+	}
+}
+
+func TestPublishFiltersAndRoutes(t *testing.T) {
+	publisher := &fakePublisher{}
+	b := bridge.New(bridge.Options{
+		Publisher: publisher,
+		Events:    []client.WebhookEvent{client.WebhookEventMemoryDeleted},
+		Topic:     func(event client.MemoryEvent) string { return "memories" },
+	})
+	ctx := context.Background()
+
+	b.Publish(ctx, client.MemoryEvent{Event: client.WebhookEventMemoryAdded, Memory: client.Memory{ID: "mem-1"}})
+	b.Publish(ctx, client.MemoryEvent{Event: client.WebhookEventMemoryDeleted, Memory: client.Memory{ID: "mem-1"}})
+
+	messages := publisher.snapshot()
+	if len(messages) != 1 || messages[0].topic != "memories" || messages[0].msg.Event != client.WebhookEventMemoryDeleted {
+		t.Errorf("published %+v, want only the delete on topic memories", messages)
+	}
+}
+
+func TestPollPublishesChanges(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	memoryClient, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	userID := "alex"
+	if _, err := memoryClient.Add(context.Background(), []client.Message{{Role: "user", Content: "I like tea"}}, client.MemoryOptions{UserID: &userID}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	publisher := &fakePublisher{}
+	b := bridge.New(bridge.Options{Publisher: publisher})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Poll(ctx, memoryClient, client.WatchOptions{
+			SearchOptions:   client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID}},
+			Interval:        5 * time.Millisecond,
+			IncludeExisting: true,
+		})
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(publisher.snapshot()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Poll() error = %v, want context.Canceled", err)
+	}
+
+	messages := publisher.snapshot()
+	if len(messages) != 1 || messages[0].topic != "mem0.memory_add" {
+		t.Errorf("published %+v, want one memory_add", messages)
+	}
+}
@@ -0,0 +1,428 @@
+// Package cache adds a read-through cache in front of a client.Client. Get,
+// GetAll and Search results are cached with a TTL, and writes invalidate the
+// cached reads of the user they touch.
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+const (
+	// DefaultTTL is how long results are cached when Options.TTL is zero
+	DefaultTTL = 5 * time.Minute
+	// DefaultSize is the LRU capacity used when Options.Store is nil
+	DefaultSize = 1000
+)
+
+// Cache keys are namespaced by scope generations. Invalidating a scope
+// replaces its generation, which orphans every key built from the old one
+// without having to enumerate them; orphans age out through TTL or eviction.
+const (
+	scopeAll      = "all"      // Every cached read
+	scopeUnscoped = "unscoped" // Reads without a user ID, which may span users
+	keyPrefix     = "mem0:"
+)
+
+// Options configures a caching Client
+type Options struct {
+	Store   Store         // Optional: NewLRU(DefaultSize) when nil
+	TTL     time.Duration // Optional: DefaultTTL when zero
+	OnError func(error)   // Optional: called when the store fails; the cache is bypassed
+}
+
+// Client is a client.Client that serves Get, GetAll and Search from a cache.
+// Every method that writes memories passes through and invalidates the
+// affected entries; other methods are not cached.
+type Client struct {
+	client.Client
+	store   Store
+	ttl     time.Duration
+	onError func(error)
+}
+
+var _ client.Client = (*Client)(nil)
+
+// New wraps c with a read-through cache
+func New(c client.Client, options Options) *Client {
+	store := options.Store
+	if store == nil {
+		store = NewLRU(DefaultSize)
+	}
+	ttl := options.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Client{Client: c, store: store, ttl: ttl, onError: options.OnError}
+}
+
+// Get returns the memory from the cache, fetching it on a miss. The entry
+// depends on the scope of the memory's owner, so writes that may change or
+// remove the owner's memories, such as DeleteAll or an inferring Add, drop it.
+func (c *Client) Get(ctx context.Context, memoryID string) (*client.Memory, error) {
+	owner, _ := c.owner(ctx, memoryID)
+	key := c.key(ctx, "get", getScopes(memoryID, owner), memoryID)
+	var memory client.Memory
+	if c.lookup(ctx, key, &memory) {
+		return &memory, nil
+	}
+
+	result, err := c.Client.Get(ctx, memoryID)
+	if err != nil {
+		return nil, err
+	}
+	if result.UserID != nil && *result.UserID != owner {
+		key = c.key(ctx, "get", getScopes(memoryID, *result.UserID), memoryID)
+	}
+	c.save(ctx, key, result)
+	c.recordOwners(ctx, *result)
+	return result, nil
+}
+
+// GetAll returns the memories from the cache, fetching them on a miss
+func (c *Client) GetAll(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+	key := c.key(ctx, "get_all", readScopes(ctx, options), options)
+	var memories []client.Memory
+	if c.lookup(ctx, key, &memories) {
+		return memories, nil
+	}
+
+	result, err := c.Client.GetAll(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	c.save(ctx, key, result)
+	c.recordOwners(ctx, result...)
+	return result, nil
+}
+
+// Search returns the results from the cache, searching on a miss
+func (c *Client) Search(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+	key := c.key(ctx, "search", readScopes(ctx, options), query, options)
+	var memories []client.Memory
+	if c.lookup(ctx, key, &memories) {
+		return memories, nil
+	}
+
+	result, err := c.Client.Search(ctx, query, options...)
+	if err != nil {
+		return nil, err
+	}
+	c.save(ctx, key, result)
+	c.recordOwners(ctx, result...)
+	return result, nil
+}
+
+// Add adds memories and invalidates the cached reads of their user
+func (c *Client) Add(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+	result, err := c.Client.Add(ctx, messages, options...)
+	c.invalidate(ctx, writeScopes(ctx, options)...)
+	return result, err
+}
+
+// AddWithGraph adds memories and invalidates the cached reads of their user
+func (c *Client) AddWithGraph(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) (*client.AddResult, error) {
+	result, err := c.Client.AddWithGraph(ctx, messages, options...)
+	c.invalidate(ctx, writeScopes(ctx, options)...)
+	return result, err
+}
+
+// AddWithResponse adds memories and invalidates the cached reads of their
+// user
+func (c *Client) AddWithResponse(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, *client.Response, error) {
+	result, response, err := c.Client.AddWithResponse(ctx, messages, options...)
+	c.invalidate(ctx, writeScopes(ctx, options)...)
+	return result, response, err
+}
+
+// Update updates a memory and invalidates it and the cached reads of its user
+func (c *Client) Update(ctx context.Context, memoryID, message string) ([]client.Memory, error) {
+	result, err := c.Client.Update(ctx, memoryID, message)
+	c.invalidate(ctx, c.memoryScopes(ctx, memoryID)...)
+	return result, err
+}
+
+// UpdateMemory updates a memory and invalidates it and the cached reads of
+// its user
+func (c *Client) UpdateMemory(ctx context.Context, memoryID string, request client.UpdateRequest) ([]client.Memory, error) {
+	result, err := c.Client.UpdateMemory(ctx, memoryID, request)
+	c.invalidate(ctx, c.memoryScopes(ctx, memoryID)...)
+	return result, err
+}
+
+// UpdateWithMetadata updates a memory and invalidates it and the cached
+// reads of its user
+func (c *Client) UpdateWithMetadata(ctx context.Context, memoryID, message string, metadata map[string]interface{}) ([]client.Memory, error) {
+	result, err := c.Client.UpdateWithMetadata(ctx, memoryID, message, metadata)
+	c.invalidate(ctx, c.memoryScopes(ctx, memoryID)...)
+	return result, err
+}
+
+// UpdateWithResponse updates a memory and invalidates it and the cached
+// reads of its user
+func (c *Client) UpdateWithResponse(ctx context.Context, memoryID, message string) ([]client.Memory, *client.Response, error) {
+	result, response, err := c.Client.UpdateWithResponse(ctx, memoryID, message)
+	c.invalidate(ctx, c.memoryScopes(ctx, memoryID)...)
+	return result, response, err
+}
+
+// PatchMetadata patches a memory's metadata and invalidates it and the
+// cached reads of its user
+func (c *Client) PatchMetadata(ctx context.Context, memoryID string, patch map[string]interface{}) ([]client.Memory, error) {
+	result, err := c.Client.PatchMetadata(ctx, memoryID, patch)
+	c.invalidate(ctx, c.memoryScopes(ctx, memoryID)...)
+	return result, err
+}
+
+// RevertMemory reverts a memory and invalidates it and the cached reads of
+// its user
+func (c *Client) RevertMemory(ctx context.Context, memoryID, historyID string) ([]client.Memory, error) {
+	result, err := c.Client.RevertMemory(ctx, memoryID, historyID)
+	c.invalidate(ctx, c.memoryScopes(ctx, memoryID)...)
+	return result, err
+}
+
+// Delete deletes a memory and invalidates it and the cached reads of its user
+func (c *Client) Delete(ctx context.Context, memoryID string) (*client.MessageResponse, error) {
+	result, err := c.Client.Delete(ctx, memoryID)
+	c.invalidate(ctx, c.memoryScopes(ctx, memoryID)...)
+	return result, err
+}
+
+// DeleteWithResponse deletes a memory and invalidates it and the cached reads
+// of its user
+func (c *Client) DeleteWithResponse(ctx context.Context, memoryID string) (*client.MessageResponse, *client.Response, error) {
+	result, response, err := c.Client.DeleteWithResponse(ctx, memoryID)
+	c.invalidate(ctx, c.memoryScopes(ctx, memoryID)...)
+	return result, response, err
+}
+
+// DeleteAll deletes memories and invalidates the cached reads of their user
+func (c *Client) DeleteAll(ctx context.Context, options ...client.MemoryOptions) (*client.DeleteAllResult, error) {
+	result, err := c.Client.DeleteAll(ctx, options...)
+	c.invalidate(ctx, writeScopes(ctx, options)...)
+	return result, err
+}
+
+// BatchUpdate updates memories and invalidates the whole cache
+func (c *Client) BatchUpdate(ctx context.Context, memories []client.MemoryUpdateBody, opts ...client.BatchOption) (*client.BatchResult, error) {
+	result, err := c.Client.BatchUpdate(ctx, memories, opts...)
+	c.invalidate(ctx, scopeAll)
+	return result, err
+}
+
+// BatchDelete deletes memories and invalidates the whole cache
+func (c *Client) BatchDelete(ctx context.Context, memoryIDs []string, opts ...client.BatchOption) (*client.BatchResult, error) {
+	result, err := c.Client.BatchDelete(ctx, memoryIDs, opts...)
+	c.invalidate(ctx, scopeAll)
+	return result, err
+}
+
+// DeleteUser deletes an entity and invalidates the whole cache
+func (c *Client) DeleteUser(ctx context.Context, data client.DeleteUserData) (*client.MessageResponse, error) {
+	result, err := c.Client.DeleteUser(ctx, data)
+	c.invalidate(ctx, scopeAll)
+	return result, err
+}
+
+// DeleteUsers deletes entities and invalidates the whole cache
+func (c *Client) DeleteUsers(ctx context.Context, params ...client.DeleteUsersParams) (*client.DeleteUsersResult, error) {
+	result, err := c.Client.DeleteUsers(ctx, params...)
+	c.invalidate(ctx, scopeAll)
+	return result, err
+}
+
+// Invalidate drops every cached read of userID, or the whole cache when
+// userID is empty. Use it after changing memories outside this Client.
+func (c *Client) Invalidate(ctx context.Context, userID string) {
+	if userID == "" {
+		c.invalidate(ctx, scopeAll)
+		return
+	}
+	c.invalidate(ctx, userScope(userID), scopeUnscoped)
+}
+
+// readScopes returns the scopes a GetAll or Search result depends on
+func readScopes(ctx context.Context, options []client.SearchOptions) []string {
+	var userID *string
+	if len(options) > 0 {
+		userID = options[0].UserID
+	}
+	if userID, ok := effectiveUserID(ctx, userID); ok {
+		return []string{scopeAll, userScope(userID)}
+	}
+	return []string{scopeAll, scopeUnscoped}
+}
+
+// writeScopes returns the scopes invalidated by a write with options
+func writeScopes(ctx context.Context, options []client.MemoryOptions) []string {
+	var userID *string
+	if len(options) > 0 {
+		userID = options[0].UserID
+	}
+	if userID, ok := effectiveUserID(ctx, userID); ok {
+		return []string{userScope(userID), scopeUnscoped}
+	}
+	return []string{scopeAll}
+}
+
+// effectiveUserID returns the user a call acts for: the user ID of its
+// options, or else that of the tenant set on ctx
+func effectiveUserID(ctx context.Context, userID *string) (string, bool) {
+	if userID != nil {
+		return *userID, true
+	}
+	if tenant, ok := client.TenantFrom(ctx); ok && tenant.UserID != "" {
+		return tenant.UserID, true
+	}
+	return "", false
+}
+
+// getScopes returns the scopes a Get result depends on: the memory's and,
+// once known, its owner's
+func getScopes(memoryID, owner string) []string {
+	if owner == "" {
+		return []string{scopeAll, memoryScope(memoryID)}
+	}
+	return []string{scopeAll, memoryScope(memoryID), userScope(owner)}
+}
+
+// memoryScopes returns the scopes invalidated by a write to one memory. The
+// whole cache is dropped when the memory's owner hasn't been seen.
+func (c *Client) memoryScopes(ctx context.Context, memoryID string) []string {
+	owner, found := c.owner(ctx, memoryID)
+	if !found {
+		return []string{scopeAll}
+	}
+	return []string{memoryScope(memoryID), userScope(owner), scopeUnscoped}
+}
+
+// owner returns the recorded user of a memory
+func (c *Client) owner(ctx context.Context, memoryID string) (string, bool) {
+	owner, found, err := c.store.Get(ctx, ownerKey(memoryID))
+	if err != nil {
+		c.report(err)
+	}
+	return string(owner), found
+}
+
+func userScope(userID string) string {
+	return "user:" + userID
+}
+
+func memoryScope(memoryID string) string {
+	return "memory:" + memoryID
+}
+
+func ownerKey(memoryID string) string {
+	return keyPrefix + "owner:" + memoryID
+}
+
+func generationKey(scope string) string {
+	return keyPrefix + "gen:" + scope
+}
+
+// key derives the cache key of a read from the generations of its scopes,
+// the tenant set on ctx and its arguments. The tenant's organization,
+// project and entity IDs reach the API without appearing in the arguments,
+// so reads of different tenants never share a key.
+func (c *Client) key(ctx context.Context, operation string, scopes []string, args ...interface{}) string {
+	hash := sha256.New()
+	for _, scope := range scopes {
+		fmt.Fprintf(hash, "%s=%s;", scope, c.generation(ctx, scope))
+	}
+	tenant, _ := client.TenantFrom(ctx)
+	encoded, _ := json.Marshal(tenant)
+	hash.Write(encoded)
+	encoded, _ = json.Marshal(args)
+	hash.Write(encoded)
+	return keyPrefix + operation + ":" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// generation returns the current generation of scope, starting a new one
+// when none is stored
+func (c *Client) generation(ctx context.Context, scope string) string {
+	value, found, err := c.store.Get(ctx, generationKey(scope))
+	if err != nil {
+		c.report(err)
+	}
+	if found {
+		return string(value)
+	}
+	return c.newGeneration(ctx, scope)
+}
+
+// newGeneration stores and returns a fresh generation for scope
+func (c *Client) newGeneration(ctx context.Context, scope string) string {
+	buf := make([]byte, 8)
+	rand.Read(buf)
+	generation := hex.EncodeToString(buf)
+	if err := c.store.Set(ctx, generationKey(scope), []byte(generation), 0); err != nil {
+		c.report(err)
+	}
+	return generation
+}
+
+// invalidate starts new generations for scopes
+func (c *Client) invalidate(ctx context.Context, scopes ...string) {
+	for _, scope := range scopes {
+		c.newGeneration(ctx, scope)
+	}
+}
+
+// lookup decodes the cached value of key into dest and reports whether it
+// was found
+func (c *Client) lookup(ctx context.Context, key string, dest interface{}) bool {
+	data, found, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.report(err)
+		return false
+	}
+	if !found {
+		return false
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		c.report(fmt.Errorf("failed to decode cached %s: %w", key, err))
+		return false
+	}
+	return true
+}
+
+// save caches value under key
+func (c *Client) save(ctx context.Context, key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.report(fmt.Errorf("failed to encode %s for cache: %w", key, err))
+		return
+	}
+	if err := c.store.Set(ctx, key, data, c.ttl); err != nil {
+		c.report(err)
+	}
+}
+
+// recordOwners remembers the user of each memory so that writes to it only
+// invalidate that user's reads
+func (c *Client) recordOwners(ctx context.Context, memories ...client.Memory) {
+	for _, memory := range memories {
+		if memory.ID == "" || memory.UserID == nil {
+			continue
+		}
+		if err := c.store.Set(ctx, ownerKey(memory.ID), []byte(*memory.UserID), c.ttl); err != nil {
+			c.report(err)
+		}
+	}
+}
+
+// report passes a store error to OnError
+func (c *Client) report(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
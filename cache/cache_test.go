@@ -0,0 +1,323 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/cache"
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func newMock() *clienttest.MockClient {
+	return &clienttest.MockClient{
+		GetFunc: func(ctx context.Context, memoryID string) (*client.Memory, error) {
+			return &client.Memory{ID: memoryID, Memory: strPtr("likes tea"), UserID: strPtr("alice")}, nil
+		},
+		SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+			userID := *options[0].UserID
+			return []client.Memory{{ID: "mem-" + userID, Memory: strPtr("likes tea"), UserID: &userID}}, nil
+		},
+		GetAllFunc: func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+			return []client.Memory{{ID: "mem-1", Memory: strPtr("likes tea")}}, nil
+		},
+	}
+}
+
+func userSearch(userID string) client.SearchOptions {
+	return client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID}}
+}
+
+func TestReadThrough(t *testing.T) {
+	mock := newMock()
+	cached := cache.New(mock, cache.Options{})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		results, err := cached.Search(ctx, "tea", userSearch("alice"))
+		if err != nil || len(results) != 1 || results[0].Text() != "likes tea" {
+			t.Fatalf("Search() = %v, %v", results, err)
+		}
+		if _, err := cached.Get(ctx, "mem-1"); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if _, err := cached.GetAll(ctx); err != nil {
+			t.Fatalf("GetAll() error = %v", err)
+		}
+	}
+	if _, err := cached.Search(ctx, "coffee", userSearch("alice")); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if got := len(mock.CallsTo("Search")); got != 2 {
+		t.Errorf("upstream Search calls = %d, want 2", got)
+	}
+	if got := len(mock.CallsTo("Get")); got != 1 {
+		t.Errorf("upstream Get calls = %d, want 1", got)
+	}
+	if got := len(mock.CallsTo("GetAll")); got != 1 {
+		t.Errorf("upstream GetAll calls = %d, want 1", got)
+	}
+}
+
+func TestErrorsAreNotCached(t *testing.T) {
+	mock := &clienttest.MockClient{
+		GetFunc: func(ctx context.Context, memoryID string) (*client.Memory, error) {
+			return nil, errors.New("unavailable")
+		},
+	}
+	cached := cache.New(mock, cache.Options{})
+
+	for i := 0; i < 2; i++ {
+		if _, err := cached.Get(context.Background(), "mem-1"); err == nil {
+			t.Fatal("Get() expected error")
+		}
+	}
+	if got := len(mock.CallsTo("Get")); got != 2 {
+		t.Errorf("upstream Get calls = %d, want 2", got)
+	}
+}
+
+func TestWritesInvalidateUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		write      func(ctx context.Context, c *cache.Client)
+		aliceStale bool
+		bobStale   bool
+	}{
+		{
+			name: "add for alice",
+			write: func(ctx context.Context, c *cache.Client) {
+				c.Add(ctx, []client.Message{{Role: "user", Content: "hi"}}, client.MemoryOptions{UserID: strPtr("alice")})
+			},
+			aliceStale: true,
+		},
+		{
+			name: "update known memory",
+			write: func(ctx context.Context, c *cache.Client) {
+				c.Update(ctx, "mem-alice", "likes green tea")
+			},
+			aliceStale: true,
+		},
+		{
+			name: "patch known memory's metadata",
+			write: func(ctx context.Context, c *cache.Client) {
+				c.PatchMetadata(ctx, "mem-alice", map[string]interface{}{"mood": "calm"})
+			},
+			aliceStale: true,
+		},
+		{
+			name: "revert known memory",
+			write: func(ctx context.Context, c *cache.Client) {
+				c.RevertMemory(ctx, "mem-alice", "hist-1")
+			},
+			aliceStale: true,
+		},
+		{
+			name: "add with response for bob",
+			write: func(ctx context.Context, c *cache.Client) {
+				c.AddWithResponse(ctx, []client.Message{{Role: "user", Content: "hi"}}, client.MemoryOptions{UserID: strPtr("bob")})
+			},
+			bobStale: true,
+		},
+		{
+			name: "delete unknown memory",
+			write: func(ctx context.Context, c *cache.Client) {
+				c.Delete(ctx, "mem-9")
+			},
+			aliceStale: true,
+			bobStale:   true,
+		},
+		{
+			name: "delete all for bob",
+			write: func(ctx context.Context, c *cache.Client) {
+				c.DeleteAll(ctx, client.MemoryOptions{UserID: strPtr("bob")})
+			},
+			bobStale: true,
+		},
+		{
+			name: "batch delete",
+			write: func(ctx context.Context, c *cache.Client) {
+				c.BatchDelete(ctx, []string{"mem-1"})
+			},
+			aliceStale: true,
+			bobStale:   true,
+		},
+		{
+			name: "manual invalidate",
+			write: func(ctx context.Context, c *cache.Client) {
+				c.Invalidate(ctx, "bob")
+			},
+			bobStale: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMock()
+			cached := cache.New(mock, cache.Options{})
+			ctx := context.Background()
+
+			cached.Search(ctx, "tea", userSearch("alice"))
+			cached.Search(ctx, "tea", userSearch("bob"))
+			mock.Reset()
+
+			tt.write(ctx, cached)
+			cached.Search(ctx, "tea", userSearch("alice"))
+			cached.Search(ctx, "tea", userSearch("bob"))
+
+			refetched := map[string]bool{}
+			for _, call := range mock.CallsTo("Search") {
+				options := call.Args[1].([]client.SearchOptions)
+				refetched[*options[0].UserID] = true
+			}
+			if refetched["alice"] != tt.aliceStale || refetched["bob"] != tt.bobStale {
+				t.Errorf("refetched = %v, want alice=%v bob=%v", refetched, tt.aliceStale, tt.bobStale)
+			}
+		})
+	}
+}
+
+func TestUserWritesInvalidateGet(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		write func(ctx context.Context, c *cache.Client)
+	}{
+		{"delete all", func(ctx context.Context, c *cache.Client) {
+			c.DeleteAll(ctx, client.MemoryOptions{UserID: strPtr("alice")})
+		}},
+		{"add", func(ctx context.Context, c *cache.Client) {
+			c.Add(ctx, []client.Message{{Role: "user", Content: "forget tea"}}, client.MemoryOptions{UserID: strPtr("alice")})
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMock()
+			cached := cache.New(mock, cache.Options{})
+			ctx := context.Background()
+
+			for i := 0; i < 2; i++ {
+				if _, err := cached.Get(ctx, "mem-1"); err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+			}
+			tt.write(ctx, cached)
+			cached.Get(ctx, "mem-1")
+
+			if got := len(mock.CallsTo("Get")); got != 2 {
+				t.Errorf("upstream Get calls = %d, want a hit before the write and a miss after it", got)
+			}
+		})
+	}
+}
+
+func TestWritesInvalidateUnscopedReads(t *testing.T) {
+	mock := newMock()
+	cached := cache.New(mock, cache.Options{})
+	ctx := context.Background()
+
+	cached.GetAll(ctx)
+	cached.Add(ctx, []client.Message{{Role: "user", Content: "hi"}}, client.MemoryOptions{UserID: strPtr("alice")})
+	cached.GetAll(ctx)
+
+	if got := len(mock.CallsTo("GetAll")); got != 2 {
+		t.Errorf("upstream GetAll calls = %d, want 2", got)
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("store down")
+}
+
+func (failingStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("store down")
+}
+
+func TestStoreErrorsBypassCache(t *testing.T) {
+	var reported int
+	mock := newMock()
+	cached := cache.New(mock, cache.Options{Store: failingStore{}, OnError: func(error) { reported++ }})
+
+	results, err := cached.Search(context.Background(), "tea", userSearch("alice"))
+	if err != nil || len(results) != 1 {
+		t.Fatalf("Search() = %v, %v", results, err)
+	}
+	if reported == 0 {
+		t.Error("OnError was not called")
+	}
+}
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	lru := cache.NewLRU(2)
+
+	lru.Set(ctx, "a", []byte("1"), 0)
+	lru.Set(ctx, "b", []byte("2"), 0)
+	lru.Get(ctx, "a")
+	lru.Set(ctx, "c", []byte("3"), 0)
+
+	if _, found, _ := lru.Get(ctx, "b"); found {
+		t.Error("least recently used entry b was not evicted")
+	}
+	if value, found, _ := lru.Get(ctx, "a"); !found || string(value) != "1" {
+		t.Errorf("Get(a) = %q, %v", value, found)
+	}
+	if lru.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", lru.Len())
+	}
+
+	lru.Set(ctx, "d", []byte("4"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, found, _ := lru.Get(ctx, "d"); found {
+		t.Error("expired entry d was returned")
+	}
+}
+
+func TestTenantsDoNotShareEntries(t *testing.T) {
+	mock := &clienttest.MockClient{
+		SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+			tenant, _ := client.TenantFrom(ctx)
+			return []client.Memory{{ID: "mem-" + tenant.UserID, Memory: strPtr("likes tea owned by " + tenant.UserID), UserID: strPtr(tenant.UserID)}}, nil
+		},
+		AddFunc: func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+			return nil, nil
+		},
+	}
+	cached := cache.New(mock, cache.Options{})
+	alice := client.WithTenant(context.Background(), client.Tenant{ProjectID: "proj-a", UserID: "alice"})
+	bob := client.WithTenant(context.Background(), client.Tenant{ProjectID: "proj-b", UserID: "bob"})
+	options := client.SearchOptions{}
+
+	for _, tc := range []struct {
+		ctx  context.Context
+		want string
+	}{{alice, "likes tea owned by alice"}, {bob, "likes tea owned by bob"}, {alice, "likes tea owned by alice"}} {
+		results, err := cached.Search(tc.ctx, "tea", options)
+		if err != nil || len(results) != 1 || results[0].Text() != tc.want {
+			t.Fatalf("Search() = %v, %v; want %q", results, err, tc.want)
+		}
+	}
+	if got := len(mock.CallsTo("Search")); got != 2 {
+		t.Errorf("upstream Search calls = %d, want one per tenant", got)
+	}
+
+	// A write for alice's tenant invalidates her reads but not bob's
+	if _, err := cached.Add(alice, []client.Message{{Role: "user", Content: "I like coffee"}}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	for _, ctx := range []context.Context{alice, bob} {
+		if _, err := cached.Search(ctx, "tea", options); err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+	}
+	if got := len(mock.CallsTo("Search")); got != 3 {
+		t.Errorf("upstream Search calls after alice's Add = %d, want 3", got)
+	}
+}
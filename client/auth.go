@@ -0,0 +1,70 @@
+package client
+
+import "context"
+
+// pingCall is a Ping in flight that concurrent callers wait on
+type pingCall struct {
+	done chan struct{}
+	err  error
+}
+
+// authenticate pings the server once to resolve the telemetry ID and the
+// organization and project, unless an earlier Ping already did. Concurrent
+// callers share a single Ping; a failed Ping is retried by the next call.
+func (c *MemoryClient) authenticate(ctx context.Context) error {
+	c.authMu.Lock()
+	if c.telemetryID != "" {
+		c.authMu.Unlock()
+		return nil
+	}
+	if call := c.pinging; call != nil {
+		c.authMu.Unlock()
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	call := &pingCall{done: make(chan struct{})}
+	c.pinging = call
+	c.authMu.Unlock()
+
+	call.err = c.Ping(ctx)
+
+	c.authMu.Lock()
+	c.pinging = nil
+	c.authMu.Unlock()
+	close(call.done)
+	return call.err
+}
+
+// ForceReauth drops the cached Ping result, so the next request that needs
+// the organization and project pings the server again. Call it after
+// rotating the API key. Organization and project IDs set in ClientOptions
+// are kept.
+func (c *MemoryClient) ForceReauth() {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	c.telemetryID = ""
+	if c.pingedScope {
+		c.organizationID = nil
+		c.projectID = nil
+		c.pingedScope = false
+	}
+}
+
+// scope returns the organization and project IDs requests are sent for
+func (c *MemoryClient) scope() (orgID, projectID interface{}) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.organizationID, c.projectID
+}
+
+// telemetry returns the telemetry ID resolved by Ping, if any
+func (c *MemoryClient) telemetry() string {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.telemetryID
+}
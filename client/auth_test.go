@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newPingCountingClient returns a client whose server counts pings and
+// answers every other request with an empty list
+func newPingCountingClient(t *testing.T, options ClientOptions) (*MemoryClient, *atomic.Int32) {
+	t.Helper()
+
+	var pings atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/ping/" {
+			pings.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1","user_email":"test@example.com"}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(srv.Close)
+
+	options.APIKey = "test-api-key"
+	options.Host = &srv.URL
+	client, err := NewMemoryClient(options)
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	return client, &pings
+}
+
+func TestConcurrentCallsShareOnePing(t *testing.T) {
+	client, pings := newPingCountingClient(t, ClientOptions{})
+	client.ForceReauth()
+	pings.Store(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Search(context.Background(), "tea", SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}}); err != nil {
+				t.Errorf("Search() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := pings.Load(); got != 1 {
+		t.Errorf("pings = %d, want 1", got)
+	}
+}
+
+func TestForceReauth(t *testing.T) {
+	t.Run("drops scope resolved by ping", func(t *testing.T) {
+		client, pings := newPingCountingClient(t, ClientOptions{})
+		client.ForceReauth()
+		if orgID, projectID := client.scope(); orgID != nil || projectID != nil || client.telemetry() != "" {
+			t.Errorf("scope = %v, %v after ForceReauth, want none", orgID, projectID)
+		}
+
+		client.GetAll(context.Background())
+		if got := pings.Load(); got != 2 {
+			t.Errorf("pings = %d, want 2", got)
+		}
+		if orgID, _ := client.scope(); orgID != "org-1" {
+			t.Errorf("org after re-ping = %v, want org-1", orgID)
+		}
+	})
+
+	t.Run("keeps configured scope", func(t *testing.T) {
+		client, _ := newPingCountingClient(t, ClientOptions{OrganizationID: "org-9", ProjectID: "proj-9"})
+		client.ForceReauth()
+		if orgID, projectID := client.scope(); orgID != "org-9" || projectID != "proj-9" {
+			t.Errorf("scope = %v, %v, want configured org-9, proj-9", orgID, projectID)
+		}
+	})
+}
+
+func TestMemoryIDCallsSkipPing(t *testing.T) {
+	client, pings := newPingCountingClient(t, ClientOptions{})
+	client.ForceReauth()
+	pings.Store(0)
+	ctx := context.Background()
+
+	client.Get(ctx, "mem-1")
+	client.Update(ctx, "mem-1", "text")
+	client.Delete(ctx, "mem-1")
+	client.History(ctx, "mem-1")
+
+	if got := pings.Load(); got != 0 {
+		t.Errorf("pings = %d, want 0", got)
+	}
+}
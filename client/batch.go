@@ -0,0 +1,303 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultBatchConcurrency is the number of concurrent requests batch helpers
+// make unless WithConcurrency is given
+const DefaultBatchConcurrency = 4
+
+// MaxBatchSize is the largest number of memories the API accepts in one
+// BatchUpdate or BatchDelete request
+const MaxBatchSize = 1000
+
+// BatchOption configures the batch helpers
+type BatchOption func(*batchConfig)
+
+// batchConfig holds the settings applied by BatchOption
+type batchConfig struct {
+	concurrency int
+	chunkSize   int
+	limiter     *limiter
+	progress    func(done, total int)
+}
+
+// WithConcurrency limits the number of requests in flight to n
+func WithConcurrency(n int) BatchOption {
+	return func(c *batchConfig) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithChunkSize sets how many memories BatchUpdate and BatchDelete send per
+// request. Values above MaxBatchSize are rejected by the API.
+func WithChunkSize(n int) BatchOption {
+	return func(c *batchConfig) {
+		if n > 0 {
+			c.chunkSize = n
+		}
+	}
+}
+
+// WithRateLimit spaces requests so no more than perSecond start each second
+func WithRateLimit(perSecond float64) BatchOption {
+	return func(c *batchConfig) {
+		if perSecond > 0 {
+			c.limiter = &limiter{interval: time.Duration(float64(time.Second) / perSecond)}
+		}
+	}
+}
+
+// WithProgress calls progress after each request completes with the number
+// of requests done so far and the total. BatchUpdate and BatchDelete count
+// chunks. Calls are serialized.
+func WithProgress(progress func(done, total int)) BatchOption {
+	return func(c *batchConfig) {
+		c.progress = progress
+	}
+}
+
+// newBatchConfig applies opts to the defaults
+func newBatchConfig(opts []BatchOption) batchConfig {
+	config := batchConfig{concurrency: DefaultBatchConcurrency, chunkSize: MaxBatchSize}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// Statuses of a BatchItem
+const (
+	BatchItemSucceeded = "success"
+	BatchItemFailed    = "error"
+)
+
+// BatchResult is the outcome of BatchUpdate or BatchDelete
+type BatchResult struct {
+	Chunks []BatchChunk
+	Items  []BatchItem // One per input, in input order
+}
+
+// BatchChunk is the outcome of one batch request, covering the inputs
+// [Offset, Offset+Size)
+type BatchChunk struct {
+	Offset  int
+	Size    int
+	Message string // Response message when the request succeeded
+	Err     error
+}
+
+// BatchItem is the outcome of one memory of a batch. Items of a failed
+// request share its error; items of a successful request carry the status
+// and error the API reported for them.
+type BatchItem struct {
+	MemoryID string
+	Status   string // BatchItemSucceeded or BatchItemFailed
+	Err      error
+}
+
+// Err joins the errors of the failed chunks and of the failed items of
+// successful chunks, or returns nil
+func (r *BatchResult) Err() error {
+	var errs []error
+	for _, chunk := range r.Chunks {
+		if chunk.Err != nil {
+			errs = append(errs, fmt.Errorf("items %d-%d: %w", chunk.Offset, chunk.Offset+chunk.Size-1, chunk.Err))
+			continue
+		}
+		for _, item := range r.Items[chunk.Offset : chunk.Offset+chunk.Size] {
+			if item.Err != nil {
+				errs = append(errs, fmt.Errorf("memory %s: %w", item.MemoryID, item.Err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Succeeded returns the number of inputs that were applied
+func (r *BatchResult) Succeeded() int {
+	return len(r.Items) - len(r.Failed())
+}
+
+// Failed returns the items that were not applied
+func (r *BatchResult) Failed() []BatchItem {
+	var failed []BatchItem
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// FailedIDs returns the memory IDs of the failed items, ready to retry
+func (r *BatchResult) FailedIDs() []string {
+	var ids []string
+	for _, item := range r.Failed() {
+		ids = append(ids, item.MemoryID)
+	}
+	return ids
+}
+
+// AddRequest is one Add call of AddBatch
+type AddRequest struct {
+	Messages []Message
+	Options  MemoryOptions
+}
+
+// AddBatchResult is the outcome of one AddRequest
+type AddBatchResult struct {
+	Memories []Memory
+	Err      error
+}
+
+// AddBatch runs the requests concurrently and returns their results in input
+// order. The error joins the errors of all failed requests, each prefixed
+// with its index; requests not started before ctx is cancelled fail with
+// ctx.Err().
+func AddBatch(ctx context.Context, c Client, requests []AddRequest, opts ...BatchOption) ([]AddBatchResult, error) {
+	results := make([]AddBatchResult, len(requests))
+	runBatch(ctx, len(requests), newBatchConfig(opts), func(ctx context.Context, i int) {
+		memories, err := c.Add(WithIdempotencySubKey(ctx, strconv.Itoa(i)), requests[i].Messages, requests[i].Options)
+		results[i] = AddBatchResult{Memories: memories, Err: err}
+	}, func(i int, err error) {
+		results[i].Err = err
+	})
+
+	var errs []error
+	for i, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("request %d: %w", i, result.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// MemoryUpdate is one UpdateMemory call of UpdateBatch
+type MemoryUpdate struct {
+	MemoryID string
+	Request  UpdateRequest
+}
+
+// UpdateBatchResult is the outcome of one MemoryUpdate
+type UpdateBatchResult struct {
+	MemoryID string
+	Memories []Memory
+	Err      error
+}
+
+// UpdateBatch runs the updates concurrently and returns their results in
+// input order. Unlike BatchUpdate it can change metadata, at the cost of one
+// request per memory. The error joins the errors of all failed updates;
+// updates not started before ctx is cancelled fail with ctx.Err().
+func UpdateBatch(ctx context.Context, c Client, updates []MemoryUpdate, opts ...BatchOption) ([]UpdateBatchResult, error) {
+	results := make([]UpdateBatchResult, len(updates))
+	for i, update := range updates {
+		results[i].MemoryID = update.MemoryID
+	}
+	runBatch(ctx, len(updates), newBatchConfig(opts), func(ctx context.Context, i int) {
+		results[i].Memories, results[i].Err = c.UpdateMemory(WithIdempotencySubKey(ctx, strconv.Itoa(i)), updates[i].MemoryID, updates[i].Request)
+	}, func(i int, err error) {
+		results[i].Err = err
+	})
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("memory %s: %w", result.MemoryID, result.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// runBatch calls run for indexes 0 to n-1 with at most config.concurrency
+// calls in flight. Indexes not run because ctx was cancelled are passed to
+// skip with ctx.Err().
+func runBatch(ctx context.Context, n int, config batchConfig, run func(ctx context.Context, i int), skip func(i int, err error)) {
+	sem := make(chan struct{}, config.concurrency)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	done := 0
+	for i := 0; i < n; i++ {
+		if err := acquire(ctx, sem, config.limiter); err != nil {
+			for j := i; j < n; j++ {
+				skip(j, err)
+			}
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			run(ctx, i)
+			if config.progress != nil {
+				progressMu.Lock()
+				done++
+				config.progress(done, n)
+				progressMu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// acquire takes a slot in sem and waits for the limiter
+func acquire(ctx context.Context, sem chan struct{}, l *limiter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if err := l.wait(ctx); err != nil {
+		<-sem
+		return err
+	}
+	return nil
+}
+
+// limiter spaces events at least interval apart
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next event may start. A nil limiter never blocks.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,273 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+func TestAddBatch(t *testing.T) {
+	var inFlight, maxInFlight int32
+	mock := &clienttest.MockClient{
+		AddFunc: func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+
+			content := messages[0].Content.(string)
+			if content == "fail" {
+				return nil, errors.New("boom")
+			}
+			return []client.Memory{{ID: content}}, nil
+		},
+	}
+
+	var requests []client.AddRequest
+	for _, content := range []string{"a", "b", "fail", "d", "e", "f"} {
+		requests = append(requests, client.AddRequest{
+			Messages: []client.Message{{Role: "user", Content: content}},
+			Options:  client.MemoryOptions{UserID: strPtr("alex")},
+		})
+	}
+
+	results, err := client.AddBatch(context.Background(), mock, requests, client.WithConcurrency(2))
+	if err == nil || !strings.Contains(err.Error(), "request 2: boom") {
+		t.Errorf("AddBatch() error = %v, want request 2 failure", err)
+	}
+	if len(results) != len(requests) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(requests))
+	}
+	for i, result := range results {
+		content := requests[i].Messages[0].Content.(string)
+		if content == "fail" {
+			if result.Err == nil {
+				t.Errorf("results[%d].Err = nil, want error", i)
+			}
+			continue
+		}
+		if result.Err != nil || len(result.Memories) != 1 || result.Memories[0].ID != content {
+			t.Errorf("results[%d] = %+v, want memory %s", i, result, content)
+		}
+	}
+	if maxInFlight > 2 {
+		t.Errorf("max concurrent Add calls = %d, want <= 2", maxInFlight)
+	}
+	if got := len(mock.CallsTo("Add")); got != len(requests) {
+		t.Errorf("Add calls = %d, want %d", got, len(requests))
+	}
+}
+
+func TestAddBatchRateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var starts []time.Time
+	mock := &clienttest.MockClient{
+		AddFunc: func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+			mu.Lock()
+			starts = append(starts, time.Now())
+			mu.Unlock()
+			return nil, nil
+		},
+	}
+
+	requests := make([]client.AddRequest, 4)
+	if _, err := client.AddBatch(context.Background(), mock, requests, client.WithConcurrency(4), client.WithRateLimit(50)); err != nil {
+		t.Fatalf("AddBatch() error = %v", err)
+	}
+	if elapsed := starts[len(starts)-1].Sub(starts[0]); elapsed < 50*time.Millisecond {
+		t.Errorf("4 requests at 50/s started within %v, want at least 50ms", elapsed)
+	}
+}
+
+func TestAddBatchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mock := &clienttest.MockClient{}
+
+	results, err := client.AddBatch(ctx, mock, make([]client.AddRequest, 3))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("AddBatch() error = %v, want context.Canceled", err)
+	}
+	for i, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("results[%d].Err = %v, want context.Canceled", i, result.Err)
+		}
+	}
+	if len(mock.Calls()) != 0 {
+		t.Errorf("calls = %v, want none", mock.Calls())
+	}
+}
+
+func TestBatchDeleteChunks(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	memoryClient, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	var ids []string
+	for _, content := range []string{"a", "b", "c", "d", "e"} {
+		memories, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: content}}, client.MemoryOptions{UserID: strPtr("alex")})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		ids = append(ids, memories[0].ID)
+	}
+	ids[3] = "missing"
+
+	result, err := memoryClient.BatchDelete(ctx, ids, client.WithChunkSize(2), client.WithConcurrency(2))
+	if err == nil || !strings.Contains(err.Error(), "items 2-3") {
+		t.Errorf("BatchDelete() error = %v, want failure of items 2-3", err)
+	}
+	if len(result.Chunks) != 3 {
+		t.Fatalf("Chunks = %+v, want 3", result.Chunks)
+	}
+	for i, want := range []struct{ offset, size int }{{0, 2}, {2, 2}, {4, 1}} {
+		if chunk := result.Chunks[i]; chunk.Offset != want.offset || chunk.Size != want.size {
+			t.Errorf("Chunks[%d] = %+v, want offset %d size %d", i, chunk, want.offset, want.size)
+		}
+	}
+	if result.Chunks[1].Err == nil || result.Chunks[0].Message == "" {
+		t.Errorf("Chunks = %+v", result.Chunks)
+	}
+	if got := result.Succeeded(); got != 3 {
+		t.Errorf("Succeeded() = %d, want 3", got)
+	}
+	if failed := result.FailedIDs(); len(failed) != 2 || failed[0] != ids[2] || failed[1] != "missing" {
+		t.Errorf("FailedIDs() = %v, want the IDs of items 2-3", failed)
+	}
+	for i, item := range result.Items {
+		wantStatus := client.BatchItemSucceeded
+		if i == 2 || i == 3 {
+			wantStatus = client.BatchItemFailed
+		}
+		if item.MemoryID != ids[i] || item.Status != wantStatus {
+			t.Errorf("Items[%d] = %+v, want %s %s", i, item, ids[i], wantStatus)
+		}
+	}
+	if remaining := srv.Memories(); len(remaining) != 2 {
+		t.Errorf("remaining memories = %d, want 2 (the failed chunk)", len(remaining))
+	}
+}
+
+func TestBatchUpdateSplitsAtMaxBatchSize(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	memoryClient, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	updates := make([]client.MemoryUpdateBody, client.MaxBatchSize+1)
+	result, _ := memoryClient.BatchUpdate(context.Background(), updates)
+	if len(result.Chunks) != 2 || result.Chunks[0].Size != client.MaxBatchSize || result.Chunks[1].Size != 1 {
+		t.Errorf("Chunks = %+v, want sizes %d and 1", result.Chunks, client.MaxBatchSize)
+	}
+	for _, chunk := range result.Chunks {
+		var apiErr *client.APIError
+		if !errors.As(chunk.Err, &apiErr) || apiErr.StatusCode != 404 {
+			t.Errorf("chunk at %d error = %v, want not found from the fake (not a size rejection)", chunk.Offset, chunk.Err)
+		}
+	}
+}
+
+func TestUpdateBatchProgress(t *testing.T) {
+	mock := &clienttest.MockClient{
+		UpdateMemoryFunc: func(ctx context.Context, memoryID string, request client.UpdateRequest) ([]client.Memory, error) {
+			if memoryID == "mem-2" {
+				return nil, errors.New("boom")
+			}
+			return []client.Memory{{ID: memoryID}}, nil
+		},
+	}
+	updates := []client.MemoryUpdate{{MemoryID: "mem-1"}, {MemoryID: "mem-2"}, {MemoryID: "mem-3"}}
+
+	var mu sync.Mutex
+	var seen []int
+	results, err := client.UpdateBatch(context.Background(), mock, updates, client.WithConcurrency(2), client.WithProgress(func(done, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+		if total != 3 {
+			t.Errorf("total = %d, want 3", total)
+		}
+		seen = append(seen, done)
+	}))
+	if err == nil || !strings.Contains(err.Error(), "memory mem-2: boom") {
+		t.Errorf("err = %v, want mem-2 failure", err)
+	}
+	for i, result := range results {
+		if result.MemoryID != updates[i].MemoryID {
+			t.Errorf("results[%d].MemoryID = %q, want input order", i, result.MemoryID)
+		}
+		if (result.Err != nil) != (result.MemoryID == "mem-2") {
+			t.Errorf("results[%d].Err = %v", i, result.Err)
+		}
+	}
+	if len(seen) != 3 || seen[0] != 1 || seen[1] != 2 || seen[2] != 3 {
+		t.Errorf("progress = %v, want [1 2 3]", seen)
+	}
+}
+
+func TestBatchIdempotencyKeysAreDistinct(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]bool{}
+	record := func(ctx context.Context) {
+		key, _ := client.IdempotencyKey(ctx)
+		mu.Lock()
+		keys[key] = true
+		mu.Unlock()
+	}
+	mock := &clienttest.MockClient{
+		AddFunc: func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+			record(ctx)
+			return nil, nil
+		},
+		UpdateMemoryFunc: func(ctx context.Context, memoryID string, request client.UpdateRequest) ([]client.Memory, error) {
+			record(ctx)
+			return nil, nil
+		},
+	}
+	ctx := client.WithIdempotencyKey(context.Background(), "import-42")
+
+	requests := make([]client.AddRequest, 5)
+	if _, err := client.AddBatch(ctx, mock, requests); err != nil {
+		t.Fatalf("AddBatch() error = %v", err)
+	}
+	updates := make([]client.MemoryUpdate, 5)
+	if _, err := client.UpdateBatch(client.WithIdempotencyKey(ctx, "patch-42"), mock, updates); err != nil {
+		t.Fatalf("UpdateBatch() error = %v", err)
+	}
+
+	if len(keys) != 10 {
+		t.Errorf("distinct keys = %d, want one per request: %v", len(keys), keys)
+	}
+	for _, key := range []string{"import-42-0", "import-42-4", "patch-42-0", "patch-42-4"} {
+		if !keys[key] {
+			t.Errorf("key %q not sent; got %v", key, keys)
+		}
+	}
+
+	// Retrying with the same key derives the same keys
+	before := len(keys)
+	if _, err := client.AddBatch(ctx, mock, requests); err != nil {
+		t.Fatalf("AddBatch() error = %v", err)
+	}
+	if len(keys) != before {
+		t.Errorf("retried AddBatch sent %d new keys, want none", len(keys)-before)
+	}
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// MaxCategoryNameLength is the longest category name Category.Validate accepts
+const MaxCategoryNameLength = 64
+
+// DefaultCategories names the categories the platform assigns memories to
+// in projects without custom categories
+var DefaultCategories = []string{
+	"personal_details", "family", "professional_details", "sports", "travel",
+	"food", "music", "health", "technology", "hobbies", "fashion",
+	"entertainment", "milestones", "user_preferences", "misc",
+}
+
+// GetCategories returns the project's custom categories, sorted by name. It
+// returns none when the project uses DefaultCategories.
+func (c *MemoryClient) GetCategories(ctx context.Context) ([]Category, error) {
+	path, err := c.projectPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.fetchWithErrorHandling(ctx, "GET", path+"?fields=custom_categories", nil)
+	if err != nil {
+		return nil, err
+	}
+	project, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected project response type %T", response)
+	}
+	return parseCategories(project["custom_categories"])
+}
+
+// SetCustomCategories replaces the project's custom categories. An empty
+// list restores DefaultCategories.
+func (c *MemoryClient) SetCustomCategories(ctx context.Context, categories []Category) error {
+	seen := make(map[string]bool, len(categories))
+	payload := make([]map[string]interface{}, 0, len(categories))
+	for _, category := range categories {
+		if err := category.Validate(); err != nil {
+			return err
+		}
+		if seen[category.Name] {
+			return NewValidationError("name", fmt.Sprintf("duplicate category %s", category.Name))
+		}
+		seen[category.Name] = true
+		payload = append(payload, map[string]interface{}{category.Name: category.Description})
+	}
+
+	_, err := c.UpdateProject(ctx, PromptUpdatePayload{CustomCategories: payload})
+	return err
+}
+
+// SearchCategories searches only memories in the given categories. The
+// categories are checked against the project's before the search is sent,
+// so a misspelt category fails instead of matching nothing.
+func (c *MemoryClient) SearchCategories(ctx context.Context, query string, categories []string, options ...SearchOptions) ([]Memory, error) {
+	if len(categories) == 0 {
+		return nil, NewValidationError("categories", "at least one category is required")
+	}
+
+	custom, err := c.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool)
+	for _, category := range custom {
+		known[category.Name] = true
+	}
+	if len(custom) == 0 {
+		for _, name := range DefaultCategories {
+			known[name] = true
+		}
+	}
+	for _, name := range categories {
+		if !known[name] {
+			return nil, NewValidationError("categories", fmt.Sprintf("unknown category %q", name))
+		}
+	}
+
+	opts := SearchOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	opts.Categories = categories
+	return c.Search(ctx, query, opts)
+}
+
+// parseCategories reads custom categories, which the API returns as a list
+// of single-entry name to description objects
+func parseCategories(value interface{}) ([]Category, error) {
+	if value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected custom_categories type %T", value)
+	}
+
+	var categories []Category
+	for _, item := range items {
+		switch item := item.(type) {
+		case map[string]interface{}:
+			for name, description := range item {
+				text, _ := description.(string)
+				categories = append(categories, Category{Name: name, Description: text})
+			}
+		case string:
+			categories = append(categories, Category{Name: item})
+		default:
+			return nil, fmt.Errorf("unexpected custom category type %T", item)
+		}
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
@@ -3,28 +3,48 @@ package client
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
+// DefaultTimeout bounds each request unless ClientOptions.Timeout is set
+const DefaultTimeout = 60 * time.Second
+
 // ClientOptions represents configuration options for the MemoryClient
 type ClientOptions struct {
-	APIKey           string      `json:"apiKey"`
-	Host             *string     `json:"host,omitempty"`
-	OrganizationName *string     `json:"organizationName,omitempty"` // Deprecated
-	ProjectName      *string     `json:"projectName,omitempty"`      // Deprecated
-	OrganizationID   interface{} `json:"organizationId,omitempty"`   // string or number
-	ProjectID        interface{} `json:"projectId,omitempty"`        // string or number
+	APIKey           string              `json:"apiKey"`
+	Host             *string             `json:"host,omitempty"`
+	OrganizationName *string             `json:"organizationName,omitempty"` // Deprecated
+	ProjectName      *string             `json:"projectName,omitempty"`      // Deprecated
+	OrganizationID   interface{}         `json:"organizationId,omitempty"`   // string or number
+	ProjectID        interface{}         `json:"projectId,omitempty"`        // string or number
+	HTTPClient       *http.Client        `json:"-"`                          // Optional: custom HTTP client
+	MetadataSchema   *MetadataSchema     `json:"-"`                          // Optional: checks metadata on Add and Search
+	Transport        *TransportOptions   `json:"-"`                          // Optional: connection pool and HTTP/2 tuning
+	Timeout          time.Duration       `json:"-"`                          // Optional: per-request timeout, DefaultTimeout when zero
+	TokenSource      TokenSource         `json:"-"`                          // Optional: supplies the API key per request instead of APIKey
+	Credentials      CredentialsProvider `json:"-"`                          // Optional: looks up the API key once when APIKey is empty
+	AuthScheme       string              `json:"-"`                          // Optional: AuthSchemeToken (default) or AuthSchemeBearer
+	AuthHeader       string              `json:"-"`                          // Optional: header carrying the key instead of Authorization; the key is sent bare unless AuthScheme is set
+	DedupeSearches   bool                `json:"-"`                          // Optional: identical concurrent searches share one request
+	Defaults         *MemoryOptions      `json:"-"`                          // Optional: applied under the options of every Add, Search, GetAll and DeleteAll call
+	CheckVersion     bool                `json:"-"`                          // Optional: warn at startup when the server requires a newer SDK
+	Codec            Codec               `json:"-"`                          // Optional: JSON encoder and decoder, StdCodec when nil
+	Metrics          MetricsHook         `json:"-"`                          // Optional: receives the connection statistics of each request
+	DetectBodyLeaks  bool                `json:"-"`                          // Optional: warn when a response body is closed before being read to the end
 }
 
 // MemoryClient represents the main client for interacting with the Mem0 API
 type MemoryClient struct {
 	apiKey           string
+	tokenSource      TokenSource
+	authScheme       string
+	authHeader       string
 	host             string
 	organizationName *string
 	projectName      *string
@@ -33,40 +53,97 @@ type MemoryClient struct {
 	headers          map[string]string
 	httpClient       *http.Client
 	telemetryID      string
+	metadataSchema   *MetadataSchema
+	defaults         *MemoryOptions
+	userAgent        string
+	codec            Codec
+	metrics          MetricsHook
+	conns            *connTracker // shared with derived clients, like the transport
+	detectBodyLeaks  bool
+
+	deprecationWarnings sync.Map // operations already warned about
+
+	keyMu sync.Mutex // guards apiKey and tokenSource
+
+	authMu        sync.Mutex // guards telemetryID, organizationID, projectID and pinging
+	pinging       *pingCall
+	pingedScope   bool   // organizationID and projectID were resolved by Ping
+	minSDKVersion string // minimum SDK version advertised by the last Ping
+
+	dedupeSearches bool
+	searchMu       sync.Mutex // guards searches
+	searches       map[string]*searchCall
 }
 
 // NewMemoryClient creates a new MemoryClient instance
 func NewMemoryClient(options ClientOptions) (*MemoryClient, error) {
-	if err := validateAPIKey(options.APIKey); err != nil {
-		return nil, err
+	if options.APIKey == "" && options.TokenSource == nil && options.Credentials != nil {
+		apiKey, err := options.Credentials.APIKey(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load API key: %w", err)
+		}
+		options.APIKey = apiKey
+	}
+	if options.TokenSource == nil {
+		if err := validateAPIKey(options.APIKey); err != nil {
+			return nil, err
+		}
+	}
+	if options.Defaults != nil {
+		if err := validateDefaults(*options.Defaults); err != nil {
+			return nil, err
+		}
 	}
 
 	host := "https://api.mem0.ai"
 	if options.Host != nil {
 		host = *options.Host
 	}
+	timeout := DefaultTimeout
+	if options.Timeout > 0 {
+		timeout = options.Timeout
+	}
 
+	conns := &connTracker{}
 	client := &MemoryClient{
 		apiKey:           options.APIKey,
+		tokenSource:      options.TokenSource,
+		authScheme:       options.AuthScheme,
+		authHeader:       options.AuthHeader,
 		host:             host,
 		organizationName: options.OrganizationName,
 		projectName:      options.ProjectName,
 		organizationID:   options.OrganizationID,
 		projectID:        options.ProjectID,
 		headers: map[string]string{
-			"Authorization": fmt.Sprintf("Token %s", options.APIKey),
-			"Content-Type":  "application/json",
+			"Content-Type": "application/json",
 		},
 		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
+			Timeout:   timeout,
+			Transport: conns.instrument(newTransport(options.Transport)),
 		},
-		telemetryID: "",
+		telemetryID:     "",
+		metadataSchema:  options.MetadataSchema,
+		dedupeSearches:  options.DedupeSearches,
+		defaults:        options.Defaults,
+		userAgent:       defaultUserAgent,
+		codec:           options.Codec,
+		metrics:         options.Metrics,
+		conns:           conns,
+		detectBodyLeaks: options.DetectBodyLeaks,
+	}
+	if options.HTTPClient != nil {
+		client.httpClient = options.HTTPClient
 	}
 
 	// Initialize the client
 	if err := client.initializeClient(context.Background()); err != nil {
 		// Log error but don't fail initialization
 		fmt.Printf("Failed to initialize client: %v\n", err)
+	} else if options.CheckVersion {
+		if err := client.compatibility(); err != nil {
+			fmt.Printf("Warning: %v. Upgrade github.com/murilopl/go-mem0.\n", err)
+		}
 	}
 
 	return client, nil
@@ -92,8 +169,9 @@ func (c *MemoryClient) validateOrgProject() {
 	}
 
 	// Check for organizationId/projectId pair
-	if (c.organizationID == nil && c.projectID != nil) ||
-		(c.organizationID != nil && c.projectID == nil) {
+	orgID, projectID := c.scope()
+	if (orgID == nil && projectID != nil) ||
+		(orgID != nil && projectID == nil) {
 		fmt.Println("Warning: Both organizationId and projectId must be provided together when using either. This will be removed from version 1.0.40.")
 	}
 }
@@ -111,50 +189,139 @@ func (c *MemoryClient) initializeClient(ctx context.Context) error {
 
 // fetchWithErrorHandling makes HTTP requests with error handling
 func (c *MemoryClient) fetchWithErrorHandling(ctx context.Context, method, endpoint string, body interface{}) (interface{}, error) {
+	respBody, err := c.request(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	if err := c.jsonCodec().Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+// request sends a request with the client's headers and returns the body of
+// a successful response, or an *APIError
+func (c *MemoryClient) request(ctx context.Context, method, endpoint string, body interface{}) ([]byte, error) {
+	resp, requestID, err := c.send(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body of request %s: %w", requestID, err)
+	}
+	return respBody, nil
+}
+
+// send sends a request with the client's headers and returns a successful
+// response, whose body the caller must close, and its request ID. Responses
+// with an error status are returned as an *APIError.
+func (c *MemoryClient) send(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, string, error) {
 	var reqBody io.Reader
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		jsonBody, err := c.jsonCodec().Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, "", fmt.Errorf("failed to marshal request body: %w", err)
 		}
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
+	var trace *connTrace
+	if c.conns != nil {
+		ctx, trace = withConnTrace(ctx)
+	}
+
 	url := fmt.Sprintf("%s%s", c.host, endpoint)
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
+	authHeader, authorization, err := c.authorization(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set(authHeader, authorization)
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 	for key, value := range c.headers {
 		req.Header.Set(key, value)
 	}
-	if c.telemetryID != "" {
-		req.Header.Set("Mem0-User-ID", c.telemetryID)
+	if telemetryID := c.telemetry(); telemetryID != "" {
+		req.Header.Set("Mem0-User-ID", telemetryID)
+	}
+	if hash, ok := ifMatchHash(ctx); ok && method != http.MethodGet {
+		req.Header.Set(IfMatchHeader, hash)
+	}
+	if key := requestIdempotencyKey(ctx); key != "" {
+		req.Header.Set(IdempotencyHeader, key)
+	}
+	requestID, ok := RequestID(ctx)
+	if !ok {
+		requestID = newUUID()
 	}
+	req.Header.Set(RequestIDHeader, requestID)
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+	captured := capturedResponse(ctx)
+	if captured != nil {
+		*captured = Response{RequestID: requestID}
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	if c.conns != nil {
+		c.conns.requests.Add(1)
+	}
+	resp, err := c.httpClient.Do(req)
+	if trace != nil {
+		c.observeConn(trace, method, endpoint, requestID)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, "", fmt.Errorf("request %s failed: %w", requestID, err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, NewAPIError(string(respBody), resp.StatusCode, string(respBody))
+	if echoed := resp.Header.Get(RequestIDHeader); echoed != "" {
+		requestID = echoed
+	}
+	if c.conns != nil {
+		c.conns.inFlight.Add(1)
+		resp.Body = &trackedBody{ReadCloser: resp.Body, tracker: c.conns, requestID: requestID, detectLeaks: c.detectBodyLeaks}
+	}
+	if captured != nil {
+		*captured = Response{
+			RequestID:  requestID,
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			RateLimit:  parseRateLimit(resp.Header, time.Now()),
+		}
 	}
 
-	var result interface{}
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response JSON: %w", err)
+	success := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !success || captured != nil {
+		// Error bodies and captured bodies are read up front; only successful,
+		// uncaptured responses are left for the caller to stream
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read response body of request %s: %w", requestID, err)
+		}
+		if captured != nil {
+			captured.Body = respBody
+		}
+		if !success {
+			apiErr := NewAPIError(string(respBody), resp.StatusCode, string(respBody))
+			apiErr.RequestID = requestID
+			return nil, "", apiErr
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
 	}
 
-	return result, nil
+	return resp, requestID, nil
 }
 
 // preparePayload combines messages with options for API requests
@@ -211,55 +378,33 @@ func (c *MemoryClient) preparePayload(messages []Message, options MemoryOptions)
 	if options.CustomInstructions != nil {
 		payload["custom_instructions"] = *options.CustomInstructions
 	}
+	if options.Includes != nil {
+		payload["includes"] = *options.Includes
+	}
+	if options.Excludes != nil {
+		payload["excludes"] = *options.Excludes
+	}
 	if options.OutputFormat != nil {
 		payload["output_format"] = *options.OutputFormat
 	}
 	if options.AsyncMode != nil {
 		payload["async_mode"] = *options.AsyncMode
 	}
+	if options.Timestamp != nil {
+		payload["timestamp"] = *options.Timestamp
+	}
+	if options.ExpirationDate != nil {
+		payload["expiration_date"] = *options.ExpirationDate
+	}
+	if options.Immutable != nil {
+		payload["immutable"] = *options.Immutable
+	}
 
 	return payload
 }
 
-// prepareParams converts options to URL parameters
+// prepareParams converts options to URL parameters, following the url and
+// json tags of their fields (see encodeParams)
 func (c *MemoryClient) prepareParams(options interface{}) url.Values {
-	params := url.Values{}
-
-	// Use reflection or type assertion to handle different option types
-	switch opts := options.(type) {
-	case MemoryOptions:
-		if opts.UserID != nil {
-			params.Add("user_id", *opts.UserID)
-		}
-		if opts.AgentID != nil {
-			params.Add("agent_id", *opts.AgentID)
-		}
-		if opts.AppID != nil {
-			params.Add("app_id", *opts.AppID)
-		}
-		if opts.RunID != nil {
-			params.Add("run_id", *opts.RunID)
-		}
-		if opts.OrgName != nil {
-			params.Add("org_name", *opts.OrgName)
-		}
-		if opts.ProjectName != nil {
-			params.Add("project_name", *opts.ProjectName)
-		}
-		if opts.OrgID != nil {
-			params.Add("org_id", fmt.Sprintf("%v", opts.OrgID))
-		}
-		if opts.ProjectID != nil {
-			params.Add("project_id", fmt.Sprintf("%v", opts.ProjectID))
-		}
-	case SearchOptions:
-		// Handle SearchOptions by first handling the embedded MemoryOptions
-		memOpts := opts.MemoryOptions
-		if memOpts.UserID != nil {
-			params.Add("user_id", *memOpts.UserID)
-		}
-		// Add other search-specific parameters as needed
-	}
-
-	return params
+	return encodeParams(options)
 }
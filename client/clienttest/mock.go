@@ -0,0 +1,413 @@
+// Package clienttest provides test doubles for the client package.
+package clienttest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// Call records a single method invocation on a MockClient
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// MockClient is a programmable client.Client. Each method delegates to the
+// matching Func field when it is set and returns zero values otherwise.
+// Every invocation is recorded (without the context) and can be inspected
+// with Calls or CallsTo. MockClient is safe for concurrent use.
+type MockClient struct {
+	PingFunc                func(ctx context.Context) error
+	AddFunc                 func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error)
+	AddWithGraphFunc        func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) (*client.AddResult, error)
+	UpdateFunc              func(ctx context.Context, memoryID, message string) ([]client.Memory, error)
+	UpdateMemoryFunc        func(ctx context.Context, memoryID string, request client.UpdateRequest) ([]client.Memory, error)
+	GetFunc                 func(ctx context.Context, memoryID string) (*client.Memory, error)
+	GetAllFunc              func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error)
+	SearchFunc              func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error)
+	DeleteFunc              func(ctx context.Context, memoryID string) (*client.MessageResponse, error)
+	DeleteAllFunc           func(ctx context.Context, options ...client.MemoryOptions) (*client.DeleteAllResult, error)
+	BatchUpdateFunc         func(ctx context.Context, memories []client.MemoryUpdateBody, opts ...client.BatchOption) (*client.BatchResult, error)
+	BatchDeleteFunc         func(ctx context.Context, memoryIDs []string, opts ...client.BatchOption) (*client.BatchResult, error)
+	HistoryFunc             func(ctx context.Context, memoryID string) ([]client.MemoryHistory, error)
+	UsersFunc               func(ctx context.Context, options ...client.UsersOptions) (*client.AllUsers, error)
+	DeleteUserFunc          func(ctx context.Context, data client.DeleteUserData) (*client.MessageResponse, error)
+	DeleteUsersFunc         func(ctx context.Context, params ...client.DeleteUsersParams) (*client.DeleteUsersResult, error)
+	HealthyFunc             func(ctx context.Context) client.HealthReport
+	CheckCompatibilityFunc  func(ctx context.Context) error
+	AddWithResponseFunc     func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, *client.Response, error)
+	UpdateWithMetadataFunc  func(ctx context.Context, memoryID, message string, metadata map[string]interface{}) ([]client.Memory, error)
+	UpdateWithResponseFunc  func(ctx context.Context, memoryID, message string) ([]client.Memory, *client.Response, error)
+	PatchMetadataFunc       func(ctx context.Context, memoryID string, patch map[string]interface{}) ([]client.Memory, error)
+	RevertMemoryFunc        func(ctx context.Context, memoryID, historyID string) ([]client.Memory, error)
+	GetFieldsFunc           func(ctx context.Context, memoryID string, fields ...string) (*client.Memory, error)
+	GetWithResponseFunc     func(ctx context.Context, memoryID string) (*client.Memory, *client.Response, error)
+	GetAllWithResponseFunc  func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, *client.Response, error)
+	SearchWithResponseFunc  func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, *client.Response, error)
+	SearchCategoriesFunc    func(ctx context.Context, query string, categories []string, options ...client.SearchOptions) ([]client.Memory, error)
+	DeleteWithResponseFunc  func(ctx context.Context, memoryID string) (*client.MessageResponse, *client.Response, error)
+	GetUserFunc             func(ctx context.Context, name string) (*client.User, error)
+	GetEntityFunc           func(ctx context.Context, entityType, name string) (*client.User, error)
+	MemoriesForEntityFunc   func(ctx context.Context, entityType, name string, options ...client.SearchOptions) ([]client.Memory, error)
+	GetCategoriesFunc       func(ctx context.Context) ([]client.Category, error)
+	SetCustomCategoriesFunc func(ctx context.Context, categories []client.Category) error
+	UpdateProjectFunc       func(ctx context.Context, payload client.PromptUpdatePayload) (*client.MessageResponse, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ client.Client = (*MockClient)(nil)
+
+// record appends a call to the call log
+func (m *MockClient) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns a copy of all recorded calls in invocation order
+func (m *MockClient) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	calls := make([]Call, len(m.calls))
+	copy(calls, m.calls)
+	return calls
+}
+
+// CallsTo returns the recorded calls to the named method
+func (m *MockClient) CallsTo(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset clears the call log
+func (m *MockClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// Ping implements client.Client
+func (m *MockClient) Ping(ctx context.Context) error {
+	m.record("Ping")
+	if m.PingFunc != nil {
+		return m.PingFunc(ctx)
+	}
+	return nil
+}
+
+// Add implements client.Client
+func (m *MockClient) Add(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+	m.record("Add", messages, options)
+	if m.AddFunc != nil {
+		return m.AddFunc(ctx, messages, options...)
+	}
+	return nil, nil
+}
+
+// AddWithGraph implements client.Client
+func (m *MockClient) AddWithGraph(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) (*client.AddResult, error) {
+	m.record("AddWithGraph", messages, options)
+	if m.AddWithGraphFunc != nil {
+		return m.AddWithGraphFunc(ctx, messages, options...)
+	}
+	return nil, nil
+}
+
+// Update implements client.Client
+func (m *MockClient) Update(ctx context.Context, memoryID, message string) ([]client.Memory, error) {
+	m.record("Update", memoryID, message)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, memoryID, message)
+	}
+	return nil, nil
+}
+
+// UpdateMemory implements client.Client
+func (m *MockClient) UpdateMemory(ctx context.Context, memoryID string, request client.UpdateRequest) ([]client.Memory, error) {
+	m.record("UpdateMemory", memoryID, request)
+	if m.UpdateMemoryFunc != nil {
+		return m.UpdateMemoryFunc(ctx, memoryID, request)
+	}
+	return nil, nil
+}
+
+// Get implements client.Client
+func (m *MockClient) Get(ctx context.Context, memoryID string) (*client.Memory, error) {
+	m.record("Get", memoryID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, memoryID)
+	}
+	return nil, nil
+}
+
+// GetAll implements client.Client
+func (m *MockClient) GetAll(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+	m.record("GetAll", options)
+	if m.GetAllFunc != nil {
+		return m.GetAllFunc(ctx, options...)
+	}
+	return nil, nil
+}
+
+// Search implements client.Client
+func (m *MockClient) Search(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+	m.record("Search", query, options)
+	if m.SearchFunc != nil {
+		return m.SearchFunc(ctx, query, options...)
+	}
+	return nil, nil
+}
+
+// Delete implements client.Client
+func (m *MockClient) Delete(ctx context.Context, memoryID string) (*client.MessageResponse, error) {
+	m.record("Delete", memoryID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, memoryID)
+	}
+	return nil, nil
+}
+
+// DeleteAll implements client.Client
+func (m *MockClient) DeleteAll(ctx context.Context, options ...client.MemoryOptions) (*client.DeleteAllResult, error) {
+	m.record("DeleteAll", options)
+	if m.DeleteAllFunc != nil {
+		return m.DeleteAllFunc(ctx, options...)
+	}
+	return nil, nil
+}
+
+// BatchUpdate implements client.Client
+func (m *MockClient) BatchUpdate(ctx context.Context, memories []client.MemoryUpdateBody, opts ...client.BatchOption) (*client.BatchResult, error) {
+	m.record("BatchUpdate", memories, opts)
+	if m.BatchUpdateFunc != nil {
+		return m.BatchUpdateFunc(ctx, memories, opts...)
+	}
+	return nil, nil
+}
+
+// BatchDelete implements client.Client
+func (m *MockClient) BatchDelete(ctx context.Context, memoryIDs []string, opts ...client.BatchOption) (*client.BatchResult, error) {
+	m.record("BatchDelete", memoryIDs, opts)
+	if m.BatchDeleteFunc != nil {
+		return m.BatchDeleteFunc(ctx, memoryIDs, opts...)
+	}
+	return nil, nil
+}
+
+// History implements client.Client
+func (m *MockClient) History(ctx context.Context, memoryID string) ([]client.MemoryHistory, error) {
+	m.record("History", memoryID)
+	if m.HistoryFunc != nil {
+		return m.HistoryFunc(ctx, memoryID)
+	}
+	return nil, nil
+}
+
+// Users implements client.Client
+func (m *MockClient) Users(ctx context.Context, options ...client.UsersOptions) (*client.AllUsers, error) {
+	m.record("Users", options)
+	if m.UsersFunc != nil {
+		return m.UsersFunc(ctx, options...)
+	}
+	return nil, nil
+}
+
+// DeleteUser implements client.Client
+func (m *MockClient) DeleteUser(ctx context.Context, data client.DeleteUserData) (*client.MessageResponse, error) {
+	m.record("DeleteUser", data)
+	if m.DeleteUserFunc != nil {
+		return m.DeleteUserFunc(ctx, data)
+	}
+	return nil, nil
+}
+
+// DeleteUsers implements client.Client
+func (m *MockClient) DeleteUsers(ctx context.Context, params ...client.DeleteUsersParams) (*client.DeleteUsersResult, error) {
+	m.record("DeleteUsers", params)
+	if m.DeleteUsersFunc != nil {
+		return m.DeleteUsersFunc(ctx, params...)
+	}
+	return nil, nil
+}
+
+// Healthy implements client.Client
+func (m *MockClient) Healthy(ctx context.Context) client.HealthReport {
+	m.record("Healthy")
+	if m.HealthyFunc != nil {
+		return m.HealthyFunc(ctx)
+	}
+	return client.HealthReport{}
+}
+
+// CheckCompatibility implements client.Client
+func (m *MockClient) CheckCompatibility(ctx context.Context) error {
+	m.record("CheckCompatibility")
+	if m.CheckCompatibilityFunc != nil {
+		return m.CheckCompatibilityFunc(ctx)
+	}
+	return nil
+}
+
+// AddWithResponse implements client.Client
+func (m *MockClient) AddWithResponse(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, *client.Response, error) {
+	m.record("AddWithResponse", messages, options)
+	if m.AddWithResponseFunc != nil {
+		return m.AddWithResponseFunc(ctx, messages, options...)
+	}
+	return nil, nil, nil
+}
+
+// UpdateWithMetadata implements client.Client
+func (m *MockClient) UpdateWithMetadata(ctx context.Context, memoryID, message string, metadata map[string]interface{}) ([]client.Memory, error) {
+	m.record("UpdateWithMetadata", memoryID, message, metadata)
+	if m.UpdateWithMetadataFunc != nil {
+		return m.UpdateWithMetadataFunc(ctx, memoryID, message, metadata)
+	}
+	return nil, nil
+}
+
+// UpdateWithResponse implements client.Client
+func (m *MockClient) UpdateWithResponse(ctx context.Context, memoryID, message string) ([]client.Memory, *client.Response, error) {
+	m.record("UpdateWithResponse", memoryID, message)
+	if m.UpdateWithResponseFunc != nil {
+		return m.UpdateWithResponseFunc(ctx, memoryID, message)
+	}
+	return nil, nil, nil
+}
+
+// PatchMetadata implements client.Client
+func (m *MockClient) PatchMetadata(ctx context.Context, memoryID string, patch map[string]interface{}) ([]client.Memory, error) {
+	m.record("PatchMetadata", memoryID, patch)
+	if m.PatchMetadataFunc != nil {
+		return m.PatchMetadataFunc(ctx, memoryID, patch)
+	}
+	return nil, nil
+}
+
+// RevertMemory implements client.Client
+func (m *MockClient) RevertMemory(ctx context.Context, memoryID, historyID string) ([]client.Memory, error) {
+	m.record("RevertMemory", memoryID, historyID)
+	if m.RevertMemoryFunc != nil {
+		return m.RevertMemoryFunc(ctx, memoryID, historyID)
+	}
+	return nil, nil
+}
+
+// GetFields implements client.Client
+func (m *MockClient) GetFields(ctx context.Context, memoryID string, fields ...string) (*client.Memory, error) {
+	m.record("GetFields", memoryID, fields)
+	if m.GetFieldsFunc != nil {
+		return m.GetFieldsFunc(ctx, memoryID, fields...)
+	}
+	return nil, nil
+}
+
+// GetWithResponse implements client.Client
+func (m *MockClient) GetWithResponse(ctx context.Context, memoryID string) (*client.Memory, *client.Response, error) {
+	m.record("GetWithResponse", memoryID)
+	if m.GetWithResponseFunc != nil {
+		return m.GetWithResponseFunc(ctx, memoryID)
+	}
+	return nil, nil, nil
+}
+
+// GetAllWithResponse implements client.Client
+func (m *MockClient) GetAllWithResponse(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, *client.Response, error) {
+	m.record("GetAllWithResponse", options)
+	if m.GetAllWithResponseFunc != nil {
+		return m.GetAllWithResponseFunc(ctx, options...)
+	}
+	return nil, nil, nil
+}
+
+// SearchWithResponse implements client.Client
+func (m *MockClient) SearchWithResponse(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, *client.Response, error) {
+	m.record("SearchWithResponse", query, options)
+	if m.SearchWithResponseFunc != nil {
+		return m.SearchWithResponseFunc(ctx, query, options...)
+	}
+	return nil, nil, nil
+}
+
+// SearchCategories implements client.Client
+func (m *MockClient) SearchCategories(ctx context.Context, query string, categories []string, options ...client.SearchOptions) ([]client.Memory, error) {
+	m.record("SearchCategories", query, categories, options)
+	if m.SearchCategoriesFunc != nil {
+		return m.SearchCategoriesFunc(ctx, query, categories, options...)
+	}
+	return nil, nil
+}
+
+// DeleteWithResponse implements client.Client
+func (m *MockClient) DeleteWithResponse(ctx context.Context, memoryID string) (*client.MessageResponse, *client.Response, error) {
+	m.record("DeleteWithResponse", memoryID)
+	if m.DeleteWithResponseFunc != nil {
+		return m.DeleteWithResponseFunc(ctx, memoryID)
+	}
+	return nil, nil, nil
+}
+
+// GetUser implements client.Client
+func (m *MockClient) GetUser(ctx context.Context, name string) (*client.User, error) {
+	m.record("GetUser", name)
+	if m.GetUserFunc != nil {
+		return m.GetUserFunc(ctx, name)
+	}
+	return nil, nil
+}
+
+// GetEntity implements client.Client
+func (m *MockClient) GetEntity(ctx context.Context, entityType, name string) (*client.User, error) {
+	m.record("GetEntity", entityType, name)
+	if m.GetEntityFunc != nil {
+		return m.GetEntityFunc(ctx, entityType, name)
+	}
+	return nil, nil
+}
+
+// MemoriesForEntity implements client.Client
+func (m *MockClient) MemoriesForEntity(ctx context.Context, entityType, name string, options ...client.SearchOptions) ([]client.Memory, error) {
+	m.record("MemoriesForEntity", entityType, name, options)
+	if m.MemoriesForEntityFunc != nil {
+		return m.MemoriesForEntityFunc(ctx, entityType, name, options...)
+	}
+	return nil, nil
+}
+
+// GetCategories implements client.Client
+func (m *MockClient) GetCategories(ctx context.Context) ([]client.Category, error) {
+	m.record("GetCategories")
+	if m.GetCategoriesFunc != nil {
+		return m.GetCategoriesFunc(ctx)
+	}
+	return nil, nil
+}
+
+// SetCustomCategories implements client.Client
+func (m *MockClient) SetCustomCategories(ctx context.Context, categories []client.Category) error {
+	m.record("SetCustomCategories", categories)
+	if m.SetCustomCategoriesFunc != nil {
+		return m.SetCustomCategoriesFunc(ctx, categories)
+	}
+	return nil
+}
+
+// UpdateProject implements client.Client
+func (m *MockClient) UpdateProject(ctx context.Context, payload client.PromptUpdatePayload) (*client.MessageResponse, error) {
+	m.record("UpdateProject", payload)
+	if m.UpdateProjectFunc != nil {
+		return m.UpdateProjectFunc(ctx, payload)
+	}
+	return nil, nil
+}
@@ -0,0 +1,95 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+func TestMockClientProgrammableResponses(t *testing.T) {
+	memoryText := "likes Go"
+	mock := &MockClient{
+		SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+			return []client.Memory{{ID: "mem-1", Memory: &memoryText}}, nil
+		},
+		DeleteFunc: func(ctx context.Context, memoryID string) (*client.MessageResponse, error) {
+			return nil, client.NewAPIError("not found", 404, "")
+		},
+	}
+
+	var c client.Client = mock
+
+	memories, err := c.Search(context.Background(), "languages")
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(memories) != 1 || memories[0].ID != "mem-1" {
+		t.Errorf("Search() = %v, want one memory with ID mem-1", memories)
+	}
+
+	_, err = c.Delete(context.Background(), "mem-1")
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("Delete() error = %v, want APIError with status 404", err)
+	}
+
+	// Unprogrammed methods return zero values
+	history, err := c.History(context.Background(), "mem-1")
+	if err != nil || history != nil {
+		t.Errorf("History() = %v, %v, want nil, nil", history, err)
+	}
+}
+
+func TestMockClientRecordsCalls(t *testing.T) {
+	mock := &MockClient{}
+	ctx := context.Background()
+
+	userID := "user-1"
+	messages := []client.Message{{Role: "user", Content: "hi"}}
+	mock.Add(ctx, messages, client.MemoryOptions{UserID: &userID})
+	mock.Get(ctx, "mem-1")
+	mock.Get(ctx, "mem-2")
+
+	calls := mock.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("Calls() length = %d, want 3", len(calls))
+	}
+	if calls[0].Method != "Add" {
+		t.Errorf("Calls()[0].Method = %s, want Add", calls[0].Method)
+	}
+	options := calls[0].Args[1].([]client.MemoryOptions)
+	if len(options) != 1 || *options[0].UserID != userID {
+		t.Errorf("Add options = %v, want user_id %s", options, userID)
+	}
+
+	gets := mock.CallsTo("Get")
+	if len(gets) != 2 || gets[1].Args[0] != "mem-2" {
+		t.Errorf("CallsTo(Get) = %v, want two calls ending with mem-2", gets)
+	}
+
+	mock.Reset()
+	if len(mock.Calls()) != 0 {
+		t.Error("Reset() should clear recorded calls")
+	}
+}
+
+func TestMockClientConcurrentUse(t *testing.T) {
+	mock := &MockClient{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mock.Ping(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	if got := len(mock.CallsTo("Ping")); got != 50 {
+		t.Errorf("CallsTo(Ping) length = %d, want 50", got)
+	}
+}
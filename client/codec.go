@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Codec encodes request bodies and decodes response bodies. Implementations
+// must follow encoding/json's struct tags and semantics; wrap sonic or
+// go-json to cut the CPU spent decoding large Search and GetAll responses.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// StdCodec is the encoding/json Codec clients use by default
+var StdCodec Codec = stdCodec{}
+
+type stdCodec struct{}
+
+// Marshal implements Codec
+func (stdCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+// Unmarshal implements Codec
+func (stdCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// WithCodec encodes and decodes the derived client's requests with codec
+func WithCodec(codec Codec) Option {
+	return func(c *MemoryClient) {
+		c.codec = codec
+	}
+}
+
+// jsonCodec returns the client's codec, StdCodec when unset
+func (c *MemoryClient) jsonCodec() Codec {
+	if c.codec == nil {
+		return StdCodec
+	}
+	return c.codec
+}
+
+// fetchInto makes a request like fetchWithErrorHandling and decodes the
+// response straight into target
+func (c *MemoryClient) fetchInto(ctx context.Context, method, endpoint string, body, target interface{}) error {
+	data, err := c.request(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	if err := c.jsonCodec().Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to parse response JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// countingCodec counts the calls it forwards to StdCodec
+type countingCodec struct {
+	marshals, unmarshals int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshals++
+	return StdCodec.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshals++
+	return StdCodec.Unmarshal(data, v)
+}
+
+func TestCodec(t *testing.T) {
+	for _, body := range []string{memoryListV1, `{"results":` + memoryListV1 + `}`} {
+		c, _ := newStubClient(t, http.StatusOK, body)
+		codec := &countingCodec{}
+		c = c.With(WithCodec(codec))
+
+		memories, err := c.Search(context.Background(), "go", SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if len(memories) != 1 || memories[0].ID != "mem-1" {
+			t.Errorf("Search() = %+v, want mem-1", memories)
+		}
+		if codec.marshals != 1 || codec.unmarshals != 1 {
+			t.Errorf("codec calls = %d marshals, %d unmarshals; want 1 each", codec.marshals, codec.unmarshals)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultProfile is the profile used when none is named
+const DefaultProfile = "default"
+
+// Environment variables that select the config file and profile
+const (
+	EnvConfigFile = "MEM0_CONFIG_FILE" // DefaultConfigPath when unset
+	EnvProfile    = "MEM0_PROFILE"
+)
+
+// Profile is a named set of client settings in the config file
+type Profile struct {
+	APIKey     string
+	Host       string
+	OrgID      string
+	ProjectID  string
+	AuthScheme string
+	Timeout    time.Duration
+}
+
+// DefaultConfigPath returns the config file location: $MEM0_CONFIG_FILE, or
+// ~/.mem0/config.toml
+func DefaultConfigPath() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".mem0", "config.toml"), nil
+}
+
+// LoadConfig reads the profiles of a config file. Each TOML table is a
+// profile:
+//
+//	[default]
+//	api_key = "m0-..."
+//
+//	[staging]
+//	api_key = "m0-..."
+//	host = "https://staging.example.com"
+//	org_id = "org-2"
+//	project_id = "proj-7"
+//	timeout = "30s"
+func LoadConfig(path string) (map[string]Profile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	profiles := map[string]Profile{}
+	seen := map[string]bool{} // "profile.key" of the settings read so far
+	var name string
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			table, err := tomlTable(line)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+			}
+			if _, ok := profiles[table]; ok {
+				return nil, fmt.Errorf("%s:%d: profile %q defined twice", path, lineNo, table)
+			}
+			name = table
+			profiles[name] = Profile{}
+			continue
+		}
+
+		key, rest, err := tomlKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		rest = strings.TrimLeft(rest, " \t")
+		if !strings.HasPrefix(rest, "=") {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, lineNo)
+		}
+		if name == "" {
+			return nil, fmt.Errorf("%s:%d: setting outside of a profile table", path, lineNo)
+		}
+		if seen[name+"."+key] {
+			return nil, fmt.Errorf("%s:%d: %s set twice in profile %q", path, lineNo, key, name)
+		}
+		seen[name+"."+key] = true
+		value, err := tomlValue(strings.TrimSpace(rest[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+
+		profile := profiles[name]
+		switch key {
+		case "api_key":
+			profile.APIKey = value
+		case "host":
+			profile.Host = value
+		case "org_id":
+			profile.OrgID = value
+		case "project_id":
+			profile.ProjectID = value
+		case "auth_scheme":
+			profile.AuthScheme = value
+		case "timeout":
+			timeout, err := parseTimeout("timeout", value)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+			}
+			profile.Timeout = timeout
+		default:
+			// A misspelt key would otherwise load the profile without it
+			return nil, fmt.Errorf("%s:%d: unknown key %q", path, lineNo, key)
+		}
+		profiles[name] = profile
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return profiles, nil
+}
+
+// The config file is read as the subset of TOML that flat profile tables
+// need: bare and quoted keys, and string, integer and boolean values. Input
+// outside the subset, such as dotted keys, arrays of tables or multi-line
+// strings, is rejected rather than misread.
+
+// tomlTable decodes a [name] table header line
+func tomlTable(line string) (string, error) {
+	if strings.HasPrefix(line, "[[") {
+		return "", errors.New("arrays of tables are not supported")
+	}
+	name, rest, err := tomlKey(strings.TrimLeft(line[1:], " \t"))
+	if err != nil {
+		return "", err
+	}
+	rest = strings.TrimLeft(rest, " \t")
+	if strings.HasPrefix(rest, ".") {
+		return "", errors.New("nested tables are not supported")
+	}
+	if !strings.HasPrefix(rest, "]") {
+		return "", errors.New("unterminated table header")
+	}
+	if err := tomlLineEnd(rest[1:]); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// tomlKey decodes the bare or quoted key at the start of s and returns the
+// text after it
+func tomlKey(s string) (key, rest string, err error) {
+	switch {
+	case strings.HasPrefix(s, `"`), strings.HasPrefix(s, "'"):
+		key, rest, err = tomlString(s)
+	default:
+		end := 0
+		for end < len(s) && isBareKeyChar(s[end]) {
+			end++
+		}
+		if end == 0 {
+			return "", "", errors.New("expected a key")
+		}
+		key, rest = s[:end], s[end:]
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if strings.HasPrefix(strings.TrimLeft(rest, " \t"), ".") {
+		return "", "", errors.New("dotted keys are not supported")
+	}
+	return key, rest, nil
+}
+
+func isBareKeyChar(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9' || b == '_' || b == '-'
+}
+
+// tomlValue decodes a string, integer or boolean TOML value as text, allowing
+// a trailing comment
+func tomlValue(raw string) (string, error) {
+	var value, rest string
+	switch {
+	case strings.HasPrefix(raw, `"""`), strings.HasPrefix(raw, "'''"):
+		return "", errors.New("multi-line strings are not supported")
+	case strings.HasPrefix(raw, `"`), strings.HasPrefix(raw, "'"):
+		var err error
+		if value, rest, err = tomlString(raw); err != nil {
+			return "", err
+		}
+	default:
+		end := strings.IndexAny(raw, " \t#")
+		if end < 0 {
+			end = len(raw)
+		}
+		value, rest = raw[:end], raw[end:]
+		if value != "true" && value != "false" {
+			integer, ok := tomlInteger(value)
+			if !ok {
+				return "", fmt.Errorf("invalid value %q; quote strings", value)
+			}
+			value = integer
+		}
+	}
+	if err := tomlLineEnd(rest); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// tomlInteger decodes a decimal TOML integer, which may have a sign and
+// underscores between digits
+func tomlInteger(s string) (string, bool) {
+	sign := ""
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		sign, s = strings.TrimPrefix(s[:1], "+"), s[1:]
+	}
+	if s == "" || s[0] == '_' || s[len(s)-1] == '_' || strings.Contains(s, "__") {
+		return "", false
+	}
+	digits := strings.ReplaceAll(s, "_", "")
+	if len(digits) > 1 && digits[0] == '0' {
+		return "", false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	return sign + digits, true
+}
+
+// tomlString decodes the basic ("...") or literal ('...') string at the
+// start of s and returns the text after it
+func tomlString(s string) (value, rest string, err error) {
+	if s[0] == '\'' {
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return "", "", errors.New("unterminated string")
+		}
+		return s[1 : end+1], s[end+2:], nil
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), s[i+1:], nil
+		case '\\':
+			if i+1 == len(s) {
+				return "", "", errors.New("unterminated string")
+			}
+			i++
+			switch e := s[i]; e {
+			case 'b':
+				b.WriteByte('\b')
+			case 't':
+				b.WriteByte('\t')
+			case 'n':
+				b.WriteByte('\n')
+			case 'f':
+				b.WriteByte('\f')
+			case 'r':
+				b.WriteByte('\r')
+			case '"', '\\':
+				b.WriteByte(e)
+			case 'u', 'U':
+				size := 4
+				if e == 'U' {
+					size = 8
+				}
+				if i+size >= len(s) {
+					return "", "", errors.New("invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+				if err != nil || !utf8.ValidRune(rune(code)) {
+					return "", "", fmt.Errorf("invalid unicode escape \\%c%s", e, s[i+1:i+1+size])
+				}
+				b.WriteRune(rune(code))
+				i += size
+			default:
+				return "", "", fmt.Errorf("invalid escape \\%c", e)
+			}
+		default:
+			if c < 0x20 && c != '\t' || c == 0x7f {
+				return "", "", errors.New("control character in string")
+			}
+			b.WriteByte(c)
+		}
+	}
+	return "", "", errors.New("unterminated string")
+}
+
+// tomlLineEnd checks that rest holds nothing but whitespace and a comment
+func tomlLineEnd(rest string) error {
+	rest = strings.TrimLeft(rest, " \t")
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return fmt.Errorf("unexpected %q after value", rest)
+	}
+	return nil
+}
+
+// WithProfile returns o with unset fields filled from the named profile of
+// the config file at DefaultConfigPath. Fields already set in o win.
+func (o ClientOptions) WithProfile(name string) (ClientOptions, error) {
+	path, err := DefaultConfigPath()
+	if err != nil {
+		return o, err
+	}
+	profiles, err := LoadConfig(path)
+	if err != nil {
+		return o, err
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return o, NewValidationError("profile", fmt.Sprintf("profile %q not found in %s", name, path))
+	}
+
+	if o.APIKey == "" {
+		o.APIKey = profile.APIKey
+	}
+	if o.Host == nil && profile.Host != "" {
+		host := profile.Host
+		o.Host = &host
+	}
+	if o.OrganizationID == nil && profile.OrgID != "" {
+		o.OrganizationID = profile.OrgID
+	}
+	if o.ProjectID == nil && profile.ProjectID != "" {
+		o.ProjectID = profile.ProjectID
+	}
+	if o.AuthScheme == "" {
+		o.AuthScheme = profile.AuthScheme
+	}
+	if o.Timeout == 0 {
+		o.Timeout = profile.Timeout
+	}
+	return o, nil
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testConfig = `# Mem0 profiles
+[default]
+api_key = "m0-default"
+
+[staging]
+api_key = 'm0-staging'   # literal string
+host = "https://staging.example.com"
+org_id = "org-2"
+project_id = 7
+auth_scheme = "Bearer"
+timeout = "30s"
+
+["eu prod"]
+api_key = "m0-\"eu\""
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvConfigFile, path)
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	profiles, err := LoadConfig(writeConfig(t, testConfig))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	want := map[string]Profile{
+		"default": {APIKey: "m0-default"},
+		"staging": {APIKey: "m0-staging", Host: "https://staging.example.com", OrgID: "org-2", ProjectID: "7", AuthScheme: "Bearer", Timeout: 30 * time.Second},
+		"eu prod": {APIKey: `m0-"eu"`},
+	}
+	if len(profiles) != len(want) {
+		t.Errorf("profiles = %+v, want %d", profiles, len(want))
+	}
+	for name, profile := range want {
+		if profiles[name] != profile {
+			t.Errorf("profile %q = %+v, want %+v", name, profiles[name], profile)
+		}
+	}
+
+	for _, bad := range []string{"api_key = \"x\"\n", "[default\n", "[default]\napi_key\n", "[default]\napi_key = \"x\n"} {
+		if _, err := LoadConfig(writeConfig(t, bad)); err == nil {
+			t.Errorf("LoadConfig(%q) expected error", bad)
+		}
+	}
+}
+
+func TestWithProfile(t *testing.T) {
+	writeConfig(t, testConfig)
+
+	options, err := ClientOptions{OrganizationID: "org-explicit"}.WithProfile("staging")
+	if err != nil {
+		t.Fatalf("WithProfile() error = %v", err)
+	}
+	if options.APIKey != "m0-staging" || *options.Host != "https://staging.example.com" || options.OrganizationID != "org-explicit" ||
+		options.ProjectID != "7" || options.AuthScheme != AuthSchemeBearer || options.Timeout != 30*time.Second {
+		t.Errorf("WithProfile() = %+v", options)
+	}
+
+	var validationErr *ValidationError
+	if _, err := (ClientOptions{}).WithProfile("missing"); !errors.As(err, &validationErr) {
+		t.Errorf("WithProfile(missing) error = %v, want *ValidationError", err)
+	}
+
+	t.Run("selected by environment", func(t *testing.T) {
+		t.Setenv(EnvProfile, "staging")
+		t.Setenv(EnvAPIKey, "m0-env")
+		options, err := OptionsFromEnv()
+		if err != nil || options.APIKey != "m0-env" || options.OrganizationID != "org-2" {
+			t.Errorf("OptionsFromEnv() = %+v, %v; want env key over staging profile", options, err)
+		}
+	})
+}
+
+func TestTOMLValue(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{`"abc\\"`, `abc\`},
+		{`"a\"b\\" # comment`, `a"b\`},
+		{`"tab\there\nline"`, "tab\there\nline"},
+		{`"\u00e9\U0001F600"`, "é😀"},
+		{`'C:\keys\m0'`, `C:\keys\m0`},
+		{`30`, "30"},
+		{`+1_000`, "1000"},
+		{`-5 # seconds`, "-5"},
+		{`true`, "true"},
+	}
+	for _, tt := range tests {
+		got, err := tomlValue(tt.raw)
+		if err != nil || got != tt.want {
+			t.Errorf("tomlValue(%s) = %q, %v; want %q", tt.raw, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{
+		`abc`,              // bare string
+		`"abc\"`,           // escaped closing quote
+		`"a\qb"`,           // unknown escape
+		`"\u12"`,           // short unicode escape
+		`"\uD800"`,         // surrogate
+		`"a" "b"`,          // trailing junk
+		`'abc`,             // unterminated literal
+		`"""multi"""`,      // multi-line string
+		`012`,              // leading zero
+		`1__0`,             // doubled underscore
+		`1.5`,              // float
+		`2024-01-01`,       // date
+		"\"a\x01b\"",       // control character
+		`["m0-a", "m0-b"]`, // array
+	} {
+		if got, err := tomlValue(bad); err == nil {
+			t.Errorf("tomlValue(%s) = %q, want an error", bad, got)
+		}
+	}
+}
+
+func TestLoadConfigRejectsUnsupportedTOML(t *testing.T) {
+	for _, bad := range []string{
+		"[default.eu]\napi_key = \"x\"\n",
+		"[[default]]\napi_key = \"x\"\n",
+		"[default]\nauth.scheme = \"Bearer\"\n",
+		"[default] junk\n",
+		"[default]\napi_key = \"x\"\n[default]\n",
+		"[default]\napi_key = \"x\"\napi_key = \"y\"\n",
+	} {
+		if _, err := LoadConfig(writeConfig(t, bad)); err == nil {
+			t.Errorf("LoadConfig(%q) expected error", bad)
+		}
+	}
+
+	profiles, err := LoadConfig(writeConfig(t, "[\"eu.prod\"] # quoted\n\"api_key\" = 'm0-\\x'\n"))
+	if err != nil || profiles["eu.prod"].APIKey != `m0-\x` {
+		t.Errorf("LoadConfig() = %+v, %v; want quoted table and key", profiles, err)
+	}
+}
+
+func TestLoadConfigReportsBadKeys(t *testing.T) {
+	path := writeConfig(t, "[default]\napikey = \"m0-x\"\n")
+	if _, err := LoadConfig(path); err == nil || err.Error() != path+`:2: unknown key "apikey"` {
+		t.Errorf("LoadConfig() error = %v, want the unknown key and its line", err)
+	}
+
+	path = writeConfig(t, "[default]\napi_key = \"m0-x\"\ntimeout = \"soon\"\n")
+	var validationErr *ValidationError
+	if _, err := LoadConfig(path); !errors.As(err, &validationErr) || validationErr.Field != "timeout" {
+		t.Errorf("LoadConfig() error = %v, want a ValidationError naming timeout", err)
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// IfMatchHeader carries the hash a conditional Update or Delete expects
+const IfMatchHeader = "If-Match"
+
+type ifMatchHashContext struct{}
+
+// WithIfMatchHash returns a context whose Update, UpdateMemory and Delete
+// calls only go ahead while the memory's Hash is still hash, failing with a
+// *ConflictError otherwise. Pass the Hash of the Memory the change is based
+// on to keep concurrent agents from overwriting each other's updates.
+//
+// The client reads the memory to compare hashes before writing and also
+// sends the hash in the If-Match header, for servers that enforce it.
+func WithIfMatchHash(ctx context.Context, hash string) context.Context {
+	return context.WithValue(ctx, ifMatchHashContext{}, hash)
+}
+
+// ConflictError reports that a memory changed since the caller read it
+type ConflictError struct {
+	MemoryID     string
+	ExpectedHash string
+	ActualHash   string // Empty when the server rejected the write itself
+}
+
+// Error implements the error interface
+func (e *ConflictError) Error() string {
+	if e.ActualHash == "" {
+		return fmt.Sprintf("memory %s changed since hash %s was read", e.MemoryID, e.ExpectedHash)
+	}
+	return fmt.Sprintf("memory %s changed: hash is %s, expected %s", e.MemoryID, e.ActualHash, e.ExpectedHash)
+}
+
+// ifMatchHash returns the hash set on ctx with WithIfMatchHash
+func ifMatchHash(ctx context.Context) (string, bool) {
+	hash, ok := ctx.Value(ifMatchHashContext{}).(string)
+	return hash, ok && hash != ""
+}
+
+// checkIfMatch fails with a *ConflictError when ctx carries an expected hash
+// that the memory no longer has
+func (c *MemoryClient) checkIfMatch(ctx context.Context, memoryID string) error {
+	expected, ok := ifMatchHash(ctx)
+	if !ok {
+		return nil
+	}
+	memory, err := c.Get(ctx, memoryID)
+	if err != nil {
+		return err
+	}
+	if actual := stringValue(memory.Hash); actual != expected {
+		return &ConflictError{MemoryID: memoryID, ExpectedHash: expected, ActualHash: actual}
+	}
+	return nil
+}
+
+// conflictError turns a 409 or 412 response to a conditional write into a
+// *ConflictError
+func conflictError(ctx context.Context, memoryID string, err error) error {
+	expected, ok := ifMatchHash(ctx)
+	var apiErr *APIError
+	if !ok || errors.Is(err, ErrImmutableMemory) || !errors.As(err, &apiErr) {
+		return err
+	}
+	if apiErr.StatusCode != http.StatusConflict && apiErr.StatusCode != http.StatusPreconditionFailed {
+		return err
+	}
+	return &ConflictError{MemoryID: memoryID, ExpectedHash: expected}
+}
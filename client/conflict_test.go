@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIfMatchHash(t *testing.T) {
+	var ifMatch string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id":"mem-1","memory":"Likes tea","hash":"h1"}`))
+		default:
+			// The server also enforces the hash and has seen a newer write
+			ifMatch = r.Header.Get(IfMatchHeader)
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"error":"hash mismatch"}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &srv.URL})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	var conflict *ConflictError
+	_, err = client.UpdateMemory(WithIfMatchHash(ctx, "h0"), "mem-1", UpdateRequest{Text: stringPtr("Likes coffee")})
+	if !errors.As(err, &conflict) || conflict.ActualHash != "h1" || conflict.ExpectedHash != "h0" {
+		t.Fatalf("UpdateMemory(stale hash) error = %v, want *ConflictError with hashes", err)
+	}
+	if ifMatch != "" {
+		t.Errorf("stale write reached the server with If-Match %q", ifMatch)
+	}
+
+	_, err = client.Delete(WithIfMatchHash(ctx, "h1"), "mem-1")
+	if !errors.As(err, &conflict) || conflict.ActualHash != "" {
+		t.Fatalf("Delete(server conflict) error = %v, want *ConflictError", err)
+	}
+	if ifMatch != "h1" {
+		t.Errorf("If-Match = %q, want h1", ifMatch)
+	}
+
+	var apiErr *APIError
+	if _, err := client.Delete(ctx, "mem-1"); errors.As(err, &conflict) || !errors.As(err, &apiErr) {
+		t.Errorf("Delete(unconditional) error = %v, want plain *APIError", err)
+	}
+}
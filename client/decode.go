@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxPreallocatedMemories bounds the capacity reserved from a response's
+// count, which counts every page rather than the one being decoded
+const maxPreallocatedMemories = 1024
+
+// fetchMemories makes a request like fetchWithErrorHandling and decodes a
+// list of memories from either the v1.0 plain list or the v1.1
+// {"results": [...]} envelope. With StdCodec the memories are streamed from
+// the response body instead of reading it whole first.
+func (c *MemoryClient) fetchMemories(ctx context.Context, method, endpoint string, body interface{}) ([]Memory, error) {
+	if codec := c.jsonCodec(); codec != StdCodec {
+		data, err := c.request(ctx, method, endpoint, body)
+		if err != nil {
+			return nil, err
+		}
+		memories, err := unmarshalMemories(codec, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse response JSON: %w", err)
+		}
+		return memories, nil
+	}
+
+	resp, requestID, err := c.send(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	memories, err := decodeMemories(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response JSON of request %s: %w", requestID, err)
+	}
+	// Drain what follows the JSON value up to io.EOF so the connection can be
+	// reused and the body does not count as undrained
+	io.Copy(io.Discard, resp.Body)
+	return memories, nil
+}
+
+// unmarshalMemories decodes a list of memories from data with codec
+func unmarshalMemories(codec Codec, data []byte) ([]Memory, error) {
+	for _, b := range data {
+		if b == ' ' || b == '\t' || b == '\r' || b == '\n' {
+			continue
+		}
+		if b == '{' {
+			var envelope struct {
+				Results []Memory `json:"results"`
+			}
+			err := codec.Unmarshal(data, &envelope)
+			return envelope.Results, err
+		}
+		break
+	}
+	var memories []Memory
+	err := codec.Unmarshal(data, &memories)
+	return memories, err
+}
+
+// decodeMemories streams a list of memories from r, one memory at a time.
+// Fields of the envelope other than results are skipped, and its count, when
+// it comes first, sizes the slice.
+func decodeMemories(r io.Reader) ([]Memory, error) {
+	dec := json.NewDecoder(r)
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case nil:
+		return nil, nil
+	case json.Delim('['):
+		return decodeMemoryElements(dec, 0)
+	case json.Delim('{'):
+	default:
+		return nil, fmt.Errorf("expected a list of memories, got %v", token)
+	}
+
+	var memories []Memory
+	capacity := 0
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "count":
+			var count *int
+			if err := dec.Decode(&count); err != nil {
+				return nil, err
+			}
+			if count != nil {
+				capacity = min(max(*count, 0), maxPreallocatedMemories)
+			}
+		case "results":
+			token, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			if token == nil {
+				continue
+			}
+			if token != json.Delim('[') {
+				return nil, fmt.Errorf("expected a list of results, got %v", token)
+			}
+			if memories, err = decodeMemoryElements(dec, capacity); err != nil {
+				return nil, err
+			}
+		default:
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return memories, nil
+}
+
+// decodeMemoryElements decodes the memories of a list whose opening bracket
+// dec has already read, through its closing bracket
+func decodeMemoryElements(dec *json.Decoder, capacity int) ([]Memory, error) {
+	memories := make([]Memory, 0, capacity)
+	for dec.More() {
+		memories = append(memories, Memory{})
+		if err := dec.Decode(&memories[len(memories)-1]); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return memories, nil
+}
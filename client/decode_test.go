@@ -0,0 +1,121 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDecodeMemories(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "list", body: `[{"id":"a"},{"id":"b"}]`, want: []string{"a", "b"}},
+		{name: "empty list", body: ` [] `, want: []string{}},
+		{name: "envelope", body: `{"count":2,"next":null,"results":[{"id":"a"},{"id":"b"}]}`, want: []string{"a", "b"}},
+		{name: "count after results", body: `{"results":[{"id":"a"}],"count":1}`, want: []string{"a"}},
+		{name: "nested fields skipped", body: `{"relations":[{"source":"a"}],"meta":{"x":[1]},"results":[{"id":"a","metadata":{"k":[1,2]}}]}`, want: []string{"a"}},
+		{name: "null", body: `null`},
+		{name: "null results", body: `{"results":null}`},
+		{name: "no results", body: `{"count":0}`},
+		{name: "truncated", body: `[{"id":"a"},`, wantErr: true},
+		{name: "scalar", body: `"a"`, wantErr: true},
+		{name: "results not a list", body: `{"results":{"id":"a"}}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memories, err := decodeMemories(strings.NewReader(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeMemories() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (memories == nil) != (tt.want == nil) || len(memories) != len(tt.want) {
+				t.Fatalf("decodeMemories() = %+v, want IDs %v", memories, tt.want)
+			}
+			for i, id := range tt.want {
+				if memories[i].ID != id {
+					t.Errorf("memory %d ID = %q, want %q", i, memories[i].ID, id)
+				}
+			}
+
+			// The codec path must agree with the streaming one
+			unmarshaled, err := unmarshalMemories(StdCodec, []byte(tt.body))
+			if err != nil || len(unmarshaled) != len(memories) {
+				t.Errorf("unmarshalMemories() = %d memories, %v; want %d", len(unmarshaled), err, len(memories))
+			}
+		})
+	}
+}
+
+func TestDecodeMemoriesPreallocatesFromCount(t *testing.T) {
+	memories, err := decodeMemories(strings.NewReader(`{"count":5000,"results":[{"id":"a"}]}`))
+	if err != nil {
+		t.Fatalf("decodeMemories() error = %v", err)
+	}
+	if cap(memories) != maxPreallocatedMemories {
+		t.Errorf("cap = %d, want %d", cap(memories), maxPreallocatedMemories)
+	}
+}
+
+// memoryPage returns a v1.1 envelope of n memories shaped like a GetAll page
+func memoryPage(n int) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `{"count":%d,"next":null,"previous":null,"results":[`, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"id":"mem-%d","memory":"User prefers dark roast coffee in the morning","user_id":"alex",`+
+			`"hash":"%032x","categories":["food","preferences"],"metadata":{"source":"chat","turn":%d},`+
+			`"created_at":"2024-07-20T10:00:00Z","updated_at":"2024-07-20T10:00:00Z","score":0.87}`, i, i, i)
+	}
+	buf.WriteString(`]}`)
+	return buf.Bytes()
+}
+
+func BenchmarkDecodeMemories(b *testing.B) {
+	for _, n := range []int{10, 1000} {
+		page := memoryPage(n)
+
+		b.Run(fmt.Sprintf("stream/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(page)))
+			for i := 0; i < b.N; i++ {
+				if _, err := decodeMemories(bytes.NewReader(page)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("unmarshal/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(page)))
+			for i := 0; i < b.N; i++ {
+				if _, err := unmarshalMemories(StdCodec, page); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		// The generic decode and re-marshal clients did before decoding
+		// responses straight into their results
+		b.Run(fmt.Sprintf("generic/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(page)))
+			for i := 0; i < b.N; i++ {
+				var generic interface{}
+				if err := StdCodec.Unmarshal(page, &generic); err != nil {
+					b.Fatal(err)
+				}
+				var memories []Memory
+				if err := parseResponse(generic.(map[string]interface{})["results"], &memories); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// searchCall is a search in flight that identical concurrent searches wait on
+type searchCall struct {
+	done     chan struct{}
+	memories []Memory
+	err      error
+}
+
+// sharedSearch sends a search unless an identical one is already in flight,
+// in which case it waits for that one's results. Searches of different
+// tenants are never identical. The shared request is not cancelled when a
+// waiting caller gives up; it is bounded by the client's timeout.
+//
+// Searches made with CaptureResponse or WithRequestID expect a request of
+// their own and are not shared.
+func (c *MemoryClient) sharedSearch(ctx context.Context, route route, payload map[string]interface{}) ([]Memory, error) {
+	if _, ok := RequestID(ctx); ok || capturedResponse(ctx) != nil {
+		return c.fetchMemories(ctx, route.Method, route.Path, payload)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	tenant, _ := TenantFrom(ctx)
+	scope, err := json.Marshal(tenant)
+	if err != nil {
+		return nil, err
+	}
+	key := route.Method + " " + route.Path + " " + string(scope) + " " + string(body)
+
+	c.searchMu.Lock()
+	call, inFlight := c.searches[key]
+	if !inFlight {
+		call = &searchCall{done: make(chan struct{})}
+		if c.searches == nil {
+			c.searches = map[string]*searchCall{}
+		}
+		c.searches[key] = call
+	}
+	c.searchMu.Unlock()
+
+	if !inFlight {
+		go func() {
+			memories, err := c.fetchMemories(sharedContext{context.WithoutCancel(ctx)}, route.Method, route.Path, payload)
+			call.memories = memories
+			call.err = err
+
+			c.searchMu.Lock()
+			delete(c.searches, key)
+			c.searchMu.Unlock()
+			close(call.done)
+		}()
+	}
+
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return nil, call.err
+		}
+		// Callers may append to their results; give each its own slice
+		return append([]Memory(nil), call.memories...), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// sharedContext hides the values that only concern the call that started a
+// shared search from the request made on behalf of every waiting caller
+type sharedContext struct {
+	context.Context
+}
+
+// Value implements context.Context
+func (c sharedContext) Value(key interface{}) interface{} {
+	switch key.(type) {
+	case responseContext, requestIDContext, idempotencyKeyContext, requestIdempotencyKeyContext, ifMatchHashContext:
+		return nil
+	}
+	return c.Context.Value(key)
+}
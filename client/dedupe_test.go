@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newSearchCountingClient returns a client whose server counts searches and
+// answers each one after a short delay
+func newSearchCountingClient(t *testing.T, options ClientOptions) (*MemoryClient, *atomic.Int32) {
+	t.Helper()
+
+	var searches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/ping/" {
+			w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1","user_email":"test@example.com"}`))
+			return
+		}
+		searches.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`[{"id":"mem-1","memory":"Likes tea"}]`))
+	}))
+	t.Cleanup(srv.Close)
+
+	options.APIKey = "test-api-key"
+	options.Host = &srv.URL
+	client, err := NewMemoryClient(options)
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	return client, &searches
+}
+
+func searchConcurrently(t *testing.T, client *MemoryClient, users ...string) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	for _, user := range users {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			memories, err := client.Search(context.Background(), "tea", SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr(user)}})
+			if err != nil {
+				t.Errorf("Search() error = %v", err)
+				return
+			}
+			if len(memories) != 1 || memories[0].ID != "mem-1" {
+				t.Errorf("Search() = %+v", memories)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDedupeSearches(t *testing.T) {
+	client, searches := newSearchCountingClient(t, ClientOptions{DedupeSearches: true})
+
+	searchConcurrently(t, client, "alex", "alex", "alex", "alex", "alex", "sam", "sam")
+	if got := searches.Load(); got != 2 {
+		t.Errorf("searches = %d, want 2 (one per user)", got)
+	}
+
+	// A finished search is not reused
+	searchConcurrently(t, client, "alex")
+	if got := searches.Load(); got != 3 {
+		t.Errorf("searches = %d, want 3", got)
+	}
+}
+
+func TestDedupeSearchesDisabledByDefault(t *testing.T) {
+	client, searches := newSearchCountingClient(t, ClientOptions{})
+
+	searchConcurrently(t, client, "alex", "alex", "alex")
+	if got := searches.Load(); got != 3 {
+		t.Errorf("searches = %d, want 3", got)
+	}
+}
+
+func TestDedupeSearchesWaiterCancelled(t *testing.T) {
+	client, searches := newSearchCountingClient(t, ClientOptions{DedupeSearches: true})
+	options := SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.Search(ctx, "tea", options); err != context.DeadlineExceeded {
+		t.Fatalf("Search() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The shared request outlives the caller that started it
+	memories, err := client.Search(context.Background(), "tea", options)
+	if err != nil || len(memories) != 1 {
+		t.Fatalf("Search() = %+v, %v", memories, err)
+	}
+	if got := searches.Load(); got != 1 {
+		t.Errorf("searches = %d, want 1", got)
+	}
+}
+
+func TestDedupeSearchesKeepsPerCallValues(t *testing.T) {
+	client, searches := newSearchCountingClient(t, ClientOptions{DedupeSearches: true})
+	options := SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}}
+
+	// Captured searches get a request and a Response of their own
+	responses := make([]Response, 3)
+	var wg sync.WaitGroup
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Search(CaptureResponse(context.Background(), &responses[i]), "tea", options); err != nil {
+				t.Errorf("Search() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := searches.Load(); got != 3 {
+		t.Errorf("searches = %d, want one per captured call", got)
+	}
+	if responses[0].RequestID == responses[1].RequestID || responses[1].RequestID == responses[2].RequestID {
+		t.Errorf("captured request IDs = %q, %q, %q, want distinct", responses[0].RequestID, responses[1].RequestID, responses[2].RequestID)
+	}
+
+	// Searches of different tenants are not merged
+	searches.Store(0)
+	for _, project := range []string{"proj-a", "proj-b", "proj-a"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := WithTenant(context.Background(), Tenant{OrgID: "org-1", ProjectID: project})
+			if _, err := client.Search(ctx, "tea", options); err != nil {
+				t.Errorf("Search() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := searches.Load(); got != 2 {
+		t.Errorf("searches = %d, want one per tenant", got)
+	}
+}
+
+func TestSharedContextHidesPerCallValues(t *testing.T) {
+	var response Response
+	ctx := CaptureResponse(WithRequestID(context.Background(), "req-1"), &response)
+	ctx = withRequestIdempotencyKey(WithIfMatchHash(WithIdempotencyKey(ctx, "key-1"), "h1"))
+	ctx = WithTenant(ctx, Tenant{UserID: "alex"})
+
+	shared := sharedContext{context.WithoutCancel(ctx)}
+	if _, ok := RequestID(shared); ok {
+		t.Error("shared context carries the request ID")
+	}
+	if capturedResponse(shared) != nil {
+		t.Error("shared context carries the captured Response")
+	}
+	if _, ok := IdempotencyKey(shared); ok || requestIdempotencyKey(shared) != "" {
+		t.Error("shared context carries the idempotency key")
+	}
+	if _, ok := ifMatchHash(shared); ok {
+		t.Error("shared context carries the If-Match hash")
+	}
+	if tenant, ok := TenantFrom(shared); !ok || tenant.UserID != "alex" {
+		t.Errorf("TenantFrom(shared) = %+v, %v; want the tenant kept", tenant, ok)
+	}
+}
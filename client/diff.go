@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MemoryChange is one event of a memory's history: its text before and after
+type MemoryChange struct {
+	Event Event
+	Old   string // Empty for EventAdd
+	New   string // Empty for EventDelete
+	Input []Message
+	At    time.Time
+}
+
+// IsConflict reports whether the change overwrote or removed earlier text,
+// which is where an agent "forgets" something
+func (c MemoryChange) IsConflict() bool {
+	switch c.Event {
+	case EventUpdate:
+		return c.Old != "" && c.Old != c.New
+	case EventDelete:
+		return true
+	}
+	return false
+}
+
+// String describes the change on one line, e.g. UPDATE "Likes tea" -> "Likes coffee"
+func (c MemoryChange) String() string {
+	switch {
+	case c.Old == "" && c.New == "":
+		return string(c.Event)
+	case c.Old == "":
+		return fmt.Sprintf("%s %q", c.Event, c.New)
+	case c.New == "":
+		return fmt.Sprintf("%s %q", c.Event, c.Old)
+	}
+	return fmt.Sprintf("%s %q -> %q", c.Event, c.Old, c.New)
+}
+
+// MemoryDiff is the change chain of one memory, oldest first
+type MemoryDiff struct {
+	MemoryID string
+	Changes  []MemoryChange
+}
+
+// LatestConflict returns the most recent change that overwrote or removed the
+// memory's text, or nil when there is none
+func (d *MemoryDiff) LatestConflict() *MemoryChange {
+	for i := len(d.Changes) - 1; i >= 0; i-- {
+		if d.Changes[i].IsConflict() {
+			return &d.Changes[i]
+		}
+	}
+	return nil
+}
+
+// String renders the chain with one timestamped change per line
+func (d *MemoryDiff) String() string {
+	var b strings.Builder
+	for _, change := range d.Changes {
+		fmt.Fprintf(&b, "%s %s\n", change.At.Format(time.RFC3339), change)
+	}
+	return b.String()
+}
+
+// DiffMemory fetches the history of memoryID and returns its change chain
+func DiffMemory(ctx context.Context, c Client, memoryID string) (*MemoryDiff, error) {
+	history, err := c.History(ctx, memoryID)
+	if err != nil {
+		return nil, err
+	}
+	return NewMemoryDiff(memoryID, history), nil
+}
+
+// NewMemoryDiff builds the change chain of a memory from its history entries,
+// which may be in any order
+func NewMemoryDiff(memoryID string, history []MemoryHistory) *MemoryDiff {
+	entries := append([]MemoryHistory(nil), history...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+
+	diff := &MemoryDiff{MemoryID: memoryID, Changes: make([]MemoryChange, len(entries))}
+	for i, entry := range entries {
+		diff.Changes[i] = MemoryChange{
+			Event: entry.Event,
+			Old:   stringValue(entry.OldMemory),
+			New:   stringValue(entry.NewMemory),
+			Input: entry.Input,
+			At:    entry.CreatedAt,
+		}
+	}
+	return diff
+}
+
+// stringValue returns *s, or the empty string when s is nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,57 @@
+package client_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+func TestDiffMemory(t *testing.T) {
+	added := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	mock := &clienttest.MockClient{
+		HistoryFunc: func(ctx context.Context, memoryID string) ([]client.MemoryHistory, error) {
+			// Returned newest first, as the API does
+			return []client.MemoryHistory{
+				{Event: client.EventNoop, OldMemory: strPtr("Likes coffee"), NewMemory: strPtr("Likes coffee"), CreatedAt: added.Add(3 * time.Hour)},
+				{Event: client.EventUpdate, OldMemory: strPtr("Likes tea"), NewMemory: strPtr("Likes coffee"), CreatedAt: added.Add(2 * time.Hour),
+					Input: []client.Message{{Role: "user", Content: "I switched to coffee"}}},
+				{Event: client.EventUpdate, OldMemory: strPtr("Likes tea"), NewMemory: strPtr("Likes tea"), CreatedAt: added.Add(time.Hour)},
+				{Event: client.EventAdd, NewMemory: strPtr("Likes tea"), CreatedAt: added},
+			}, nil
+		},
+	}
+
+	diff, err := client.DiffMemory(context.Background(), mock, "mem-1")
+	if err != nil {
+		t.Fatalf("DiffMemory() error = %v", err)
+	}
+	if len(diff.Changes) != 4 || diff.Changes[0].Event != client.EventAdd || diff.Changes[3].Event != client.EventNoop {
+		t.Fatalf("Changes = %+v, want oldest first", diff.Changes)
+	}
+
+	conflict := diff.LatestConflict()
+	if conflict == nil || conflict.Old != "Likes tea" || conflict.New != "Likes coffee" || len(conflict.Input) != 1 {
+		t.Errorf("LatestConflict() = %+v, want the tea -> coffee update", conflict)
+	}
+	if got := conflict.String(); got != `UPDATE "Likes tea" -> "Likes coffee"` {
+		t.Errorf("String() = %s", got)
+	}
+	if !strings.HasPrefix(diff.String(), `2024-07-01T09:00:00Z ADD "Likes tea"`+"\n") {
+		t.Errorf("diff.String() = %s", diff)
+	}
+
+	deleted := client.NewMemoryDiff("mem-2", []client.MemoryHistory{
+		{Event: client.EventAdd, NewMemory: strPtr("Lives in Lisbon"), CreatedAt: added},
+		{Event: client.EventDelete, OldMemory: strPtr("Lives in Lisbon"), CreatedAt: added.Add(time.Hour)},
+	})
+	if conflict := deleted.LatestConflict(); conflict == nil || conflict.Event != client.EventDelete {
+		t.Errorf("LatestConflict() = %+v, want the delete", conflict)
+	}
+	if conflict := client.NewMemoryDiff("mem-3", nil).LatestConflict(); conflict != nil {
+		t.Errorf("LatestConflict() of empty history = %+v, want nil", conflict)
+	}
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// MaxDocumentSize is the largest document NewDocumentFromFile encodes, in bytes
+const MaxDocumentSize = 10 << 20
+
+// Document content types
+const (
+	DocumentTypePDF = "pdf_url" // PDF documents
+	DocumentTypeMDX = "mdx_url" // MDX, Markdown and plain text documents
+)
+
+// DocumentURL locates document content: an http(s) URL, or base64 data
+type DocumentURL struct {
+	URL string `json:"url"`
+}
+
+// DocumentContent is document content in a message
+type DocumentContent struct {
+	Type   string       `json:"type"` // DocumentTypePDF or DocumentTypeMDX
+	PDFURL *DocumentURL `json:"pdf_url,omitempty"`
+	MDXURL *DocumentURL `json:"mdx_url,omitempty"`
+}
+
+// documentTypes maps supported MIME types to document content types
+var documentTypes = map[string]string{
+	"application/pdf": DocumentTypePDF,
+	"text/markdown":   DocumentTypeMDX,
+	"text/mdx":        DocumentTypeMDX,
+	"text/plain":      DocumentTypeMDX,
+}
+
+// documentExtensions maps file extensions to MIME types missing from the
+// system table
+var documentExtensions = map[string]string{
+	".md":       "text/markdown",
+	".markdown": "text/markdown",
+	".mdx":      "text/mdx",
+	".txt":      "text/plain",
+	".pdf":      "application/pdf",
+}
+
+// NewDocumentMessage creates a user message referencing the document at
+// documentURL. The type is taken from the URL's extension: .pdf, or .mdx,
+// .md and .txt.
+func NewDocumentMessage(documentURL string) (Message, error) {
+	if err := validateHTTPURL("document_url", documentURL); err != nil {
+		return Message{}, err
+	}
+	parsed, _ := url.Parse(documentURL)
+	mimeType := mimeTypeByExtension(path.Ext(parsed.Path))
+	contentType, ok := documentTypes[mimeType]
+	if !ok {
+		return Message{}, NewValidationError("document_url", fmt.Sprintf("cannot tell document type of %q; use a .pdf, .mdx, .md or .txt URL", documentURL))
+	}
+	return Message{Role: "user", Content: newDocumentContent(contentType, documentURL)}, nil
+}
+
+// NewDocumentFromFile creates a user message embedding the document at
+// filePath as base64. The type is detected from the extension, or from the
+// content when the extension is unknown.
+func NewDocumentFromFile(filePath string) (Message, error) {
+	data, err := readLimited(filePath, MaxDocumentSize)
+	if err != nil {
+		return Message{}, err
+	}
+
+	mimeType := mimeTypeByExtension(filepath.Ext(filePath))
+	if _, ok := documentTypes[mimeType]; !ok {
+		mimeType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+	}
+	contentType, ok := documentTypes[mimeType]
+	if !ok {
+		return Message{}, NewValidationError("document", fmt.Sprintf("unsupported document type %s for %s; supported: PDF, MDX, Markdown and plain text", mimeType, filepath.Base(filePath)))
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	if contentType == DocumentTypePDF {
+		encoded = "data:application/pdf;base64," + encoded
+	}
+	return Message{Role: "user", Content: newDocumentContent(contentType, encoded)}, nil
+}
+
+// Document returns the document content of the message. It accepts both
+// content built with the constructors and content decoded from JSON.
+func (m Message) Document() (*DocumentContent, bool) {
+	switch content := m.Content.(type) {
+	case DocumentContent:
+		return &content, content.Type == DocumentTypePDF || content.Type == DocumentTypeMDX
+	case *DocumentContent:
+		return content, content != nil && (content.Type == DocumentTypePDF || content.Type == DocumentTypeMDX)
+	case map[string]interface{}:
+		data, err := json.Marshal(content)
+		if err != nil {
+			return nil, false
+		}
+		var decoded DocumentContent
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded.URL() == "" {
+			return nil, false
+		}
+		return &decoded, true
+	default:
+		return nil, false
+	}
+}
+
+// URL returns the URL or data of the document
+func (d DocumentContent) URL() string {
+	switch {
+	case d.Type == DocumentTypePDF && d.PDFURL != nil:
+		return d.PDFURL.URL
+	case d.Type == DocumentTypeMDX && d.MDXURL != nil:
+		return d.MDXURL.URL
+	default:
+		return ""
+	}
+}
+
+func newDocumentContent(contentType, documentURL string) DocumentContent {
+	content := DocumentContent{Type: contentType}
+	if contentType == DocumentTypePDF {
+		content.PDFURL = &DocumentURL{URL: documentURL}
+	} else {
+		content.MDXURL = &DocumentURL{URL: documentURL}
+	}
+	return content
+}
+
+// mimeTypeByExtension returns the MIME type of ext without parameters
+func mimeTypeByExtension(ext string) string {
+	ext = strings.ToLower(ext)
+	if mimeType, ok := documentExtensions[ext]; ok {
+		return mimeType
+	}
+	mimeType, _, _ := mime.ParseMediaType(mime.TypeByExtension(ext))
+	return mimeType
+}
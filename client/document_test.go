@@ -0,0 +1,104 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewDocumentMessage(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantType string
+		wantErr  string
+	}{
+		{url: "https://example.com/handbook.pdf", wantType: DocumentTypePDF},
+		{url: "https://example.com/docs/intro.MDX?v=2", wantType: DocumentTypeMDX},
+		{url: "https://example.com/notes.txt", wantType: DocumentTypeMDX},
+		{url: "https://example.com/readme.md", wantType: DocumentTypeMDX},
+		{url: "https://example.com/sheet.xlsx", wantErr: "cannot tell document type"},
+		{url: "file:///tmp/handbook.pdf", wantErr: "http(s) URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			message, err := NewDocumentMessage(tt.url)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewDocumentMessage() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewDocumentMessage() error = %v", err)
+			}
+			document, ok := message.Document()
+			if !ok || document.Type != tt.wantType || document.URL() != tt.url {
+				t.Errorf("NewDocumentMessage() = %+v", message.Content)
+			}
+		})
+	}
+}
+
+func TestNewDocumentFromFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	markdown := write("notes.md", []byte("# Notes\nlikes tea"))
+	message, err := NewDocumentFromFile(markdown)
+	if err != nil {
+		t.Fatalf("NewDocumentFromFile(md) error = %v", err)
+	}
+	document, _ := message.Document()
+	if decoded, _ := base64.StdEncoding.DecodeString(document.URL()); document.Type != DocumentTypeMDX || string(decoded) != "# Notes\nlikes tea" {
+		t.Errorf("NewDocumentFromFile(md) = %+v", document)
+	}
+
+	pdf := write("scan", []byte("%PDF-1.7\n%âãÏÓ\n"))
+	message, err = NewDocumentFromFile(pdf)
+	if err != nil {
+		t.Fatalf("NewDocumentFromFile(pdf) error = %v", err)
+	}
+	if document, _ := message.Document(); document.Type != DocumentTypePDF || !strings.HasPrefix(document.URL(), "data:application/pdf;base64,") {
+		t.Errorf("NewDocumentFromFile(pdf) = %+v", document)
+	}
+
+	if _, err := NewDocumentFromFile(write("image.png", pngHeader)); err == nil || !strings.Contains(err.Error(), "unsupported document type") {
+		t.Errorf("NewDocumentFromFile(png) error = %v", err)
+	}
+}
+
+func TestDocumentMessageRoundTrip(t *testing.T) {
+	message, err := NewDocumentMessage("https://example.com/handbook.pdf")
+	if err != nil {
+		t.Fatalf("NewDocumentMessage() error = %v", err)
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"role":"user","content":{"type":"pdf_url","pdf_url":{"url":"https://example.com/handbook.pdf"}}}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded Message
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if document, ok := decoded.Document(); !ok || document.URL() != "https://example.com/handbook.pdf" {
+		t.Errorf("decoded Document() = %+v, %v", document, ok)
+	}
+	if _, ok := decoded.Image(); ok {
+		t.Error("Image() of document message reported an image")
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// UnmarshalJSON reads an event in any case. Events this client does not know
+// are kept, upper-cased, and report IsKnown false.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, strings.ToUpper)
+	*e = Event(value)
+	return err
+}
+
+// IsKnown reports whether e is one of the Event constants
+func (e Event) IsKnown() bool {
+	switch e {
+	case EventAdd, EventUpdate, EventDelete, EventNoop:
+		return true
+	}
+	return false
+}
+
+// UnmarshalJSON reads feedback in any case. Values this client does not know
+// are kept, upper-cased, and report IsKnown false.
+func (f *Feedback) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, strings.ToUpper)
+	*f = Feedback(value)
+	return err
+}
+
+// IsKnown reports whether f is one of the Feedback constants
+func (f Feedback) IsKnown() bool {
+	switch f {
+	case FeedbackPositive, FeedbackNegative, FeedbackVeryNegative:
+		return true
+	}
+	return false
+}
+
+// UnmarshalJSON reads a webhook event in any case. Events this client does
+// not know are kept, lower-cased, and report IsKnown false.
+func (w *WebhookEvent) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, strings.ToLower)
+	*w = WebhookEvent(value)
+	return err
+}
+
+// IsKnown reports whether w is one of the WebhookEvent constants
+func (w WebhookEvent) IsKnown() bool {
+	switch w {
+	case WebhookEventMemoryAdded, WebhookEventMemoryUpdated, WebhookEventMemoryDeleted:
+		return true
+	}
+	return false
+}
+
+// unmarshalEnum decodes a JSON string and normalizes its case. JSON null
+// decodes to the empty string.
+func unmarshalEnum(data []byte, normalize func(string) string) (string, error) {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", err
+	}
+	if value == nil {
+		return "", nil
+	}
+	return normalize(strings.TrimSpace(*value)), nil
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumUnmarshal(t *testing.T) {
+	var entry struct {
+		Event    Event          `json:"event"`
+		Feedback *Feedback      `json:"feedback"`
+		Webhooks []WebhookEvent `json:"event_types"`
+	}
+	data := `{"event": "update", "feedback": "Very_Negative", "event_types": ["MEMORY_ADD", "memory_merge"]}`
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if entry.Event != EventUpdate || !entry.Event.IsKnown() {
+		t.Errorf("Event = %q, known %v; want UPDATE", entry.Event, entry.Event.IsKnown())
+	}
+	if entry.Feedback == nil || *entry.Feedback != FeedbackVeryNegative || !entry.Feedback.IsKnown() {
+		t.Errorf("Feedback = %v, want VERY_NEGATIVE", entry.Feedback)
+	}
+	if len(entry.Webhooks) != 2 || entry.Webhooks[0] != WebhookEventMemoryAdded {
+		t.Fatalf("event_types = %v, want memory_add first", entry.Webhooks)
+	}
+	if entry.Webhooks[1] != "memory_merge" || entry.Webhooks[1].IsKnown() {
+		t.Errorf("event_types[1] = %q, known %v; want unknown memory_merge", entry.Webhooks[1], entry.Webhooks[1].IsKnown())
+	}
+}
+
+func TestEnumUnknownValues(t *testing.T) {
+	var events []Event
+	if err := json.Unmarshal([]byte(`["merge", "Archive", null]`), &events); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if events[0] != "MERGE" || events[1] != "ARCHIVE" || events[0] == events[1] {
+		t.Errorf("events = %v, want distinct MERGE and ARCHIVE", events)
+	}
+	for _, event := range events {
+		if event.IsKnown() {
+			t.Errorf("%q.IsKnown() = true, want false", event)
+		}
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(`42`), &event); err == nil {
+		t.Error("Unmarshal(42) error = nil, want error")
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Environment variables read by OptionsFromEnv
+const (
+	EnvAPIKey     = "MEM0_API_KEY"
+	EnvHost       = "MEM0_HOST"
+	EnvOrgID      = "MEM0_ORG_ID"
+	EnvProjectID  = "MEM0_PROJECT_ID"
+	EnvTimeout    = "MEM0_TIMEOUT"     // Go duration such as "30s", or whole seconds
+	EnvAuthScheme = "MEM0_AUTH_SCHEME" // AuthSchemeToken or AuthSchemeBearer
+)
+
+// OptionsFromEnv builds ClientOptions from the MEM0_* environment variables.
+// When MEM0_PROFILE names a config file profile, it fills the options the
+// environment leaves unset. Other unset options keep their defaults.
+func OptionsFromEnv() (ClientOptions, error) {
+	options := ClientOptions{
+		APIKey:     os.Getenv(EnvAPIKey),
+		AuthScheme: os.Getenv(EnvAuthScheme),
+	}
+	if host := os.Getenv(EnvHost); host != "" {
+		options.Host = &host
+	}
+	if orgID := os.Getenv(EnvOrgID); orgID != "" {
+		options.OrganizationID = orgID
+	}
+	if projectID := os.Getenv(EnvProjectID); projectID != "" {
+		options.ProjectID = projectID
+	}
+	if value := os.Getenv(EnvTimeout); value != "" {
+		timeout, err := parseTimeout(EnvTimeout, value)
+		if err != nil {
+			return ClientOptions{}, err
+		}
+		options.Timeout = timeout
+	}
+	if profile := os.Getenv(EnvProfile); profile != "" {
+		return options.WithProfile(profile)
+	}
+	return options, nil
+}
+
+// NewFromEnv creates a MemoryClient configured from the MEM0_* environment
+// variables
+func NewFromEnv() (*MemoryClient, error) {
+	options, err := OptionsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewMemoryClient(options)
+}
+
+// parseTimeout parses a duration or a whole number of seconds, naming field
+// in its error
+func parseTimeout(field, value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if timeout, err := time.ParseDuration(value); err == nil && timeout > 0 {
+		return timeout, nil
+	}
+	return 0, NewValidationError(field, fmt.Sprintf("must be a positive duration or number of seconds, got %q", value))
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOptionsFromEnv(t *testing.T) {
+	t.Setenv(EnvAPIKey, "m0-env")
+	t.Setenv(EnvHost, "https://mem0.internal")
+	t.Setenv(EnvOrgID, "org-1")
+	t.Setenv(EnvProjectID, "proj-1")
+	t.Setenv(EnvAuthScheme, AuthSchemeBearer)
+
+	tests := []struct {
+		timeout string
+		want    time.Duration
+		wantErr bool
+	}{
+		{timeout: "", want: 0},
+		{timeout: "45", want: 45 * time.Second},
+		{timeout: "1m30s", want: 90 * time.Second},
+		{timeout: "-5s", wantErr: true},
+		{timeout: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeout, func(t *testing.T) {
+			t.Setenv(EnvTimeout, tt.timeout)
+
+			options, err := OptionsFromEnv()
+			if tt.wantErr {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) || validationErr.Field != EnvTimeout {
+					t.Errorf("OptionsFromEnv() error = %v, want %s validation error", err, EnvTimeout)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OptionsFromEnv() error = %v", err)
+			}
+			if options.APIKey != "m0-env" || *options.Host != "https://mem0.internal" || options.OrganizationID != "org-1" ||
+				options.ProjectID != "proj-1" || options.AuthScheme != AuthSchemeBearer || options.Timeout != tt.want {
+				t.Errorf("OptionsFromEnv() = %+v", options)
+			}
+		})
+	}
+}
+
+func TestNewFromEnvRequiresKey(t *testing.T) {
+	t.Setenv(EnvAPIKey, "")
+
+	var validationErr *ValidationError
+	if _, err := NewFromEnv(); !errors.As(err, &validationErr) {
+		t.Errorf("NewFromEnv() error = %v, want *ValidationError", err)
+	}
+}
+
+func TestTimeoutOption(t *testing.T) {
+	client, _ := newStubClient(t, 200, `{}`)
+	if client.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("default timeout = %v, want %v", client.httpClient.Timeout, DefaultTimeout)
+	}
+
+	host := "http://127.0.0.1:0"
+	custom, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &host, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	if custom.httpClient.Timeout != 5*time.Second {
+		t.Errorf("timeout = %v, want 5s", custom.httpClient.Timeout)
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrErasureUnverified is returned by EraseUserData when memories of the user
+// are still listed after the last verification attempt
+var ErrErasureUnverified = errors.New("erasure could not be verified")
+
+// Defaults of EraseOptions
+const (
+	DefaultEraseAttempts = 5
+	DefaultEraseInterval = 2 * time.Second
+)
+
+// EraseOptions configures the verification of EraseUserData
+type EraseOptions struct {
+	Attempts int           // GetAll checks before giving up, DefaultEraseAttempts when zero
+	Interval time.Duration // Wait between checks, DefaultEraseInterval when zero
+}
+
+// ErasureReport records an erasure for compliance evidence
+type ErasureReport struct {
+	UserID            string    `json:"user_id"`
+	RequestedAt       time.Time `json:"requested_at"`
+	MemoriesBefore    int       `json:"memories_before"`
+	DeleteMessage     string    `json:"delete_message"`
+	Attempts          int       `json:"verification_attempts"`
+	MemoriesRemaining int       `json:"memories_remaining"`
+	Verified          bool      `json:"verified"`
+	VerifiedAt        time.Time `json:"verified_at,omitzero"`
+}
+
+// EraseUserData deletes the user entity and all of its memories, then lists
+// the user's memories until none remain, allowing for eventual consistency.
+// The report is returned even on error; the error wraps ErrErasureUnverified
+// when memories were still listed after the last attempt. A user the API does
+// not know counts as already erased.
+func EraseUserData(ctx context.Context, c Client, userID string, options ...EraseOptions) (*ErasureReport, error) {
+	if userID == "" {
+		return nil, NewValidationError("user_id", "user ID is required")
+	}
+	opts := EraseOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Attempts <= 0 {
+		opts.Attempts = DefaultEraseAttempts
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultEraseInterval
+	}
+
+	report := &ErasureReport{UserID: userID, RequestedAt: time.Now().UTC()}
+	listOptions := SearchOptions{MemoryOptions: MemoryOptions{UserID: &userID}}
+
+	before, err := c.GetAll(ctx, listOptions)
+	if err != nil {
+		return report, fmt.Errorf("failed to list memories before erasure: %w", err)
+	}
+	report.MemoriesBefore = len(before)
+
+	deleted, err := c.DeleteUsers(ctx, DeleteUsersParams{UserID: &userID})
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+		report.DeleteMessage = "User not found"
+	case err != nil:
+		return report, fmt.Errorf("failed to delete user: %w", err)
+	case deleted != nil:
+		report.DeleteMessage = deleted.Message
+	}
+
+	for attempt := 1; attempt <= opts.Attempts; attempt++ {
+		report.Attempts = attempt
+		remaining, err := c.GetAll(ctx, listOptions)
+		if err != nil {
+			return report, fmt.Errorf("failed to verify erasure: %w", err)
+		}
+		report.MemoriesRemaining = len(remaining)
+		if len(remaining) == 0 {
+			report.Verified = true
+			report.VerifiedAt = time.Now().UTC()
+			return report, nil
+		}
+		if attempt == opts.Attempts {
+			break
+		}
+
+		timer := time.NewTimer(opts.Interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return report, ctx.Err()
+		}
+	}
+
+	return report, fmt.Errorf("%w: %d memories of user %s remain after %d checks",
+		ErrErasureUnverified, report.MemoriesRemaining, userID, report.Attempts)
+}
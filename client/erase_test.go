@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+func TestEraseUserData(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	memoryClient, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	for _, userID := range []string{"alex", "alex", "sam"} {
+		if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "I like tea"}}, client.MemoryOptions{UserID: strPtr(userID)}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	report, err := client.EraseUserData(ctx, memoryClient, "alex")
+	if err != nil {
+		t.Fatalf("EraseUserData() error = %v", err)
+	}
+	if !report.Verified || report.MemoriesBefore != 2 || report.MemoriesRemaining != 0 || report.Attempts != 1 || report.VerifiedAt.IsZero() {
+		t.Errorf("report = %+v, want 2 memories erased and verified on the first check", report)
+	}
+	if remaining := srv.Memories(); len(remaining) != 1 {
+		t.Errorf("remaining memories = %d, want sam's only", len(remaining))
+	}
+
+	report, err = client.EraseUserData(ctx, memoryClient, "kim")
+	if err != nil || !report.Verified {
+		t.Errorf("EraseUserData(unknown user) = %+v, %v; want verified", report, err)
+	}
+}
+
+func TestEraseUserDataEventualConsistency(t *testing.T) {
+	lists := 0
+	mock := &clienttest.MockClient{
+		GetAllFunc: func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+			lists++
+			if lists <= 3 {
+				return []client.Memory{{ID: "mem-1"}}, nil
+			}
+			return nil, nil
+		},
+		DeleteUsersFunc: func(ctx context.Context, params ...client.DeleteUsersParams) (*client.DeleteUsersResult, error) {
+			return &client.DeleteUsersResult{Message: "Entity deleted successfully."}, nil
+		},
+	}
+	ctx := context.Background()
+
+	report, err := client.EraseUserData(ctx, mock, "alex", client.EraseOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("EraseUserData() error = %v", err)
+	}
+	if !report.Verified || report.Attempts != 3 || report.MemoriesBefore != 1 {
+		t.Errorf("report = %+v, want verified on the third check", report)
+	}
+
+	lists = -10
+	report, err = client.EraseUserData(ctx, mock, "alex", client.EraseOptions{Attempts: 2, Interval: time.Millisecond})
+	if !errors.Is(err, client.ErrErasureUnverified) {
+		t.Errorf("EraseUserData() error = %v, want ErrErasureUnverified", err)
+	}
+	if report == nil || report.Verified || report.MemoriesRemaining != 1 || report.Attempts != 2 {
+		t.Errorf("report = %+v, want unverified after 2 checks", report)
+	}
+}
@@ -1,20 +1,42 @@
 package client
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrImmutableMemory is returned by Update and Delete when the server refuses
+// to modify a memory that was added with Immutable set
+var ErrImmutableMemory = errors.New("memory is immutable")
+
+// ErrEntityNotFound is returned by GetEntity when no entity has the given
+// type and name
+var ErrEntityNotFound = errors.New("entity not found")
+
+// ErrMetadataConflict is returned by PatchMetadata when the memory kept
+// changing while its metadata was being patched
+var ErrMetadataConflict = errors.New("memory changed during metadata patch")
 
 // APIError represents an error from the Mem0 API
 type APIError struct {
 	Message    string
 	StatusCode int
 	Body       string
+	RequestID  string // Correlation ID of the failed request, when known
 }
 
 // Error implements the error interface
 func (e *APIError) Error() string {
+	message := fmt.Sprintf("API request failed: %s", e.Message)
 	if e.StatusCode > 0 {
-		return fmt.Sprintf("API request failed (status %d): %s", e.StatusCode, e.Message)
+		message = fmt.Sprintf("API request failed (status %d): %s", e.StatusCode, e.Message)
 	}
-	return fmt.Sprintf("API request failed: %s", e.Message)
+	if e.RequestID != "" {
+		message += fmt.Sprintf(" (request id %s)", e.RequestID)
+	}
+	return message
 }
 
 // NewAPIError creates a new APIError
@@ -47,3 +69,21 @@ func NewValidationError(field, message string) *ValidationError {
 		Message: message,
 	}
 }
+
+// immutableError wraps a rejected modification of an immutable memory so that
+// errors.Is(err, ErrImmutableMemory) holds while the APIError stays reachable
+func immutableError(err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusForbidden, http.StatusConflict:
+	default:
+		return err
+	}
+	if !strings.Contains(strings.ToLower(apiErr.Body), "immutable") {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrImmutableMemory, err)
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExportMemories writes every memory matching options to w as JSON Lines,
+// one Memory object per line, and returns the number written
+func ExportMemories(ctx context.Context, c Client, w io.Writer, options ...SearchOptions) (int, error) {
+	memories, err := c.GetAll(ctx, options...)
+	if err != nil {
+		return 0, err
+	}
+
+	encoder := json.NewEncoder(w)
+	for i, memory := range memories {
+		if err := encoder.Encode(memory); err != nil {
+			return i, fmt.Errorf("failed to write memory %s: %w", memory.ID, err)
+		}
+	}
+
+	return len(memories), nil
+}
+
+// ImportMemories reads JSON Lines produced by ExportMemories and adds each
+// memory verbatim (with inference disabled) at its exported creation time.
+// Entity IDs, metadata and timestamp come from the exported record unless
+// options set them, which allows importing into a different user or project. It returns the number of memories added
+// before the first error.
+func ImportMemories(ctx context.Context, c Client, r io.Reader, options ...MemoryOptions) (int, error) {
+	overrides := MemoryOptions{}
+	if len(options) > 0 {
+		overrides = options[0]
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	imported := 0
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var memory Memory
+		if err := json.Unmarshal(scanner.Bytes(), &memory); err != nil {
+			return imported, fmt.Errorf("line %d: invalid memory: %w", line, err)
+		}
+
+		text := ""
+		if memory.Memory != nil {
+			text = *memory.Memory
+		} else if memory.Data != nil {
+			text = memory.Data.Memory
+		}
+		if text == "" {
+			return imported, fmt.Errorf("line %d: memory %s has no text", line, memory.ID)
+		}
+
+		opts := overrides
+		if opts.UserID == nil && opts.AgentID == nil && opts.AppID == nil && opts.RunID == nil {
+			opts.UserID = memory.UserID
+			opts.AgentID = memory.AgentID
+			opts.AppID = memory.AppID
+			opts.RunID = memory.RunID
+		}
+		if opts.Metadata == nil {
+			if metadata, ok := memory.Metadata.(map[string]interface{}); ok {
+				opts.Metadata = metadata
+			}
+		}
+		if opts.Timestamp == nil && memory.CreatedAt != nil {
+			opts.WithTimestamp(*memory.CreatedAt)
+		}
+		infer := false
+		opts.Infer = &infer
+
+		// Each line is its own write, so a caller's idempotency key must not
+		// collapse the import into its first memory
+		lineCtx := WithIdempotencySubKey(ctx, strconv.Itoa(line))
+		if _, err := c.Add(lineCtx, []Message{{Role: "user", Content: text}}, opts); err != nil {
+			return imported, fmt.Errorf("line %d: failed to import memory %s: %w", line, memory.ID, err)
+		}
+		imported++
+	}
+	if err := scanner.Err(); err != nil {
+		return imported, fmt.Errorf("failed to read memories: %w", err)
+	}
+
+	return imported, nil
+}
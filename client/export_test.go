@@ -0,0 +1,146 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	source := mem0test.NewServer()
+	defer source.Close()
+	sourceClient, err := source.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	_, err = sourceClient.Add(ctx, []client.Message{
+		{Role: "user", Content: "I am a vegetarian"},
+		{Role: "user", Content: "I live in Lisbon"},
+	}, client.MemoryOptions{UserID: strPtr("alex"), Metadata: map[string]interface{}{"source": "chat"}})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	exported, err := client.ExportMemories(ctx, sourceClient, &buf, client.SearchOptions{
+		MemoryOptions: client.MemoryOptions{UserID: strPtr("alex")},
+	})
+	if err != nil {
+		t.Fatalf("ExportMemories() error = %v", err)
+	}
+	if exported != 2 || strings.Count(buf.String(), "\n") != 2 {
+		t.Fatalf("ExportMemories() = %d lines %q, want 2", exported, buf.String())
+	}
+
+	target := mem0test.NewServer()
+	defer target.Close()
+	targetClient, err := target.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	imported, err := client.ImportMemories(ctx, targetClient, &buf)
+	if err != nil {
+		t.Fatalf("ImportMemories() error = %v", err)
+	}
+	if imported != 2 {
+		t.Errorf("ImportMemories() = %d, want 2", imported)
+	}
+
+	memories := target.Memories()
+	if len(memories) != 2 || *memories[0].Memory != "I am a vegetarian" || *memories[1].UserID != "alex" {
+		t.Errorf("imported memories = %+v", memories)
+	}
+	if metadata := memories[0].Metadata.(map[string]interface{}); metadata["source"] != "chat" {
+		t.Errorf("imported metadata = %v, want source=chat", metadata)
+	}
+}
+
+func TestImportMemoriesKeepsCreationTime(t *testing.T) {
+	mock := &clienttest.MockClient{}
+	input := `{"id":"mem-1","memory":"Likes tea","user_id":"alex","created_at":"2024-03-01T12:00:00Z"}` + "\n" +
+		`{"id":"mem-2","memory":"Likes coffee","user_id":"alex"}` + "\n"
+
+	if _, err := client.ImportMemories(context.Background(), mock, strings.NewReader(input)); err != nil {
+		t.Fatalf("ImportMemories() error = %v", err)
+	}
+	calls := mock.CallsTo("Add")
+	first := calls[0].Args[1].([]client.MemoryOptions)[0]
+	if first.Timestamp == nil || *first.Timestamp != time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC).Unix() {
+		t.Errorf("first Timestamp = %v, want the exported created_at", first.Timestamp)
+	}
+	if second := calls[1].Args[1].([]client.MemoryOptions)[0]; second.Timestamp != nil {
+		t.Errorf("second Timestamp = %d, want none without created_at", *second.Timestamp)
+	}
+}
+
+func TestImportMemoriesOverridesEntity(t *testing.T) {
+	mock := &clienttest.MockClient{}
+	input := `{"id":"mem-1","memory":"Likes tea","user_id":"alex"}` + "\n\n" +
+		`{"id":"mem-2","memory":"Likes coffee","agent_id":"barista"}` + "\n"
+
+	imported, err := client.ImportMemories(context.Background(), mock, strings.NewReader(input),
+		client.MemoryOptions{UserID: strPtr("sam")})
+	if err != nil {
+		t.Fatalf("ImportMemories() error = %v", err)
+	}
+	if imported != 2 {
+		t.Errorf("ImportMemories() = %d, want 2", imported)
+	}
+
+	for _, call := range mock.CallsTo("Add") {
+		opts := call.Args[1].([]client.MemoryOptions)[0]
+		if opts.UserID == nil || *opts.UserID != "sam" || opts.AgentID != nil {
+			t.Errorf("Add options = %+v, want only user_id=sam", opts)
+		}
+		if opts.Infer == nil || *opts.Infer {
+			t.Error("ImportMemories() should disable inference")
+		}
+	}
+}
+
+func TestImportMemoriesReportsBadLine(t *testing.T) {
+	mock := &clienttest.MockClient{}
+	input := `{"id":"mem-1","memory":"Likes tea","user_id":"alex"}` + "\n" + `not json` + "\n"
+
+	imported, err := client.ImportMemories(context.Background(), mock, strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ImportMemories() error = %v, want line 2 error", err)
+	}
+	if imported != 1 {
+		t.Errorf("ImportMemories() = %d, want 1", imported)
+	}
+}
+
+func TestImportMemoriesIdempotencyKeys(t *testing.T) {
+	var keys []string
+	mock := &clienttest.MockClient{
+		AddFunc: func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+			key, _ := client.IdempotencyKey(ctx)
+			keys = append(keys, key)
+			return nil, nil
+		},
+	}
+	input := `{"id":"mem-1","memory":"Likes tea","user_id":"alex"}` + "\n" +
+		`{"id":"mem-2","memory":"Likes coffee","user_id":"alex"}` + "\n"
+
+	ctx := client.WithIdempotencyKey(context.Background(), "restore-1")
+	if _, err := client.ImportMemories(ctx, mock, strings.NewReader(input)); err != nil {
+		t.Fatalf("ImportMemories() error = %v", err)
+	}
+	if strings.Join(keys, ",") != "restore-1-1,restore-1-2" {
+		t.Errorf("keys = %v, want one per line", keys)
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fixtureTargets maps each canonical API response under testdata/fixtures to
+// the Go type it must decode into. Add an entry whenever a fixture is added;
+// when the API changes, refresh the fixture and let the test point at the
+// types that need updating.
+var fixtureTargets = map[string]func() interface{}{
+	"v1/ping.json":     func() interface{} { return &PingResponse{} },
+	"v1/add.json":      func() interface{} { return &[]Memory{} },
+	"v1/get.json":      func() interface{} { return &Memory{} },
+	"v1/get_all.json":  func() interface{} { return &[]Memory{} },
+	"v1/search.json":   func() interface{} { return &[]Memory{} },
+	"v1/history.json":  func() interface{} { return &[]MemoryHistory{} },
+	"v1/entities.json": func() interface{} { return &AllUsers{} },
+	"v1/delete.json":   func() interface{} { return &MessageResponse{} },
+	"v2/get_all.json":  func() interface{} { return &[]Memory{} },
+	"v2/search.json":   func() interface{} { return &[]Memory{} },
+}
+
+// decodeFixture strictly decodes a fixture, failing on fields the Go type
+// does not declare
+func decodeFixture(t *testing.T, name string, target interface{}) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "fixtures", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		t.Fatalf("fixture %s does not match Go types: %v", name, err)
+	}
+}
+
+func TestFixturesDecodeStrictly(t *testing.T) {
+	for name, newTarget := range fixtureTargets {
+		t.Run(name, func(t *testing.T) {
+			decodeFixture(t, name, newTarget())
+		})
+	}
+}
+
+func TestFixturesAreRegistered(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "fixtures", "*", "*.json"))
+	if err != nil {
+		t.Fatalf("failed to list fixtures: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no fixtures found")
+	}
+
+	for _, file := range files {
+		name := filepath.ToSlash(strings.TrimPrefix(file, filepath.Join("testdata", "fixtures")+string(filepath.Separator)))
+		if _, ok := fixtureTargets[name]; !ok {
+			t.Errorf("fixture %s has no entry in fixtureTargets", name)
+		}
+	}
+}
+
+func TestFixtureFieldValues(t *testing.T) {
+	var memory Memory
+	decodeFixture(t, "v1/get.json", &memory)
+
+	if memory.ID != "3c90c3cc-0d44-4b50-8888-8dd25736052a" {
+		t.Errorf("ID = %s", memory.ID)
+	}
+	if memory.Memory == nil || *memory.Memory != "Is a vegetarian" {
+		t.Errorf("Memory = %v", memory.Memory)
+	}
+	if memory.Hash == nil || memory.UserID == nil || *memory.UserID != "alex" {
+		t.Errorf("Hash/UserID not decoded: %+v", memory)
+	}
+	if len(memory.Categories) != 2 {
+		t.Errorf("Categories = %v, want 2 entries", memory.Categories)
+	}
+	if memory.CreatedAt == nil || memory.CreatedAt.Nanosecond() != 123456000 {
+		t.Errorf("CreatedAt = %v, want microsecond precision", memory.CreatedAt)
+	}
+	if metadata, ok := memory.Metadata.(map[string]interface{}); !ok || metadata["source"] != "onboarding" {
+		t.Errorf("Metadata = %v", memory.Metadata)
+	}
+
+	var history []MemoryHistory
+	decodeFixture(t, "v1/history.json", &history)
+	if len(history) != 1 || len(history[0].Input) != 2 || history[0].Event != EventAdd {
+		t.Errorf("History = %+v, want one ADD entry with two input messages", history)
+	}
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GraphEntity is a node of the graph memory
+type GraphEntity struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// GraphRelation is an edge of the graph memory, e.g. alice -likes-> pizza
+type GraphRelation struct {
+	Source       string `json:"source"`
+	SourceType   string `json:"source_type,omitempty"`
+	Relationship string `json:"relationship"`
+	Target       string `json:"target"`
+	TargetType   string `json:"target_type,omitempty"`
+}
+
+// UnmarshalJSON accepts "destination" as an alias for "target", which some
+// endpoints use
+func (r *GraphRelation) UnmarshalJSON(data []byte) error {
+	type plain GraphRelation
+	var aux struct {
+		plain
+		Destination     string `json:"destination"`
+		DestinationType string `json:"destination_type"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*r = GraphRelation(aux.plain)
+	if r.Target == "" {
+		r.Target = aux.Destination
+	}
+	if r.TargetType == "" {
+		r.TargetType = aux.DestinationType
+	}
+	return nil
+}
+
+// GraphRelations lists the relations added to and deleted from the graph by
+// an Add call
+type GraphRelations struct {
+	AddedEntities   []GraphRelation `json:"added_entities"`
+	DeletedEntities []GraphRelation `json:"deleted_entities"`
+}
+
+// UnmarshalJSON flattens the per-query nesting the API uses for
+// added_entities and deleted_entities
+func (g *GraphRelations) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		AddedEntities   json.RawMessage `json:"added_entities"`
+		DeletedEntities json.RawMessage `json:"deleted_entities"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if g.AddedEntities, err = flattenRelations(raw.AddedEntities); err != nil {
+		return fmt.Errorf("added_entities: %w", err)
+	}
+	if g.DeletedEntities, err = flattenRelations(raw.DeletedEntities); err != nil {
+		return fmt.Errorf("deleted_entities: %w", err)
+	}
+	return nil
+}
+
+// Entities returns the distinct entities referenced by the added relations
+func (g *GraphRelations) Entities() []GraphEntity {
+	var entities []GraphEntity
+	seen := make(map[GraphEntity]bool)
+	add := func(entity GraphEntity) {
+		if entity.Name == "" || seen[entity] {
+			return
+		}
+		seen[entity] = true
+		entities = append(entities, entity)
+	}
+	for _, relation := range g.AddedEntities {
+		add(GraphEntity{Name: relation.Source, Type: relation.SourceType})
+		add(GraphEntity{Name: relation.Target, Type: relation.TargetType})
+	}
+	return entities
+}
+
+// flattenRelations decodes a list whose items are either relations or lists
+// of relations
+func flattenRelations(data json.RawMessage) ([]GraphRelation, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	var relations []GraphRelation
+	for _, item := range items {
+		var nested []GraphRelation
+		if err := json.Unmarshal(item, &nested); err == nil {
+			relations = append(relations, nested...)
+			continue
+		}
+		var relation GraphRelation
+		if err := json.Unmarshal(item, &relation); err != nil {
+			return nil, err
+		}
+		relations = append(relations, relation)
+	}
+	return relations, nil
+}
+
+// AddResult is the response of AddWithGraph
+type AddResult struct {
+	Results   []Memory        `json:"results"`
+	Relations *GraphRelations `json:"relations,omitempty"`
+}
+
+// UnmarshalJSON accepts both the plain list of memories and the
+// {"results", "relations"} object returned when enable_graph is set
+func (a *AddResult) UnmarshalJSON(data []byte) error {
+	var memories []Memory
+	if err := json.Unmarshal(data, &memories); err == nil {
+		*a = AddResult{Results: memories}
+		return nil
+	}
+	type plain AddResult
+	var result plain
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	*a = AddResult(result)
+	return nil
+}
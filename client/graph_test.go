@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAddWithGraph(t *testing.T) {
+	body := `{
+		"results": [{"id": "mem-1", "memory": "Likes pizza", "event": "ADD"}],
+		"relations": {
+			"added_entities": [[{"source": "alice", "relationship": "likes", "target": "pizza"}], []],
+			"deleted_entities": [{"source": "alice", "relationship": "dislikes", "destination": "pizza"}]
+		}
+	}`
+	client, captured := newStubClient(t, http.StatusOK, body)
+
+	enableGraph := true
+	result, err := client.AddWithGraph(context.Background(), []Message{{Role: "user", Content: "I like pizza"}}, MemoryOptions{EnableGraph: &enableGraph})
+	if err != nil {
+		t.Fatalf("AddWithGraph() error = %v", err)
+	}
+	if captured.Body["enable_graph"] != true {
+		t.Errorf("enable_graph = %v, want true", captured.Body["enable_graph"])
+	}
+	if len(result.Results) != 1 || result.Results[0].ID != "mem-1" {
+		t.Errorf("Results = %+v", result.Results)
+	}
+	if result.Relations == nil {
+		t.Fatal("Relations = nil")
+	}
+
+	wantAdded := []GraphRelation{{Source: "alice", Relationship: "likes", Target: "pizza"}}
+	if !reflect.DeepEqual(result.Relations.AddedEntities, wantAdded) {
+		t.Errorf("AddedEntities = %+v, want %+v", result.Relations.AddedEntities, wantAdded)
+	}
+	wantDeleted := []GraphRelation{{Source: "alice", Relationship: "dislikes", Target: "pizza"}}
+	if !reflect.DeepEqual(result.Relations.DeletedEntities, wantDeleted) {
+		t.Errorf("DeletedEntities = %+v, want %+v", result.Relations.DeletedEntities, wantDeleted)
+	}
+	wantEntities := []GraphEntity{{Name: "alice"}, {Name: "pizza"}}
+	if !reflect.DeepEqual(result.Relations.Entities(), wantEntities) {
+		t.Errorf("Entities() = %+v, want %+v", result.Relations.Entities(), wantEntities)
+	}
+
+	memories, err := client.Add(context.Background(), []Message{{Role: "user", Content: "I like pizza"}}, MemoryOptions{EnableGraph: &enableGraph})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if len(memories) != 1 || memories[0].ID != "mem-1" {
+		t.Errorf("Add() = %+v, want graph results unwrapped", memories)
+	}
+}
+
+func TestAddWithGraphPlainList(t *testing.T) {
+	client, _ := newStubClient(t, http.StatusOK, `[{"id": "mem-1", "event": "ADD"}]`)
+
+	result, err := client.AddWithGraph(context.Background(), []Message{{Role: "user", Content: "hi"}})
+	if err != nil {
+		t.Fatalf("AddWithGraph() error = %v", err)
+	}
+	if len(result.Results) != 1 || result.Relations != nil {
+		t.Errorf("AddWithGraph() = %+v, want one result and no relations", result)
+	}
+}
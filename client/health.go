@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// HealthReport is the outcome of Healthy
+type HealthReport struct {
+	Healthy       bool          `json:"healthy"`
+	Reachable     bool          `json:"reachable"`     // The API answered
+	Authenticated bool          `json:"authenticated"` // The API key was accepted
+	Latency       time.Duration `json:"latency_ns"`    // Round trip of the ping
+	RateLimit     *RateLimit    `json:"rate_limit,omitempty"`
+	Error         string        `json:"error,omitempty"`
+	CheckedAt     time.Time     `json:"checked_at"`
+}
+
+// Headroom returns the fraction of the rate limit still available, or 1 when
+// the API reported no limit
+func (h HealthReport) Headroom() float64 {
+	if h.RateLimit == nil || h.RateLimit.Limit == 0 {
+		return 1
+	}
+	return float64(h.RateLimit.Remaining) / float64(h.RateLimit.Limit)
+}
+
+// Healthy pings the API and reports whether it is reachable, accepts the API
+// key and has rate limit left. The client is healthy when all three hold.
+func (c *MemoryClient) Healthy(ctx context.Context) HealthReport {
+	response := &Response{}
+	start := time.Now()
+	err := c.Ping(CaptureResponse(ctx, response))
+	report := HealthReport{
+		Reachable:     response.StatusCode != 0,
+		Authenticated: err == nil,
+		Latency:       time.Since(start),
+		RateLimit:     response.RateLimit,
+		CheckedAt:     start.UTC(),
+	}
+	if err != nil {
+		report.Error = err.Error()
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+			report.Authenticated = true
+		}
+	}
+	report.Healthy = report.Reachable && report.Authenticated && report.Headroom() > 0 &&
+		response.StatusCode != http.StatusTooManyRequests
+	return report
+}
+
+// ReadyHandler returns an http.Handler for readiness probes such as /readyz.
+// It answers with the JSON HealthReport, and status 200 when healthy or 503
+// otherwise.
+func (c *MemoryClient) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Healthy(r.Context())
+		status := http.StatusOK
+		if !report.Healthy {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(report)
+	})
+}
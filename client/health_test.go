@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthy(t *testing.T) {
+	tests := []struct {
+		name              string
+		status            int
+		body              string
+		headers           map[string]string
+		wantHealthy       bool
+		wantAuthenticated bool
+		wantHeadroom      float64
+	}{
+		{
+			name:              "healthy",
+			status:            200,
+			body:              `{"status": "ok"}`,
+			headers:           map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "25"},
+			wantHealthy:       true,
+			wantAuthenticated: true,
+			wantHeadroom:      0.25,
+		},
+		{
+			name:         "invalid key",
+			status:       401,
+			body:         `{"detail": "Invalid API key"}`,
+			wantHeadroom: 1,
+		},
+		{
+			name:              "rate limited",
+			status:            429,
+			body:              `{"detail": "Too many requests"}`,
+			headers:           map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "0", "Retry-After": "30"},
+			wantAuthenticated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			host := srv.URL
+			client, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &host})
+			if err != nil {
+				t.Fatalf("NewMemoryClient() error = %v", err)
+			}
+
+			report := client.Healthy(context.Background())
+			if !report.Reachable || report.Healthy != tt.wantHealthy || report.Authenticated != tt.wantAuthenticated {
+				t.Errorf("Healthy() = %+v, want healthy %v, authenticated %v", report, tt.wantHealthy, tt.wantAuthenticated)
+			}
+			if got := report.Headroom(); got != tt.wantHeadroom {
+				t.Errorf("Headroom() = %v, want %v", got, tt.wantHeadroom)
+			}
+			if report.Latency <= 0 || report.CheckedAt.IsZero() {
+				t.Errorf("Healthy() = %+v, want latency and check time", report)
+			}
+			if tt.wantHealthy != (report.Error == "") {
+				t.Errorf("Healthy() error = %q", report.Error)
+			}
+		})
+	}
+}
+
+func TestHealthyUnreachable(t *testing.T) {
+	host := "http://127.0.0.1:1"
+	client, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &host})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	if report := client.Healthy(context.Background()); report.Reachable || report.Healthy || report.Error == "" {
+		t.Errorf("Healthy() = %+v, want unreachable", report)
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	client, _ := newStubClient(t, 200, `{}`)
+
+	rec := httptest.NewRecorder()
+	client.ReadyHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var report HealthReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("response is not a HealthReport: %s", rec.Body)
+	}
+	if rec.Code != http.StatusOK || !report.Healthy {
+		t.Errorf("/readyz = %d %+v, want 200 healthy", rec.Code, report)
+	}
+
+	host := "http://127.0.0.1:1"
+	unreachable, _ := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &host})
+	rec = httptest.NewRecorder()
+	unreachable.ReadyHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz = %d, want 503", rec.Code)
+	}
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLatencyBudgetExceeded is returned by HedgedSearch when no attempt
+// answered within the budget. The results returned with it are the fallback
+// and are safe to use.
+var ErrLatencyBudgetExceeded = errors.New("latency budget exceeded")
+
+// HedgeOptions configures HedgedSearch
+type HedgeOptions struct {
+	// HedgeAfter starts a second attempt when the first has not answered in
+	// time, such as the search's P95 latency. No hedging when zero.
+	HedgeAfter time.Duration
+	// Budget bounds the whole call. No budget when zero.
+	Budget time.Duration
+	// Fallback supplies results when the budget runs out, e.g. from a cache.
+	// Empty results are used when nil or when it reports false.
+	Fallback func(query string, options SearchOptions) ([]Memory, bool)
+}
+
+// HedgedSearch runs Search for latency-sensitive paths such as a chat turn.
+// It starts a second attempt after HedgeAfter, or at once if the first fails,
+// and returns the first successful response. When Budget runs out first, it
+// returns the Fallback results with ErrLatencyBudgetExceeded instead of
+// waiting longer.
+func HedgedSearch(ctx context.Context, c Client, query string, options SearchOptions, hedge HedgeOptions) ([]Memory, error) {
+	searchCtx, cancel := context.WithCancel(ctx)
+	if hedge.Budget > 0 {
+		searchCtx, cancel = context.WithTimeout(ctx, hedge.Budget)
+	}
+	defer cancel()
+
+	type result struct {
+		memories []Memory
+		err      error
+	}
+	results := make(chan result, 2)
+	search := func() {
+		memories, err := c.Search(searchCtx, query, options)
+		results <- result{memories, err}
+	}
+
+	go search()
+	running := 1
+	var hedgeTimer <-chan time.Time
+	if hedge.HedgeAfter > 0 {
+		timer := time.NewTimer(hedge.HedgeAfter)
+		defer timer.Stop()
+		hedgeTimer = timer.C
+	}
+	startHedge := func() {
+		if hedgeTimer != nil {
+			hedgeTimer = nil
+			running++
+			go search()
+		}
+	}
+
+	var firstErr error
+	for {
+		select {
+		case <-hedgeTimer:
+			startHedge()
+		case r := <-results:
+			running--
+			if r.err == nil {
+				return r.memories, nil
+			}
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			if searchCtx.Err() == nil {
+				startHedge()
+			}
+			if running == 0 && (hedgeTimer == nil || searchCtx.Err() != nil) {
+				if ctx.Err() == nil && searchCtx.Err() != nil {
+					return fallback(query, options, hedge)
+				}
+				return nil, firstErr
+			}
+		case <-searchCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return fallback(query, options, hedge)
+		}
+	}
+}
+
+// fallback returns the results HedgedSearch serves when the budget runs out
+func fallback(query string, options SearchOptions, hedge HedgeOptions) ([]Memory, error) {
+	if hedge.Fallback != nil {
+		if memories, ok := hedge.Fallback(query, options); ok {
+			return memories, ErrLatencyBudgetExceeded
+		}
+	}
+	return []Memory{}, ErrLatencyBudgetExceeded
+}
@@ -0,0 +1,107 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+// slowSearch returns a mock whose nth Search call (from 1) waits delays[n-1]
+func slowSearch(calls *int32, delays ...time.Duration) *clienttest.MockClient {
+	return &clienttest.MockClient{
+		SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+			n := atomic.AddInt32(calls, 1)
+			select {
+			case <-time.After(delays[n-1]):
+				return []client.Memory{{ID: "attempt-" + string(rune('0'+n))}}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	}
+}
+
+func TestHedgedSearch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fast first attempt is not hedged", func(t *testing.T) {
+		var calls int32
+		memories, err := client.HedgedSearch(ctx, slowSearch(&calls, 0), "tea", client.SearchOptions{},
+			client.HedgeOptions{HedgeAfter: 50 * time.Millisecond})
+		if err != nil || len(memories) != 1 || memories[0].ID != "attempt-1" {
+			t.Errorf("HedgedSearch() = %v, %v; want attempt-1", memories, err)
+		}
+		if calls != 1 {
+			t.Errorf("Search called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("slow first attempt is hedged", func(t *testing.T) {
+		var calls int32
+		memories, err := client.HedgedSearch(ctx, slowSearch(&calls, time.Second, 0), "tea", client.SearchOptions{},
+			client.HedgeOptions{HedgeAfter: 10 * time.Millisecond})
+		if err != nil || len(memories) != 1 || memories[0].ID != "attempt-2" {
+			t.Errorf("HedgedSearch() = %v, %v; want attempt-2", memories, err)
+		}
+	})
+
+	t.Run("budget falls back", func(t *testing.T) {
+		var calls int32
+		start := time.Now()
+		memories, err := client.HedgedSearch(ctx, slowSearch(&calls, time.Second, time.Second), "tea", client.SearchOptions{},
+			client.HedgeOptions{
+				HedgeAfter: 5 * time.Millisecond,
+				Budget:     30 * time.Millisecond,
+				Fallback: func(query string, options client.SearchOptions) ([]client.Memory, bool) {
+					return []client.Memory{{ID: "cached"}}, true
+				},
+			})
+		if !errors.Is(err, client.ErrLatencyBudgetExceeded) || len(memories) != 1 || memories[0].ID != "cached" {
+			t.Errorf("HedgedSearch() = %v, %v; want cached results and ErrLatencyBudgetExceeded", memories, err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("HedgedSearch() took %s, want about the budget", elapsed)
+		}
+	})
+
+	t.Run("budget without fallback returns empty results", func(t *testing.T) {
+		var calls int32
+		memories, err := client.HedgedSearch(ctx, slowSearch(&calls, time.Second), "tea", client.SearchOptions{},
+			client.HedgeOptions{Budget: 10 * time.Millisecond})
+		if !errors.Is(err, client.ErrLatencyBudgetExceeded) || memories == nil || len(memories) != 0 {
+			t.Errorf("HedgedSearch() = %v, %v; want empty results", memories, err)
+		}
+	})
+
+	t.Run("failed first attempt hedges at once", func(t *testing.T) {
+		var calls int32
+		mock := &clienttest.MockClient{
+			SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					return nil, errors.New("boom")
+				}
+				return []client.Memory{{ID: "retry"}}, nil
+			},
+		}
+		memories, err := client.HedgedSearch(ctx, mock, "tea", client.SearchOptions{}, client.HedgeOptions{HedgeAfter: time.Hour})
+		if err != nil || len(memories) != 1 || memories[0].ID != "retry" {
+			t.Errorf("HedgedSearch() = %v, %v; want the hedge's results", memories, err)
+		}
+	})
+
+	t.Run("errors without hedging", func(t *testing.T) {
+		mock := &clienttest.MockClient{
+			SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+				return nil, errors.New("boom")
+			},
+		}
+		if _, err := client.HedgedSearch(ctx, mock, "tea", client.SearchOptions{}, client.HedgeOptions{}); err == nil || err.Error() != "boom" {
+			t.Errorf("HedgedSearch() error = %v, want boom", err)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+// IdempotencyHeader is the header carrying the idempotency key of mutating
+// requests
+const IdempotencyHeader = "Idempotency-Key"
+
+type idempotencyKeyContext struct{}
+
+type requestIdempotencyKeyContext struct{}
+
+// WithIdempotencyKey returns a context whose Add, Update, Delete, DeleteAll
+// and batch calls send key in the Idempotency-Key header. Retry a call that
+// timed out with the same key so the API can discard the duplicate. Without
+// one, every call gets a random key.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContext{}, key)
+}
+
+// IdempotencyKey returns the key set on ctx with WithIdempotencyKey
+func IdempotencyKey(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContext{}).(string)
+	return key, ok && key != ""
+}
+
+// WithIdempotencySubKey returns a context for one of several writes a call
+// makes. The key set on ctx with WithIdempotencyKey, if any, is suffixed
+// with "-" and part, so the writes don't share a key and the API doesn't
+// collapse them into the first; retrying the call with the same key derives
+// the same keys again.
+func WithIdempotencySubKey(ctx context.Context, part string) context.Context {
+	if key, ok := IdempotencyKey(ctx); ok {
+		return WithIdempotencyKey(ctx, key+"-"+part)
+	}
+	return ctx
+}
+
+// withRequestIdempotencyKey marks ctx as carrying a mutating request, with
+// the caller's idempotency key or a new UUID
+func withRequestIdempotencyKey(ctx context.Context) context.Context {
+	key, ok := IdempotencyKey(ctx)
+	if !ok {
+		key = newUUID()
+	}
+	return context.WithValue(ctx, requestIdempotencyKeyContext{}, key)
+}
+
+// requestIdempotencyKey returns the key fetchWithErrorHandling should send
+func requestIdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(requestIdempotencyKeyContext{}).(string)
+	return key
+}
+
+// newUUID returns a random version 4 UUID
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestIdempotencyKeyHeader(t *testing.T) {
+	calls := []struct {
+		name string
+		body string
+		call func(ctx context.Context, c *MemoryClient) error
+	}{
+		{"Add", `[]`, func(ctx context.Context, c *MemoryClient) error {
+			_, err := c.Add(ctx, []Message{{Role: "user", Content: "hi"}}, MemoryOptions{UserID: stringPtr("alice")})
+			return err
+		}},
+		{"Update", `[]`, func(ctx context.Context, c *MemoryClient) error {
+			_, err := c.Update(ctx, "mem-1", "text")
+			return err
+		}},
+		{"Delete", `{}`, func(ctx context.Context, c *MemoryClient) error {
+			_, err := c.Delete(ctx, "mem-1")
+			return err
+		}},
+		{"DeleteAll", `{}`, func(ctx context.Context, c *MemoryClient) error {
+			_, err := c.DeleteAll(ctx, MemoryOptions{UserID: stringPtr("alice")})
+			return err
+		}},
+		{"BatchDelete", `{}`, func(ctx context.Context, c *MemoryClient) error {
+			_, err := c.BatchDelete(ctx, []string{"mem-1"})
+			return err
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			client, captured := newStubClient(t, http.StatusOK, tt.body)
+
+			if err := tt.call(context.Background(), client); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			generated := captured.Header.Get(IdempotencyHeader)
+			if !uuidPattern.MatchString(generated) {
+				t.Errorf("generated key = %q, want a v4 UUID", generated)
+			}
+
+			if err := tt.call(context.Background(), client); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			if again := captured.Header.Get(IdempotencyHeader); again == generated {
+				t.Errorf("second call reused key %q", again)
+			}
+
+			ctx := WithIdempotencyKey(context.Background(), "retry-1")
+			if err := tt.call(ctx, client); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			if got := captured.Header.Get(IdempotencyHeader); got != "retry-1" {
+				t.Errorf("key = %q, want retry-1", got)
+			}
+		})
+	}
+}
+
+func TestIdempotencyKeyNotSentOnReads(t *testing.T) {
+	client, captured := newStubClient(t, http.StatusOK, `[]`)
+	ctx := WithIdempotencyKey(context.Background(), "key-1")
+
+	if _, err := client.Search(ctx, "tea", SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alice")}}); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got := captured.Header.Get(IdempotencyHeader); got != "" {
+		t.Errorf("Search sent %s = %q", IdempotencyHeader, got)
+	}
+}
+
+func TestIdempotencyKeyPerBatchChunk(t *testing.T) {
+	client, _ := newStubClient(t, http.StatusOK, `{}`)
+	keys := make(chan string, 3)
+	transport := client.httpClient.Transport
+	client.httpClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if key := r.Header.Get(IdempotencyHeader); key != "" {
+			keys <- key
+		}
+		return transport.RoundTrip(r)
+	})
+
+	ctx := WithIdempotencyKey(context.Background(), "bulk")
+	if _, err := client.BatchDelete(ctx, []string{"a", "b", "c"}, WithChunkSize(2), WithConcurrency(1)); err != nil {
+		t.Fatalf("BatchDelete() error = %v", err)
+	}
+	close(keys)
+
+	var got []string
+	for key := range keys {
+		got = append(got, key)
+	}
+	if len(got) != 2 || got[0] != "bulk-0" || got[1] != "bulk-2" {
+		t.Errorf("chunk keys = %v, want [bulk-0 bulk-2]", got)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxCustomInstructionsLength is the longest custom_instructions value, in
+// characters, that Validate accepts
+const MaxCustomInstructionsLength = 4000
+
+// Instructions composes custom_instructions, which tell the platform what to
+// extract into memories. Templates are combined with Merge, so teams can keep
+// shared building blocks such as InstructionsNoSecrets.
+type Instructions struct {
+	Include []string // Topics worth remembering
+	Exclude []string // Topics never to remember
+	Tone    string   // How memories should be phrased
+	Rules   []string // Further extraction rules, one sentence each
+}
+
+// Reusable instruction templates
+var (
+	// InstructionsNoSecrets keeps credentials and financial identifiers out
+	// of memory
+	InstructionsNoSecrets = Instructions{
+		Exclude: []string{
+			"passwords, API keys, access tokens and other credentials",
+			"payment card, bank account and government ID numbers",
+		},
+	}
+
+	// InstructionsPreferences remembers lasting preferences rather than
+	// one-off requests
+	InstructionsPreferences = Instructions{
+		Include: []string{"stated likes, dislikes and preferences", "recurring habits and routines"},
+		Rules:   []string{"Ignore one-off requests that say nothing lasting about the user."},
+	}
+)
+
+// Merge returns i combined with others. Topics and rules are appended in
+// order without duplicates; the last non-empty Tone wins.
+func (i Instructions) Merge(others ...Instructions) Instructions {
+	merged := Instructions{Tone: i.Tone}
+	merged.Include = appendUnique(merged.Include, i.Include...)
+	merged.Exclude = appendUnique(merged.Exclude, i.Exclude...)
+	merged.Rules = appendUnique(merged.Rules, i.Rules...)
+	for _, other := range others {
+		merged.Include = appendUnique(merged.Include, other.Include...)
+		merged.Exclude = appendUnique(merged.Exclude, other.Exclude...)
+		merged.Rules = appendUnique(merged.Rules, other.Rules...)
+		if other.Tone != "" {
+			merged.Tone = other.Tone
+		}
+	}
+	return merged
+}
+
+// String renders the instructions as custom_instructions text
+func (i Instructions) String() string {
+	var sections []string
+	if len(i.Include) > 0 {
+		sections = append(sections, "Remember:\n- "+strings.Join(i.Include, "\n- "))
+	}
+	if len(i.Exclude) > 0 {
+		sections = append(sections, "Never remember:\n- "+strings.Join(i.Exclude, "\n- "))
+	}
+	if i.Tone != "" {
+		sections = append(sections, "Tone: "+i.Tone)
+	}
+	if len(i.Rules) > 0 {
+		sections = append(sections, "Rules:\n- "+strings.Join(i.Rules, "\n- "))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// Build renders the instructions and validates the result
+func (i Instructions) Build() (string, error) {
+	text := i.String()
+	if text == "" {
+		return "", NewValidationError("custom_instructions", "instructions are empty")
+	}
+	if err := validateCustomInstructions(text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// WithInstructions sets CustomInstructions from i and returns o for
+// chaining. Validate rejects the result if it is too long.
+func (o *MemoryOptions) WithInstructions(i Instructions) *MemoryOptions {
+	text := i.String()
+	o.CustomInstructions = &text
+	return o
+}
+
+// validateCustomInstructions rejects overlong custom instructions
+func validateCustomInstructions(text string) error {
+	if n := len([]rune(text)); n > MaxCustomInstructionsLength {
+		return NewValidationError("custom_instructions", fmt.Sprintf("must be at most %d characters, got %d", MaxCustomInstructionsLength, n))
+	}
+	return nil
+}
+
+// appendUnique appends the non-blank values not already in list
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		duplicate := false
+		for _, existing := range list {
+			if existing == value {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			list = append(list, value)
+		}
+	}
+	return list
+}
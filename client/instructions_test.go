@@ -0,0 +1,49 @@
+package client_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+func TestInstructionsMerge(t *testing.T) {
+	support := client.Instructions{
+		Include: []string{"product names and versions", " "},
+		Tone:    "neutral",
+	}
+	merged := support.Merge(client.InstructionsNoSecrets, client.Instructions{
+		Include: []string{"product names and versions"},
+		Tone:    "third person",
+		Rules:   []string{"Keep one fact per memory."},
+	})
+
+	want := "Remember:\n- product names and versions\n\n" +
+		"Never remember:\n- passwords, API keys, access tokens and other credentials\n- payment card, bank account and government ID numbers\n\n" +
+		"Tone: third person\n\n" +
+		"Rules:\n- Keep one fact per memory."
+	if got := merged.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if len(client.InstructionsNoSecrets.Include) != 0 {
+		t.Errorf("Merge() modified the InstructionsNoSecrets template")
+	}
+}
+
+func TestInstructionsValidation(t *testing.T) {
+	var validationErr *client.ValidationError
+	if _, err := (client.Instructions{}).Build(); !errors.As(err, &validationErr) {
+		t.Errorf("Build(empty) error = %v, want *ValidationError", err)
+	}
+	long := client.Instructions{Rules: []string{strings.Repeat("x", client.MaxCustomInstructionsLength)}}
+	if _, err := long.Build(); !errors.As(err, &validationErr) {
+		t.Errorf("Build(too long) error = %v, want *ValidationError", err)
+	}
+
+	options := client.MemoryOptions{UserID: strPtr("alex")}
+	options.WithInstructions(long)
+	if err := options.Validate(); !errors.As(err, &validationErr) {
+		t.Errorf("Validate(too long) error = %v, want *ValidationError", err)
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import "context"
+
+// Client is the interface implemented by MemoryClient. Depend on it instead of
+// the concrete type to substitute clienttest.MockClient in tests. It holds
+// every API call of MemoryClient; methods that configure the client itself,
+// such as With, SetAPIKey and PoolStats, are left to the concrete type.
+type Client interface {
+	Ping(ctx context.Context) error
+	Healthy(ctx context.Context) HealthReport
+	CheckCompatibility(ctx context.Context) error
+	Add(ctx context.Context, messages []Message, options ...MemoryOptions) ([]Memory, error)
+	AddWithGraph(ctx context.Context, messages []Message, options ...MemoryOptions) (*AddResult, error)
+	AddWithResponse(ctx context.Context, messages []Message, options ...MemoryOptions) ([]Memory, *Response, error)
+	Update(ctx context.Context, memoryID, message string) ([]Memory, error)
+	UpdateWithMetadata(ctx context.Context, memoryID, message string, metadata map[string]interface{}) ([]Memory, error)
+	UpdateWithResponse(ctx context.Context, memoryID, message string) ([]Memory, *Response, error)
+	UpdateMemory(ctx context.Context, memoryID string, request UpdateRequest) ([]Memory, error)
+	PatchMetadata(ctx context.Context, memoryID string, patch map[string]interface{}) ([]Memory, error)
+	RevertMemory(ctx context.Context, memoryID, historyID string) ([]Memory, error)
+	Get(ctx context.Context, memoryID string) (*Memory, error)
+	GetFields(ctx context.Context, memoryID string, fields ...string) (*Memory, error)
+	GetWithResponse(ctx context.Context, memoryID string) (*Memory, *Response, error)
+	GetAll(ctx context.Context, options ...SearchOptions) ([]Memory, error)
+	GetAllWithResponse(ctx context.Context, options ...SearchOptions) ([]Memory, *Response, error)
+	Search(ctx context.Context, query string, options ...SearchOptions) ([]Memory, error)
+	SearchWithResponse(ctx context.Context, query string, options ...SearchOptions) ([]Memory, *Response, error)
+	SearchCategories(ctx context.Context, query string, categories []string, options ...SearchOptions) ([]Memory, error)
+	Delete(ctx context.Context, memoryID string) (*MessageResponse, error)
+	DeleteWithResponse(ctx context.Context, memoryID string) (*MessageResponse, *Response, error)
+	DeleteAll(ctx context.Context, options ...MemoryOptions) (*DeleteAllResult, error)
+	BatchUpdate(ctx context.Context, memories []MemoryUpdateBody, opts ...BatchOption) (*BatchResult, error)
+	BatchDelete(ctx context.Context, memoryIDs []string, opts ...BatchOption) (*BatchResult, error)
+	History(ctx context.Context, memoryID string) ([]MemoryHistory, error)
+	Users(ctx context.Context, options ...UsersOptions) (*AllUsers, error)
+	GetUser(ctx context.Context, name string) (*User, error)
+	GetEntity(ctx context.Context, entityType, name string) (*User, error)
+	MemoriesForEntity(ctx context.Context, entityType, name string, options ...SearchOptions) ([]Memory, error)
+	DeleteUser(ctx context.Context, data DeleteUserData) (*MessageResponse, error)
+	DeleteUsers(ctx context.Context, params ...DeleteUsersParams) (*DeleteUsersResult, error)
+	GetCategories(ctx context.Context) ([]Category, error)
+	SetCustomCategories(ctx context.Context, categories []Category) error
+	UpdateProject(ctx context.Context, payload PromptUpdatePayload) (*MessageResponse, error)
+}
+
+var _ Client = (*MemoryClient)(nil)
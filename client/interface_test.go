@@ -0,0 +1,26 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClientHoldsEveryAPICall(t *testing.T) {
+	// Methods that configure the client rather than call the API
+	configuration := map[string]bool{
+		"ForProject":   true,
+		"ForceReauth":  true,
+		"PoolStats":    true,
+		"ReadyHandler": true,
+		"SetAPIKey":    true,
+		"With":         true,
+	}
+	iface := reflect.TypeOf((*Client)(nil)).Elem()
+	concrete := reflect.TypeOf(&MemoryClient{})
+	for i := 0; i < concrete.NumMethod(); i++ {
+		name := concrete.Method(i).Name
+		if _, ok := iface.MethodByName(name); !ok && !configuration[name] {
+			t.Errorf("Client is missing MemoryClient.%s", name)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"reflect"
+)
+
+// Options reach the API through four layers, each overriding the one
+// before it:
+//
+//  1. the client's defaults (ClientOptions.Defaults)
+//  2. the client's organization and project, set at construction, by
+//     ForProject or With, or resolved by Ping
+//  3. the tenant set on the context with WithTenant
+//  4. the options passed to the call
+//
+// Methods apply the defaults and the tenant's entity IDs with withDefaults
+// before validating the call, so invalid calls fail without a request, and
+// the organization and project with resolveOptions once authenticate has
+// resolved them. Defaults cannot hold an organization or project, so
+// applying the layers in that order keeps their precedence. A Scope sets its
+// entity ID on the call's options before they reach the client, so it acts
+// as part of layer 4 and rejects calls naming another entity.
+
+// withDefaults applies the client's defaults and the tenant's entity IDs
+// under opts
+func (c *MemoryClient) withDefaults(ctx context.Context, opts MemoryOptions) MemoryOptions {
+	var base MemoryOptions
+	if c.defaults != nil {
+		base = *c.defaults
+	}
+	if tenant, ok := TenantFrom(ctx); ok {
+		base = mergeOptions(base, tenant.entityOptions())
+	}
+	return mergeOptions(base, opts)
+}
+
+// resolveOptions applies the organization and project of the tenant, or
+// else of the client, under opts. It must run after authenticate.
+func (c *MemoryClient) resolveOptions(ctx context.Context, opts MemoryOptions) MemoryOptions {
+	base := c.projectOptions()
+	if tenant, ok := TenantFrom(ctx); ok && (tenant.OrgID != "" || tenant.ProjectID != "") {
+		base = MemoryOptions{}
+		if tenant.OrgID != "" {
+			base.OrgID = tenant.OrgID
+		}
+		if tenant.ProjectID != "" {
+			base.ProjectID = tenant.ProjectID
+		}
+	}
+	return mergeOptions(base, opts)
+}
+
+// validateDefaults rejects defaults that would override the client's
+// organization or project
+func validateDefaults(defaults MemoryOptions) error {
+	if defaults.OrgID != nil || defaults.ProjectID != nil || defaults.OrgName != nil || defaults.ProjectName != nil {
+		return NewValidationError("defaults", "defaults cannot set the organization or project; set OrganizationID and ProjectID instead")
+	}
+	return defaults.Validate()
+}
+
+// projectOptions returns options carrying only the client's organization
+// and project. IDs take the place of the deprecated names when both are set.
+func (c *MemoryClient) projectOptions() MemoryOptions {
+	var opts MemoryOptions
+	if orgID, projectID := c.scope(); orgID != nil && projectID != nil {
+		opts.OrgID, opts.ProjectID = orgID, projectID
+	} else if c.organizationName != nil && c.projectName != nil {
+		opts.OrgName, opts.ProjectName = c.organizationName, c.projectName
+	}
+	return opts
+}
+
+// mergeOptions overlays override on base. A field set in override wins and
+// a field left unset takes base's value, with these exceptions:
+//   - Metadata keys are merged, override's winning
+//   - APIVersion and Version are taken together, as are the organization and
+//     project fields, so a pair is never half from each side
+//   - ConfirmDeleteAll is never inherited
+func mergeOptions(base, override MemoryOptions) MemoryOptions {
+	merged := override
+	b := reflect.ValueOf(base)
+	m := reflect.ValueOf(&merged).Elem()
+	for i := 0; i < m.NumField(); i++ {
+		if m.Field(i).IsZero() && m.Type().Field(i).Type.Kind() != reflect.Bool {
+			m.Field(i).Set(b.Field(i))
+		}
+	}
+
+	if override.APIVersion != nil || override.Version != nil {
+		merged.APIVersion, merged.Version = override.APIVersion, override.Version
+	}
+	if override.OrgID != nil || override.ProjectID != nil || override.OrgName != nil || override.ProjectName != nil {
+		merged.OrgID, merged.ProjectID = override.OrgID, override.ProjectID
+		merged.OrgName, merged.ProjectName = override.OrgName, override.ProjectName
+	}
+	if base.Metadata != nil && override.Metadata != nil {
+		merged.Metadata = make(map[string]interface{}, len(base.Metadata)+len(override.Metadata))
+		for key, value := range base.Metadata {
+			merged.Metadata[key] = value
+		}
+		for key, value := range override.Metadata {
+			merged.Metadata[key] = value
+		}
+	}
+	return merged
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeOptions(t *testing.T) {
+	v1, v2 := APIVersionV1, APIVersionV2
+	format := OutputFormatV1_1
+	base := MemoryOptions{
+		APIVersion:       &v2,
+		OutputFormat:     &format,
+		Infer:            boolPtr(false),
+		UserID:           stringPtr("default-user"),
+		Metadata:         map[string]interface{}{"environment": "prod", "team": "core"},
+		ConfirmDeleteAll: true,
+	}
+
+	merged := mergeOptions(base, MemoryOptions{
+		Version:  &v1,
+		Infer:    boolPtr(true),
+		Metadata: map[string]interface{}{"team": "search"},
+	})
+	if merged.APIVersion != nil || merged.Version == nil || *merged.Version != v1 {
+		t.Errorf("APIVersion, Version = %v, %v, want only the call's Version", merged.APIVersion, merged.Version)
+	}
+	if *merged.Infer != true || *merged.UserID != "default-user" || *merged.OutputFormat != format {
+		t.Errorf("merged = %+v, want the call's Infer over the defaults' UserID and OutputFormat", merged)
+	}
+	if want := map[string]interface{}{"environment": "prod", "team": "search"}; !reflect.DeepEqual(merged.Metadata, want) {
+		t.Errorf("Metadata = %v, want %v", merged.Metadata, want)
+	}
+	if merged.ConfirmDeleteAll {
+		t.Error("ConfirmDeleteAll was inherited from the defaults")
+	}
+	if base.Metadata["team"] != "core" {
+		t.Error("merging modified the defaults' metadata")
+	}
+}
+
+func TestClientDefaults(t *testing.T) {
+	c, captured := newStubClient(t, http.StatusOK, `[]`)
+	v2 := APIVersionV2
+	c.defaults = &MemoryOptions{APIVersion: &v2, Infer: boolPtr(false), Metadata: map[string]interface{}{"environment": "prod"}}
+	ctx := context.Background()
+
+	if _, err := c.Add(ctx, []Message{{Role: "user", Content: "hi"}}, MemoryOptions{UserID: stringPtr("alex")}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if captured.Body["infer"] != false || captured.Body["version"] != "v2" || captured.Body["user_id"] != "alex" {
+		t.Errorf("Add body = %v, want the defaults under the call's user_id", captured.Body)
+	}
+	if metadata, _ := captured.Body["metadata"].(map[string]interface{}); metadata["environment"] != "prod" {
+		t.Errorf("Add metadata = %v, want the default environment", captured.Body["metadata"])
+	}
+
+	if _, err := c.GetAll(ctx, SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}}); err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if captured.Method != http.MethodPost || captured.Path != "/v2/memories/" {
+		t.Errorf("GetAll = %s %s, want the default v2 route", captured.Method, captured.Path)
+	}
+}
+
+func TestInvalidDefaults(t *testing.T) {
+	version := APIVersion("v9")
+	for name, defaults := range map[string]MemoryOptions{
+		"bad version": {APIVersion: &version},
+		"org id":      {OrgID: "org-2", ProjectID: "proj-2"},
+		"org name":    {OrgName: stringPtr("acme")},
+	} {
+		_, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Defaults: &defaults})
+		if _, ok := err.(*ValidationError); !ok {
+			t.Errorf("%s: NewMemoryClient() error = %v, want a ValidationError", name, err)
+		}
+	}
+}
+
+func TestMergeOptionsTakesPairsTogether(t *testing.T) {
+	base := MemoryOptions{OrgID: "org-1", ProjectID: "proj-1"}
+
+	merged := mergeOptions(base, MemoryOptions{OrgID: "org-2"})
+	if merged.OrgID != "org-2" || merged.ProjectID != nil {
+		t.Errorf("OrgID, ProjectID = %v, %v, want only the override's org", merged.OrgID, merged.ProjectID)
+	}
+	merged = mergeOptions(base, MemoryOptions{OrgName: stringPtr("acme"), ProjectName: stringPtr("web")})
+	if merged.OrgID != nil || merged.ProjectID != nil || *merged.OrgName != "acme" {
+		t.Errorf("merged = %+v, want the override's names without the base's IDs", merged)
+	}
+	merged = mergeOptions(base, MemoryOptions{UserID: stringPtr("alex")})
+	if merged.OrgID != "org-1" || merged.ProjectID != "proj-1" {
+		t.Errorf("OrgID, ProjectID = %v, %v, want the base's", merged.OrgID, merged.ProjectID)
+	}
+}
+
+func TestProjectOptions(t *testing.T) {
+	c := &MemoryClient{organizationName: stringPtr("acme"), projectName: stringPtr("web")}
+	if opts := c.projectOptions(); *opts.OrgName != "acme" || *opts.ProjectName != "web" || opts.OrgID != nil {
+		t.Errorf("names only: projectOptions() = %+v, want the names", opts)
+	}
+	c.organizationID, c.projectID = "org-1", "proj-1"
+	if opts := c.projectOptions(); opts.OrgID != "org-1" || opts.ProjectID != "proj-1" || opts.OrgName != nil || opts.ProjectName != nil {
+		t.Errorf("IDs and names: projectOptions() = %+v, want the IDs only", opts)
+	}
+	c.projectID = nil
+	if opts := c.projectOptions(); opts.OrgID != nil || *opts.OrgName != "acme" {
+		t.Errorf("half an ID pair: projectOptions() = %+v, want the names", opts)
+	}
+}
+
+// TestOptionPrecedence checks every method that sends MemoryOptions orders
+// the layers the same way: defaults, then the client's project, then the call
+func TestOptionPrecedence(t *testing.T) {
+	ctx := context.Background()
+	calls := map[string]func(c *MemoryClient, opts MemoryOptions) error{
+		"Add": func(c *MemoryClient, opts MemoryOptions) error {
+			_, err := c.Add(ctx, []Message{{Role: "user", Content: "hi"}}, opts)
+			return err
+		},
+		"Search": func(c *MemoryClient, opts MemoryOptions) error {
+			_, err := c.Search(ctx, "hi", SearchOptions{MemoryOptions: opts})
+			return err
+		},
+		"GetAll": func(c *MemoryClient, opts MemoryOptions) error {
+			opts.WithAPIVersion(APIVersionV2)
+			_, err := c.GetAll(ctx, SearchOptions{MemoryOptions: opts})
+			return err
+		},
+		"DeleteAll": func(c *MemoryClient, opts MemoryOptions) error {
+			opts.WithAPIVersion(APIVersionV2)
+			_, err := c.DeleteAll(ctx, opts)
+			return err
+		},
+	}
+	for name, call := range calls {
+		body := `[]`
+		if name == "DeleteAll" {
+			body = `{"message":"ok"}`
+		}
+		c, captured := newStubClient(t, http.StatusOK, body)
+		c.defaults = &MemoryOptions{UserID: stringPtr("default-user"), Metadata: map[string]interface{}{"environment": "prod"}}
+
+		// The client's project fills in what the call leaves out
+		if err := call(c, MemoryOptions{}); err != nil {
+			t.Fatalf("%s() error = %v", name, err)
+		}
+		if captured.Body["org_id"] != "org-1" || captured.Body["project_id"] != "proj-1" {
+			t.Errorf("%s: org_id, project_id = %v, %v, want the client's", name, captured.Body["org_id"], captured.Body["project_id"])
+		}
+		if !strings.Contains(fmt.Sprint(captured.Body), "default-user") {
+			t.Errorf("%s: body = %v, want the default user", name, captured.Body)
+		}
+
+		// The call overrides both
+		if err := call(c, MemoryOptions{UserID: stringPtr("alex"), OrgID: "org-2", ProjectID: "proj-2"}); err != nil {
+			t.Fatalf("%s() error = %v", name, err)
+		}
+		if captured.Body["org_id"] != "org-2" || captured.Body["project_id"] != "proj-2" {
+			t.Errorf("%s: org_id, project_id = %v, %v, want the call's", name, captured.Body["org_id"], captured.Body["project_id"])
+		}
+		if body := fmt.Sprint(captured.Body); strings.Contains(body, "default-user") || !strings.Contains(body, "alex") {
+			t.Errorf("%s: body = %v, want the call's user", name, captured.Body)
+		}
+	}
+}
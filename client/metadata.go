@@ -0,0 +1,115 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MetadataSchema describes the metadata a project stores on its memories.
+// When set on ClientOptions, metadata passed to Add and Search is checked
+// against it before the request is sent.
+type MetadataSchema struct {
+	typ    reflect.Type
+	fields map[string]bool
+
+	// Strict rejects metadata keys that are not fields of the schema type
+	Strict bool
+}
+
+// NewMetadataSchema creates a schema from the struct type T. Keys are the
+// struct's JSON field names.
+func NewMetadataSchema[T any]() (*MetadataSchema, error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("metadata schema must be a struct type, got %s", typ)
+	}
+
+	fields := make(map[string]bool)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		fields[name] = true
+	}
+
+	return &MetadataSchema{typ: typ, fields: fields}, nil
+}
+
+// Validate checks that metadata decodes into the schema type and, in strict
+// mode, that it has no unregistered keys
+func (s *MetadataSchema) Validate(metadata map[string]interface{}) error {
+	if metadata == nil {
+		return nil
+	}
+
+	if s.Strict {
+		var unknown []string
+		for key := range metadata {
+			if !s.fields[key] {
+				unknown = append(unknown, key)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return NewValidationError("metadata", fmt.Sprintf("unregistered keys: %s", strings.Join(unknown, ", ")))
+		}
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return NewValidationError("metadata", fmt.Sprintf("cannot be encoded: %v", err))
+	}
+	if err := json.Unmarshal(data, reflect.New(s.typ).Interface()); err != nil {
+		return NewValidationError("metadata", fmt.Sprintf("does not match %s: %v", s.typ, err))
+	}
+	return nil
+}
+
+// EncodeMetadata converts a metadata struct into the map used by
+// MemoryOptions.Metadata
+func EncodeMetadata(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("metadata must encode to a JSON object: %w", err)
+	}
+	return metadata, nil
+}
+
+// DecodeMetadata converts a memory's metadata into T. A memory without
+// metadata yields the zero value.
+func DecodeMetadata[T any](memory Memory) (T, error) {
+	var result T
+	if memory.Metadata == nil {
+		return result, nil
+	}
+	if err := parseResponse(memory.Metadata, &result); err != nil {
+		return result, fmt.Errorf("failed to decode metadata of memory %s: %w", memory.ID, err)
+	}
+	return result, nil
+}
+
+// validateMetadata checks metadata against the client's schema, if any
+func (c *MemoryClient) validateMetadata(metadata map[string]interface{}) error {
+	if c.metadataSchema == nil {
+		return nil
+	}
+	return c.metadataSchema.Validate(metadata)
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type ticketMetadata struct {
+	Ticket   string   `json:"ticket"`
+	Priority int      `json:"priority,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Internal string   `json:"-"`
+	Source   string
+}
+
+func TestNewMetadataSchemaFields(t *testing.T) {
+	schema, err := NewMetadataSchema[ticketMetadata]()
+	if err != nil {
+		t.Fatalf("NewMetadataSchema() error = %v", err)
+	}
+
+	for _, key := range []string{"ticket", "priority", "tags", "Source"} {
+		if !schema.fields[key] {
+			t.Errorf("fields missing %q", key)
+		}
+	}
+	for _, key := range []string{"Internal", "-", "Ticket"} {
+		if schema.fields[key] {
+			t.Errorf("fields unexpectedly contains %q", key)
+		}
+	}
+
+	if _, err := NewMetadataSchema[map[string]string](); err == nil {
+		t.Error("NewMetadataSchema[map]() expected error")
+	}
+}
+
+func TestMetadataSchemaValidate(t *testing.T) {
+	schema, err := NewMetadataSchema[ticketMetadata]()
+	if err != nil {
+		t.Fatalf("NewMetadataSchema() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		strict   bool
+		metadata map[string]interface{}
+		wantErr  string
+	}{
+		{name: "nil metadata", strict: true, metadata: nil},
+		{name: "valid", strict: true, metadata: map[string]interface{}{"ticket": "T-1", "priority": 2, "tags": []string{"a"}}},
+		{name: "unknown key lenient", strict: false, metadata: map[string]interface{}{"ticket": "T-1", "extra": true}},
+		{name: "unknown keys strict", strict: true, metadata: map[string]interface{}{"zeta": 1, "alpha": 2}, wantErr: "unregistered keys: alpha, zeta"},
+		{name: "type mismatch", strict: false, metadata: map[string]interface{}{"priority": "high"}, wantErr: "does not match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema.Strict = tt.strict
+			err := schema.Validate(tt.metadata)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want *ValidationError", err)
+			}
+			if validationErr.Field != "metadata" || !strings.Contains(validationErr.Message, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want metadata error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeMetadata(t *testing.T) {
+	metadata, err := EncodeMetadata(ticketMetadata{Ticket: "T-1", Priority: 3})
+	if err != nil {
+		t.Fatalf("EncodeMetadata() error = %v", err)
+	}
+	if metadata["ticket"] != "T-1" || metadata["priority"] != float64(3) {
+		t.Errorf("EncodeMetadata() = %v", metadata)
+	}
+	if _, err := EncodeMetadata([]string{"not", "an", "object"}); err == nil {
+		t.Error("EncodeMetadata(slice) expected error")
+	}
+
+	decoded, err := DecodeMetadata[ticketMetadata](Memory{ID: "mem-1", Metadata: metadata})
+	if err != nil {
+		t.Fatalf("DecodeMetadata() error = %v", err)
+	}
+	if decoded.Ticket != "T-1" || decoded.Priority != 3 {
+		t.Errorf("DecodeMetadata() = %+v", decoded)
+	}
+
+	empty, err := DecodeMetadata[ticketMetadata](Memory{ID: "mem-2"})
+	if err != nil || empty.Ticket != "" {
+		t.Errorf("DecodeMetadata(no metadata) = %+v, %v", empty, err)
+	}
+
+	_, err = DecodeMetadata[ticketMetadata](Memory{ID: "mem-3", Metadata: map[string]interface{}{"priority": "high"}})
+	if err == nil || !strings.Contains(err.Error(), "mem-3") {
+		t.Errorf("DecodeMetadata(mismatch) error = %v, want error naming mem-3", err)
+	}
+}
+
+func TestMetadataSchemaRejectsBeforeRequest(t *testing.T) {
+	schema, err := NewMetadataSchema[ticketMetadata]()
+	if err != nil {
+		t.Fatalf("NewMetadataSchema() error = %v", err)
+	}
+	schema.Strict = true
+
+	client, captured := newStubClient(t, http.StatusOK, `[]`)
+	client.metadataSchema = schema
+	ctx := context.Background()
+	metadata := map[string]interface{}{"ticket": "T-1", "color": "blue"}
+
+	if _, err := client.Add(ctx, []Message{{Role: "user", Content: "hi"}}, MemoryOptions{Metadata: metadata}); err == nil {
+		t.Error("Add() expected metadata validation error")
+	}
+	if _, err := client.Search(ctx, "hi", SearchOptions{MemoryOptions: MemoryOptions{Metadata: metadata}}); err == nil {
+		t.Error("Search() expected metadata validation error")
+	}
+	if captured.Path != "" {
+		t.Errorf("request sent to %s despite invalid metadata", captured.Path)
+	}
+
+	if _, err := client.Add(ctx, []Message{{Role: "user", Content: "hi"}}, MemoryOptions{Metadata: map[string]interface{}{"ticket": "T-1"}}); err != nil {
+		t.Errorf("Add() with valid metadata error = %v", err)
+	}
+	if captured.Path != "/v1/memories/" {
+		t.Errorf("Add() path = %q, want /v1/memories/", captured.Path)
+	}
+}
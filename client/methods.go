@@ -3,6 +3,9 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // Ping checks the API connection and initializes telemetry
@@ -27,115 +30,144 @@ func (c *MemoryClient) Ping(ctx context.Context) error {
 	}
 
 	// Update client configuration from response
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
 	if orgID, exists := responseMap["org_id"]; exists && c.organizationID == nil {
 		c.organizationID = orgID
+		c.pingedScope = true
 	}
 	if projectID, exists := responseMap["project_id"]; exists && c.projectID == nil {
 		c.projectID = projectID
+		c.pingedScope = true
 	}
 	if userEmail, exists := responseMap["user_email"].(string); exists {
 		c.telemetryID = userEmail
 	}
+	if minimum, exists := responseMap["min_sdk_version"].(string); exists {
+		c.minSDKVersion = minimum
+	}
 
 	return nil
 }
 
 // Add creates new memories from messages
 func (c *MemoryClient) Add(ctx context.Context, messages []Message, options ...MemoryOptions) ([]Memory, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return nil, err
-		}
+	result, err := c.AddWithGraph(ctx, messages, options...)
+	if err != nil {
+		return nil, err
 	}
+	return result.Results, nil
+}
 
-	c.validateOrgProject()
-
+// AddWithGraph creates new memories from messages and also returns the graph
+// relations the API reports when enable_graph is set
+func (c *MemoryClient) AddWithGraph(ctx context.Context, messages []Message, options ...MemoryOptions) (*AddResult, error) {
 	// Use first options or empty options
 	opts := MemoryOptions{}
 	if len(options) > 0 {
 		opts = options[0]
 	}
-
-	// Set organization/project info
-	if c.organizationName != nil && c.projectName != nil {
-		opts.OrgName = c.organizationName
-		opts.ProjectName = c.projectName
+	opts = c.withDefaults(ctx, opts)
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
-
-	if c.organizationID != nil && c.projectID != nil {
-		opts.OrgID = c.organizationID
-		opts.ProjectID = c.projectID
-		// Remove deprecated fields if using new ones
-		opts.OrgName = nil
-		opts.ProjectName = nil
+	if err := c.validateMetadata(opts.Metadata); err != nil {
+		return nil, err
 	}
 
-	// Handle API version
-	if opts.APIVersion != nil {
-		version := string(*opts.APIVersion)
-		opts.Version = (*APIVersion)(&version)
+	if err := c.authenticate(ctx); err != nil {
+		return nil, err
 	}
 
-	payload := c.preparePayload(messages, opts)
+	c.validateOrgProject()
 
-	response, err := c.fetchWithErrorHandling(ctx, "POST", "/v1/memories/", payload)
-	if err != nil {
-		return nil, err
+	opts = c.resolveOptions(ctx, opts)
+
+	// The add endpoint reads the version from either field; send both
+	if version, explicit := opts.apiVersion(); explicit {
+		opts.APIVersion = &version
+		opts.Version = &version
 	}
 
-	// Parse response to []Memory
-	var memories []Memory
-	if err := parseResponse(response, &memories); err != nil {
+	format := outputFormat(opts)
+	opts.OutputFormat = &format
+
+	payload := c.preparePayload(messages, opts)
+
+	ctx = withRequestIdempotencyKey(ctx)
+	var result AddResult
+	if err := c.fetchInto(ctx, "POST", "/v1/memories/", payload, &result); err != nil {
 		return nil, err
 	}
 
-	return memories, nil
+	return &result, nil
 }
 
-// Update modifies an existing memory
+// Update modifies the text of an existing memory
+//
+// Deprecated: use UpdateMemory, which can also change metadata.
 func (c *MemoryClient) Update(ctx context.Context, memoryID, message string) ([]Memory, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
+	return c.UpdateMemory(ctx, memoryID, UpdateRequest{Text: &message})
+}
+
+// UpdateWithMetadata modifies an existing memory and replaces its metadata.
+// Nil metadata leaves the metadata unchanged.
+//
+// Deprecated: use UpdateMemory.
+func (c *MemoryClient) UpdateWithMetadata(ctx context.Context, memoryID, message string, metadata map[string]interface{}) ([]Memory, error) {
+	return c.UpdateMemory(ctx, memoryID, UpdateRequest{Text: &message, Metadata: metadata})
+}
+
+// UpdateMemory changes the text and/or metadata of an existing memory.
+// Fields left nil in request are not changed.
+func (c *MemoryClient) UpdateMemory(ctx context.Context, memoryID string, request UpdateRequest) ([]Memory, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	payload := map[string]interface{}{}
+	if request.Text != nil {
+		payload["text"] = *request.Text
+	}
+	if request.Metadata != nil {
+		if err := c.validateMetadata(request.Metadata); err != nil {
 			return nil, err
 		}
+		payload["metadata"] = request.Metadata
 	}
 
 	c.validateOrgProject()
-
-	payload := map[string]interface{}{
-		"text": message,
+	if err := c.checkIfMatch(ctx, memoryID); err != nil {
+		return nil, err
 	}
 
 	endpoint := fmt.Sprintf("/v1/memories/%s/", memoryID)
-	response, err := c.fetchWithErrorHandling(ctx, "PUT", endpoint, payload)
+	ctx = withRequestIdempotencyKey(ctx)
+	memories, err := c.fetchMemories(ctx, "PUT", endpoint, payload)
 	if err != nil {
-		return nil, err
-	}
-
-	var memories []Memory
-	if err := parseResponse(response, &memories); err != nil {
-		return nil, err
+		return nil, conflictError(ctx, memoryID, immutableError(err))
 	}
-
 	return memories, nil
 }
 
 // Get retrieves a specific memory by ID
 func (c *MemoryClient) Get(ctx context.Context, memoryID string) (*Memory, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return nil, err
-		}
-	}
+	return c.GetFields(ctx, memoryID)
+}
 
-	endpoint := fmt.Sprintf("/v1/memories/%s/", memoryID)
-	response, err := c.fetchWithErrorHandling(ctx, "GET", endpoint, nil)
-	if err != nil {
+// GetFields retrieves a specific memory by ID with only the given fields
+// populated, such as "id", "memory" and "created_at". No fields returns the
+// whole memory.
+func (c *MemoryClient) GetFields(ctx context.Context, memoryID string, fields ...string) (*Memory, error) {
+	if err := validateFields(fields); err != nil {
 		return nil, err
 	}
 
+	endpoint := fmt.Sprintf("/v1/memories/%s/", memoryID)
+	if len(fields) > 0 {
+		endpoint += "?" + url.Values{"fields": {strings.Join(fields, ",")}}.Encode()
+	}
 	var memory Memory
-	if err := parseResponse(response, &memory); err != nil {
+	if err := c.fetchInto(ctx, "GET", endpoint, nil, &memory); err != nil {
 		return nil, err
 	}
 
@@ -144,201 +176,202 @@ func (c *MemoryClient) Get(ctx context.Context, memoryID string) (*Memory, error
 
 // GetAll retrieves all memories with optional filters
 func (c *MemoryClient) GetAll(ctx context.Context, options ...SearchOptions) ([]Memory, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return nil, err
-		}
-	}
-
-	c.validateOrgProject()
-
 	// Use first options or empty options
 	opts := SearchOptions{}
 	if len(options) > 0 {
 		opts = options[0]
 	}
-
-	// Set organization/project info
-	if c.organizationName != nil && c.projectName != nil {
-		opts.OrgName = c.organizationName
-		opts.ProjectName = c.projectName
+	opts.MemoryOptions = c.withDefaults(ctx, opts.MemoryOptions)
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
 
-	if c.organizationID != nil && c.projectID != nil {
-		opts.OrgID = c.organizationID
-		opts.ProjectID = c.projectID
-		opts.OrgName = nil
-		opts.ProjectName = nil
+	if err := c.authenticate(ctx); err != nil {
+		return nil, err
 	}
 
-	var endpoint string
-	var method string
-	var requestBody interface{}
+	c.validateOrgProject()
 
-	// Handle pagination
-	paginationParams := ""
-	if opts.Page != nil && opts.PageSize != nil {
-		paginationParams = fmt.Sprintf("page=%d&page_size=%d", *opts.Page, *opts.PageSize)
-	}
+	opts.MemoryOptions = c.resolveOptions(ctx, opts.MemoryOptions)
+
+	route := c.route("get all", getAllRoutes, opts.MemoryOptions)
+	var query []string
+	var requestBody interface{}
 
-	if opts.APIVersion != nil && *opts.APIVersion == APIVersionV2 {
-		// V2 API uses POST
-		method = "POST"
-		if paginationParams != "" {
-			endpoint = fmt.Sprintf("/v2/memories/?%s", paginationParams)
-		} else {
-			endpoint = "/v2/memories/"
+	if route.Method == "POST" {
+		// V2 takes the scope as filters in the request body
+		body := map[string]interface{}{}
+		if filters := v2Filters(opts.MemoryOptions); filters != nil {
+			body["filters"] = filters
 		}
-		// Prepare request body for V2
-		requestBody = map[string]interface{}{}
 		if opts.OrgID != nil {
-			requestBody.(map[string]interface{})["org_id"] = opts.OrgID
+			body["org_id"] = opts.OrgID
 		}
 		if opts.ProjectID != nil {
-			requestBody.(map[string]interface{})["project_id"] = opts.ProjectID
+			body["project_id"] = opts.ProjectID
+		}
+		if opts.IncludeExpired != nil {
+			body["include_expired"] = *opts.IncludeExpired
+		}
+		if opts.ExpiredOnly != nil {
+			body["expired_only"] = *opts.ExpiredOnly
+		}
+		if opts.Fields != nil {
+			body["fields"] = opts.Fields
+		}
+		if opts.IncludeEmbeddings != nil {
+			body["include_embeddings"] = *opts.IncludeEmbeddings
 		}
+		body["output_format"] = outputFormat(opts.MemoryOptions)
+		requestBody = body
 	} else {
-		// V1 API uses GET with query parameters
-		method = "GET"
-		params := c.prepareParams(opts.MemoryOptions)
-		queryString := params.Encode()
-		if paginationParams != "" && queryString != "" {
-			endpoint = fmt.Sprintf("/v1/memories/?%s&%s", queryString, paginationParams)
-		} else if paginationParams != "" {
-			endpoint = fmt.Sprintf("/v1/memories/?%s", paginationParams)
-		} else if queryString != "" {
-			endpoint = fmt.Sprintf("/v1/memories/?%s", queryString)
-		} else {
-			endpoint = "/v1/memories/"
+		// V1 takes everything as query parameters
+		params := c.prepareParams(opts)
+		params.Add("output_format", string(outputFormat(opts.MemoryOptions)))
+		if encoded := params.Encode(); encoded != "" {
+			query = append(query, encoded)
 		}
 	}
 
-	response, err := c.fetchWithErrorHandling(ctx, method, endpoint, requestBody)
-	if err != nil {
-		return nil, err
+	// Handle pagination
+	if opts.Page != nil && opts.PageSize != nil {
+		query = append(query, fmt.Sprintf("page=%d&page_size=%d", *opts.Page, *opts.PageSize))
 	}
 
-	var memories []Memory
-	if err := parseResponse(response, &memories); err != nil {
-		return nil, err
+	endpoint := route.Path
+	if len(query) > 0 {
+		endpoint += "?" + strings.Join(query, "&")
 	}
 
-	return memories, nil
+	return c.fetchMemories(ctx, route.Method, endpoint, requestBody)
 }
 
 // Search searches for memories matching a query
 func (c *MemoryClient) Search(ctx context.Context, query string, options ...SearchOptions) ([]Memory, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return nil, err
-		}
-	}
-
-	c.validateOrgProject()
-
 	opts := SearchOptions{}
 	if len(options) > 0 {
 		opts = options[0]
 	}
+	opts.MemoryOptions = c.withDefaults(ctx, opts.MemoryOptions)
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.validateMetadata(opts.Metadata); err != nil {
+		return nil, err
+	}
+
+	if err := c.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	c.validateOrgProject()
 
 	payload := map[string]interface{}{
 		"query": query,
 	}
 
-	// Set organization/project info
-	if c.organizationName != nil && c.projectName != nil {
-		payload["org_name"] = *c.organizationName
-		payload["project_name"] = *c.projectName
+	opts.MemoryOptions = c.resolveOptions(ctx, opts.MemoryOptions)
+	if opts.OrgName != nil {
+		payload["org_name"] = *opts.OrgName
 	}
-
-	if c.organizationID != nil && c.projectID != nil {
-		payload["org_id"] = c.organizationID
-		payload["project_id"] = c.projectID
-		delete(payload, "org_name")
-		delete(payload, "project_name")
+	if opts.ProjectName != nil {
+		payload["project_name"] = *opts.ProjectName
+	}
+	if opts.OrgID != nil {
+		payload["org_id"] = opts.OrgID
+	}
+	if opts.ProjectID != nil {
+		payload["project_id"] = opts.ProjectID
 	}
 
 	// Add search options to payload
 	addSearchOptionsToPayload(payload, opts)
-
-	endpoint := "/v1/memories/search/"
-	if opts.APIVersion != nil && *opts.APIVersion == APIVersionV2 {
-		endpoint = "/v2/memories/search/"
-	}
-
-	response, err := c.fetchWithErrorHandling(ctx, "POST", endpoint, payload)
-	if err != nil {
-		return nil, err
+	payload["output_format"] = outputFormat(opts.MemoryOptions)
+
+	// V2 takes the date window as a created_at filter
+	if version, _ := opts.apiVersion(); version == APIVersionV2 {
+		if date := dateFilter(opts.MemoryOptions); date != nil {
+			payload["filters"] = date
+			if opts.Filters != nil {
+				payload["filters"] = map[string]interface{}{"AND": []interface{}{opts.Filters, date}}
+			}
+			delete(payload, "start_date")
+			delete(payload, "end_date")
+		}
 	}
 
-	var memories []Memory
-	if err := parseResponse(response, &memories); err != nil {
-		return nil, err
+	route := c.route("search", searchRoutes, opts.MemoryOptions)
+	if c.dedupeSearches {
+		return c.sharedSearch(ctx, route, payload)
 	}
-
-	return memories, nil
+	return c.fetchMemories(ctx, route.Method, route.Path, payload)
 }
 
 // Delete removes a specific memory
 func (c *MemoryClient) Delete(ctx context.Context, memoryID string) (*MessageResponse, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return nil, err
-		}
-	}
-
-	endpoint := fmt.Sprintf("/v1/memories/%s/", memoryID)
-	response, err := c.fetchWithErrorHandling(ctx, "DELETE", endpoint, nil)
-	if err != nil {
+	if err := c.checkIfMatch(ctx, memoryID); err != nil {
 		return nil, err
 	}
-
+	endpoint := fmt.Sprintf("/v1/memories/%s/", memoryID)
+	ctx = withRequestIdempotencyKey(ctx)
 	var result MessageResponse
-	if err := parseResponse(response, &result); err != nil {
-		return nil, err
+	if err := c.fetchInto(ctx, "DELETE", endpoint, nil, &result); err != nil {
+		return nil, conflictError(ctx, memoryID, immutableError(err))
 	}
 
 	return &result, nil
 }
 
-// DeleteAll removes all memories matching the filter criteria
-func (c *MemoryClient) DeleteAll(ctx context.Context, options ...MemoryOptions) (*MessageResponse, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return nil, err
-		}
-	}
-
-	c.validateOrgProject()
-
+// DeleteAll removes all memories matching the filter criteria. Without a
+// user_id, agent_id, app_id, run_id or filters it would empty the whole
+// project, so such calls fail unless ConfirmDeleteAll is set.
+func (c *MemoryClient) DeleteAll(ctx context.Context, options ...MemoryOptions) (*DeleteAllResult, error) {
 	opts := MemoryOptions{}
 	if len(options) > 0 {
 		opts = options[0]
 	}
-
-	// Set organization/project info
-	if c.organizationName != nil && c.projectName != nil {
-		opts.OrgName = c.organizationName
-		opts.ProjectName = c.projectName
+	opts = c.withDefaults(ctx, opts)
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if version, _ := opts.apiVersion(); opts.Filters != nil && version != APIVersionV2 {
+		return nil, NewValidationError("filters", "filters require api_version v2")
+	}
+	if opts.UserID == nil && opts.AgentID == nil && opts.AppID == nil && opts.RunID == nil && opts.Filters == nil && !opts.ConfirmDeleteAll {
+		return nil, NewValidationError("confirm_delete_all", "no user_id, agent_id, app_id, run_id or filters; set ConfirmDeleteAll to delete every memory in the project")
 	}
 
-	if c.organizationID != nil && c.projectID != nil {
-		opts.OrgID = c.organizationID
-		opts.ProjectID = c.projectID
-		opts.OrgName = nil
-		opts.ProjectName = nil
+	if err := c.authenticate(ctx); err != nil {
+		return nil, err
 	}
 
-	params := c.prepareParams(opts)
-	endpoint := fmt.Sprintf("/v1/memories/?%s", params.Encode())
+	c.validateOrgProject()
 
-	response, err := c.fetchWithErrorHandling(ctx, "DELETE", endpoint, nil)
-	if err != nil {
-		return nil, err
+	opts = c.resolveOptions(ctx, opts)
+
+	route := c.route("delete all", deleteAllRoutes, opts)
+	endpoint := route.Path
+	var requestBody interface{}
+	if version, _ := opts.apiVersion(); version == APIVersionV2 {
+		// V2 takes the scope as filters in the request body
+		body := map[string]interface{}{}
+		if filters := v2Filters(opts); filters != nil {
+			body["filters"] = filters
+		}
+		if opts.OrgID != nil {
+			body["org_id"] = opts.OrgID
+		}
+		if opts.ProjectID != nil {
+			body["project_id"] = opts.ProjectID
+		}
+		requestBody = body
+	} else if params := c.prepareParams(opts); len(params) > 0 {
+		endpoint += "?" + params.Encode()
 	}
 
-	var result MessageResponse
-	if err := parseResponse(response, &result); err != nil {
+	ctx = withRequestIdempotencyKey(ctx)
+	var result DeleteAllResult
+	if err := c.fetchInto(ctx, route.Method, endpoint, requestBody, &result); err != nil {
 		return nil, err
 	}
 
@@ -366,6 +399,12 @@ func addSearchOptionsToPayload(payload map[string]interface{}, opts SearchOption
 	if opts.Filters != nil {
 		payload["filters"] = opts.Filters
 	}
+	if opts.StartDate != nil {
+		payload["start_date"] = *opts.StartDate
+	}
+	if opts.EndDate != nil {
+		payload["end_date"] = *opts.EndDate
+	}
 
 	// Add search-specific options
 	if opts.Limit != nil {
@@ -395,16 +434,24 @@ func addSearchOptionsToPayload(payload map[string]interface{}, opts SearchOption
 	if opts.Rerank != nil {
 		payload["rerank"] = *opts.Rerank
 	}
-}
-
-// BatchUpdate updates multiple memories in a single request
-func (c *MemoryClient) BatchUpdate(ctx context.Context, memories []MemoryUpdateBody) (string, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return "", err
-		}
+	if opts.FilterMemories != nil {
+		payload["filter_memories"] = *opts.FilterMemories
+	}
+	if opts.IncludeExpired != nil {
+		payload["include_expired"] = *opts.IncludeExpired
 	}
+	if opts.ExpiredOnly != nil {
+		payload["expired_only"] = *opts.ExpiredOnly
+	}
+	if opts.IncludeEmbeddings != nil {
+		payload["include_embeddings"] = *opts.IncludeEmbeddings
+	}
+}
 
+// BatchUpdate updates multiple memories. Inputs larger than the chunk size
+// (MaxBatchSize by default) are split into several requests, run with the
+// concurrency given by opts.
+func (c *MemoryClient) BatchUpdate(ctx context.Context, memories []MemoryUpdateBody, opts ...BatchOption) (*BatchResult, error) {
 	memoriesBody := make([]map[string]interface{}, len(memories))
 	for i, memory := range memories {
 		memoriesBody[i] = map[string]interface{}{
@@ -413,125 +460,180 @@ func (c *MemoryClient) BatchUpdate(ctx context.Context, memories []MemoryUpdateB
 		}
 	}
 
-	payload := map[string]interface{}{
-		"memories": memoriesBody,
-	}
-
-	response, err := c.fetchWithErrorHandling(ctx, "PUT", "/v1/batch/", payload)
-	if err != nil {
-		return "", err
-	}
-
-	// The response is expected to be a string
-	if result, ok := response.(string); ok {
-		return result, nil
-	}
+	return c.batchInChunks(ctx, "PUT", memoriesBody, "Batch update completed", opts)
+}
 
-	// If not a string, try to parse as a map and get message
-	if responseMap, ok := response.(map[string]interface{}); ok {
-		if message, exists := responseMap["message"].(string); exists {
-			return message, nil
+// BatchDelete deletes multiple memories. Inputs larger than the chunk size
+// (MaxBatchSize by default) are split into several requests, run with the
+// concurrency given by opts.
+func (c *MemoryClient) BatchDelete(ctx context.Context, memoryIDs []string, opts ...BatchOption) (*BatchResult, error) {
+	memoriesBody := make([]map[string]interface{}, len(memoryIDs))
+	for i, memoryID := range memoryIDs {
+		memoriesBody[i] = map[string]interface{}{
+			"memory_id": memoryID,
 		}
 	}
 
-	return "Batch update completed", nil
+	return c.batchInChunks(ctx, "DELETE", memoriesBody, "Batch delete completed", opts)
 }
 
-// BatchDelete deletes multiple memories in a single request
-func (c *MemoryClient) BatchDelete(ctx context.Context, memoryIDs []string) (string, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return "", err
+// batchInChunks sends items to /v1/batch/ in chunks and collects the
+// per-chunk outcome. When there are several chunks, each one's idempotency
+// key is the call's key suffixed with the chunk offset.
+func (c *MemoryClient) batchInChunks(ctx context.Context, method string, items []map[string]interface{}, fallback string, opts []BatchOption) (*BatchResult, error) {
+	key := requestIdempotencyKey(withRequestIdempotencyKey(ctx))
+	config := newBatchConfig(opts)
+	result := &BatchResult{Items: make([]BatchItem, len(items))}
+	for i, item := range items {
+		result.Items[i].MemoryID, _ = item["memory_id"].(string)
+	}
+	for offset := 0; offset < len(items); offset += config.chunkSize {
+		size := min(config.chunkSize, len(items)-offset)
+		result.Chunks = append(result.Chunks, BatchChunk{Offset: offset, Size: size})
+	}
+
+	runBatch(ctx, len(result.Chunks), config, func(ctx context.Context, i int) {
+		chunk := &result.Chunks[i]
+		payload := map[string]interface{}{
+			"memories": items[chunk.Offset : chunk.Offset+chunk.Size],
 		}
-	}
 
-	memoriesBody := make([]map[string]interface{}, len(memoryIDs))
-	for i, memoryID := range memoryIDs {
-		memoriesBody[i] = map[string]interface{}{
-			"memory_id": memoryID,
+		chunkKey := key
+		if len(result.Chunks) > 1 {
+			chunkKey = fmt.Sprintf("%s-%d", key, chunk.Offset)
+		}
+		ctx = context.WithValue(ctx, requestIdempotencyKeyContext{}, chunkKey)
+		response, err := c.fetchWithErrorHandling(ctx, method, "/v1/batch/", payload)
+		if err != nil {
+			chunk.Err = err
+			failItems(result.Items[chunk.Offset:chunk.Offset+chunk.Size], err)
+			return
 		}
+		chunk.Message = batchMessage(response, fallback)
+		parseBatchItems(response, result.Items[chunk.Offset:chunk.Offset+chunk.Size])
+	}, func(i int, err error) {
+		chunk := &result.Chunks[i]
+		chunk.Err = err
+		failItems(result.Items[chunk.Offset:chunk.Offset+chunk.Size], err)
+	})
+
+	return result, result.Err()
+}
+
+// failItems marks every item of a failed request with its error
+func failItems(items []BatchItem, err error) {
+	for i := range items {
+		items[i].Status = BatchItemFailed
+		items[i].Err = err
 	}
+}
 
-	payload := map[string]interface{}{
-		"memories": memoriesBody,
+// parseBatchItems sets the status of the items of a successful request from
+// the per-memory results of the response, {"results": [{"memory_id": ...,
+// "status": ..., "error": ...}]}. Items the response does not mention
+// succeeded.
+func parseBatchItems(response interface{}, items []BatchItem) {
+	reported := map[string]map[string]interface{}{}
+	if responseMap, ok := response.(map[string]interface{}); ok {
+		results, _ := responseMap["results"].([]interface{})
+		for _, entry := range results {
+			if entryMap, ok := entry.(map[string]interface{}); ok {
+				if id, ok := entryMap["memory_id"].(string); ok {
+					reported[id] = entryMap
+				}
+			}
+		}
 	}
 
-	response, err := c.fetchWithErrorHandling(ctx, "DELETE", "/v1/batch/", payload)
-	if err != nil {
-		return "", err
+	for i := range items {
+		items[i].Status = BatchItemSucceeded
+		entry, ok := reported[items[i].MemoryID]
+		if !ok {
+			continue
+		}
+		status, _ := entry["status"].(string)
+		message, _ := entry["error"].(string)
+		switch strings.ToLower(status) {
+		case "error", "failed", "failure":
+			if message == "" {
+				message = "batch item failed"
+			}
+		}
+		if message != "" {
+			items[i].Status = BatchItemFailed
+			items[i].Err = NewAPIError(message, 0, "")
+		}
 	}
+}
 
-	// The response is expected to be a string
+// batchMessage extracts the message of a batch response, which is either a
+// string or an object with a message field
+func batchMessage(response interface{}, fallback string) string {
 	if result, ok := response.(string); ok {
-		return result, nil
+		return result
 	}
-
-	// If not a string, try to parse as a map and get message
 	if responseMap, ok := response.(map[string]interface{}); ok {
 		if message, exists := responseMap["message"].(string); exists {
-			return message, nil
+			return message
 		}
 	}
-
-	return "Batch delete completed", nil
+	return fallback
 }
 
 // History retrieves the change history for a specific memory
 func (c *MemoryClient) History(ctx context.Context, memoryID string) ([]MemoryHistory, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return nil, err
-		}
-	}
-
 	endpoint := fmt.Sprintf("/v1/memories/%s/history/", memoryID)
-	response, err := c.fetchWithErrorHandling(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var history []MemoryHistory
-	if err := parseResponse(response, &history); err != nil {
+	if err := c.fetchInto(ctx, "GET", endpoint, nil, &history); err != nil {
 		return nil, err
 	}
 
 	return history, nil
 }
 
-// Users retrieves all users/entities
-func (c *MemoryClient) Users(ctx context.Context) (*AllUsers, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return nil, err
-		}
+// Users retrieves a page of users/entities, the first one by default. Use
+// ForEachUser to walk every page.
+func (c *MemoryClient) Users(ctx context.Context, options ...UsersOptions) (*AllUsers, error) {
+	opts := UsersOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := c.authenticate(ctx); err != nil {
+		return nil, err
 	}
 
 	c.validateOrgProject()
 
-	options := MemoryOptions{}
-	if c.organizationName != nil && c.projectName != nil {
-		options.OrgName = c.organizationName
-		options.ProjectName = c.projectName
-	}
+	requestOptions := c.resolveOptions(ctx, MemoryOptions{})
 
-	if c.organizationID != nil && c.projectID != nil {
-		options.OrgID = c.organizationID
-		options.ProjectID = c.projectID
-		options.OrgName = nil
-		options.ProjectName = nil
+	params := c.prepareParams(requestOptions)
+	if opts.Page != nil && opts.PageSize != nil {
+		params.Set("page", strconv.Itoa(*opts.Page))
+		params.Set("page_size", strconv.Itoa(*opts.PageSize))
+	}
+	if opts.Type != nil {
+		params.Set("type", *opts.Type)
 	}
-
-	params := c.prepareParams(options)
 	endpoint := fmt.Sprintf("/v1/entities/?%s", params.Encode())
 
-	response, err := c.fetchWithErrorHandling(ctx, "GET", endpoint, nil)
-	if err != nil {
+	var users AllUsers
+	if err := c.fetchInto(ctx, "GET", endpoint, nil, &users); err != nil {
 		return nil, err
 	}
 
-	var users AllUsers
-	if err := parseResponse(response, &users); err != nil {
-		return nil, err
+	// Older servers ignore the type parameter
+	if opts.Type != nil {
+		results := users.Results[:0]
+		for _, user := range users.Results {
+			if user.Type == *opts.Type {
+				results = append(results, user)
+			}
+		}
+		users.Results = results
 	}
 
 	return &users, nil
@@ -539,37 +641,26 @@ func (c *MemoryClient) Users(ctx context.Context) (*AllUsers, error) {
 
 // DeleteUser deletes a user entity (deprecated - use DeleteUsers instead)
 func (c *MemoryClient) DeleteUser(ctx context.Context, data DeleteUserData) (*MessageResponse, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return nil, err
-		}
-	}
-
 	entityType := data.EntityType
 	if entityType == "" {
 		entityType = "user"
 	}
 
 	endpoint := fmt.Sprintf("/v1/entities/%s/%d/", entityType, data.EntityID)
-	response, err := c.fetchWithErrorHandling(ctx, "DELETE", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var result MessageResponse
-	if err := parseResponse(response, &result); err != nil {
+	if err := c.fetchInto(ctx, "DELETE", endpoint, nil, &result); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
 
-// DeleteUsers deletes users based on the provided parameters
-func (c *MemoryClient) DeleteUsers(ctx context.Context, params ...DeleteUsersParams) (*MessageResponse, error) {
-	if c.telemetryID == "" {
-		if err := c.Ping(ctx); err != nil {
-			return nil, err
-		}
+// DeleteUsers deletes the entity named by params, or every user, agent, app
+// and run when none is named. Entities are deleted concurrently; the result
+// reports each one, and the error joins the failures.
+func (c *MemoryClient) DeleteUsers(ctx context.Context, params ...DeleteUsersParams) (*DeleteUsersResult, error) {
+	if err := c.authenticate(ctx); err != nil {
+		return nil, err
 	}
 
 	c.validateOrgProject()
@@ -592,18 +683,16 @@ func (c *MemoryClient) DeleteUsers(ctx context.Context, params ...DeleteUsersPar
 	} else if deleteParams.RunID != nil {
 		toDelete = []map[string]string{{"type": "run", "name": *deleteParams.RunID}}
 	} else {
-		// Delete all entities
-		entities, err := c.Users(ctx)
-		if err != nil {
-			return nil, err
-		}
-
-		toDelete = make([]map[string]string, len(entities.Results))
-		for i, entity := range entities.Results {
-			toDelete[i] = map[string]string{
+		// Delete all entities, across every page
+		err := ForEachUser(ctx, c, UsersOptions{}, func(entity User) error {
+			toDelete = append(toDelete, map[string]string{
 				"type": entity.Type,
 				"name": entity.Name,
-			}
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -611,40 +700,34 @@ func (c *MemoryClient) DeleteUsers(ctx context.Context, params ...DeleteUsersPar
 		return nil, fmt.Errorf("no entities to delete")
 	}
 
-	requestOptions := MemoryOptions{}
-	if c.organizationName != nil && c.projectName != nil {
-		requestOptions.OrgName = c.organizationName
-		requestOptions.ProjectName = c.projectName
-	}
+	requestOptions := c.resolveOptions(ctx, MemoryOptions{})
 
-	if c.organizationID != nil && c.projectID != nil {
-		requestOptions.OrgID = c.organizationID
-		requestOptions.ProjectID = c.projectID
-		requestOptions.OrgName = nil
-		requestOptions.ProjectName = nil
+	// Delete the entities concurrently, recording each outcome
+	result := &DeleteUsersResult{Entities: make([]EntityDeletion, len(toDelete))}
+	for i, entity := range toDelete {
+		result.Entities[i] = EntityDeletion{Type: entity["type"], Name: entity["name"]}
 	}
-
-	// Delete each entity
-	for _, entity := range toDelete {
-		endpoint := fmt.Sprintf("/v2/entities/%s/%s/", entity["type"], entity["name"])
+	config := newBatchConfig([]BatchOption{WithConcurrency(deleteParams.Concurrency)})
+	runBatch(ctx, len(toDelete), config, func(ctx context.Context, i int) {
+		entity := &result.Entities[i]
+		endpoint := fmt.Sprintf("/v2/entities/%s/%s/", entity.Type, entity.Name)
 		params := c.prepareParams(requestOptions)
 		if params.Encode() != "" {
 			endpoint += "?" + params.Encode()
 		}
+		ctx = withRequestIdempotencyKey(WithIdempotencySubKey(ctx, strconv.Itoa(i)))
+		_, entity.Err = c.fetchWithErrorHandling(ctx, "DELETE", endpoint, nil)
+	}, func(i int, err error) {
+		result.Entities[i].Err = err
+	})
 
-		_, err := c.fetchWithErrorHandling(ctx, "DELETE", endpoint, nil)
-		if err != nil {
-			return nil, NewAPIError(
-				fmt.Sprintf("Failed to delete %s %s: %s", entity["type"], entity["name"], err.Error()),
-				0, "",
-			)
-		}
-	}
-
-	message := "All users, agents, apps and runs deleted."
+	result.Message = "All users, agents, apps and runs deleted."
 	if deleteParams.UserID != nil || deleteParams.AgentID != nil || deleteParams.AppID != nil || deleteParams.RunID != nil {
-		message = "Entity deleted successfully."
+		result.Message = "Entity deleted successfully."
+	}
+	if failed := len(result.Failed()); failed > 0 {
+		result.Message = fmt.Sprintf("Deleted %d of %d entities; %d failed.", len(toDelete)-failed, len(toDelete), failed)
 	}
 
-	return &MessageResponse{Message: message}, nil
+	return result, result.Err()
 }
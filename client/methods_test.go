@@ -0,0 +1,662 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// stubRequest is a request captured by the stub server
+type stubRequest struct {
+	Method   string
+	Path     string
+	RawQuery string
+	Header   http.Header
+	Body     map[string]interface{}
+}
+
+// newStubClient starts a server that answers pings and replies to every other
+// request with the given status and body. The last non-ping request is stored
+// in the returned stubRequest.
+func newStubClient(t *testing.T, status int, body string) (*MemoryClient, *stubRequest) {
+	t.Helper()
+
+	captured := &stubRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/ping/" {
+			w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1","user_email":"test@example.com"}`))
+			return
+		}
+
+		*captured = stubRequest{Method: r.Method, Path: r.URL.Path, RawQuery: r.URL.RawQuery, Header: r.Header.Clone()}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &captured.Body); err != nil {
+				t.Errorf("request body is not a JSON object: %s", data)
+			}
+		}
+
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	host := srv.URL
+	client, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &host})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	return client, captured
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+const memoryListV1 = `[
+	{"id": "mem-1", "memory": "Likes Go", "user_id": "alex", "categories": ["technology"],
+	 "created_at": "2024-07-20T10:00:00Z", "updated_at": "2024-07-20T10:00:00Z", "score": 0.92}
+]`
+
+func TestClientMethodsAgainstStub(t *testing.T) {
+	ctx := context.Background()
+	v2 := APIVersionV2
+
+	tests := []struct {
+		name       string
+		status     int
+		response   string
+		call       func(c *MemoryClient) (interface{}, error)
+		wantMethod string
+		wantPath   string
+		wantQuery  string
+		wantBody   map[string]interface{}
+		check      func(t *testing.T, result interface{})
+	}{
+		{
+			name:     "Add v1",
+			status:   200,
+			response: `[{"id": "mem-1", "data": {"memory": "Likes Go"}, "event": "ADD"}]`,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.Add(ctx, []Message{{Role: "user", Content: "I like Go"}}, MemoryOptions{UserID: stringPtr("alex")})
+			},
+			wantMethod: "POST",
+			wantPath:   "/v1/memories/",
+			wantBody:   map[string]interface{}{"user_id": "alex", "org_id": "org-1", "project_id": "proj-1"},
+			check: func(t *testing.T, result interface{}) {
+				memories := result.([]Memory)
+				if len(memories) != 1 || memories[0].Data.Memory != "Likes Go" || *memories[0].Event != EventAdd {
+					t.Errorf("Add() = %+v, want one ADD event for 'Likes Go'", memories)
+				}
+			},
+		},
+		{
+			name:     "Search v1",
+			status:   200,
+			response: memoryListV1,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.Search(ctx, "languages", SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}, Limit: intPtr(5)})
+			},
+			wantMethod: "POST",
+			wantPath:   "/v1/memories/search/",
+			wantBody:   map[string]interface{}{"query": "languages", "user_id": "alex", "limit": float64(5)},
+			check: func(t *testing.T, result interface{}) {
+				memories := result.([]Memory)
+				if len(memories) != 1 || *memories[0].Score != 0.92 || memories[0].CreatedAt.Year() != 2024 {
+					t.Errorf("Search() = %+v, want one scored memory", memories)
+				}
+			},
+		},
+		{
+			name:     "Search v2 with filters",
+			status:   200,
+			response: memoryListV1,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.Search(ctx, "languages", SearchOptions{MemoryOptions: MemoryOptions{
+					APIVersion: &v2,
+					Filters:    map[string]interface{}{"user_id": "alex"},
+				}})
+			},
+			wantMethod: "POST",
+			wantPath:   "/v2/memories/search/",
+			wantBody:   map[string]interface{}{"query": "languages"},
+			check: func(t *testing.T, result interface{}) {
+				if len(result.([]Memory)) != 1 {
+					t.Errorf("Search() = %+v, want one memory", result)
+				}
+			},
+		},
+		{
+			name:     "GetAll v1",
+			status:   200,
+			response: memoryListV1,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.GetAll(ctx, SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}})
+			},
+			wantMethod: "GET",
+			wantPath:   "/v1/memories/",
+			wantQuery:  "org_id=org-1&output_format=v1.1&project_id=proj-1&user_id=alex",
+			check: func(t *testing.T, result interface{}) {
+				if memories := result.([]Memory); len(memories) != 1 || *memories[0].UserID != "alex" {
+					t.Errorf("GetAll() = %+v, want one memory for alex", memories)
+				}
+			},
+		},
+		{
+			name:     "GetAll v1 paginated",
+			status:   200,
+			response: `[]`,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.GetAll(ctx, SearchOptions{MemoryOptions: MemoryOptions{Page: intPtr(2), PageSize: intPtr(10)}})
+			},
+			wantMethod: "GET",
+			wantPath:   "/v1/memories/",
+			wantQuery:  "org_id=org-1&output_format=v1.1&project_id=proj-1&page=2&page_size=10",
+			check: func(t *testing.T, result interface{}) {
+				if memories := result.([]Memory); len(memories) != 0 {
+					t.Errorf("GetAll() = %+v, want empty page", memories)
+				}
+			},
+		},
+		{
+			name:     "GetAll v2",
+			status:   200,
+			response: memoryListV1,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.GetAll(ctx, SearchOptions{MemoryOptions: MemoryOptions{APIVersion: &v2}})
+			},
+			wantMethod: "POST",
+			wantPath:   "/v2/memories/",
+			wantBody:   map[string]interface{}{"org_id": "org-1", "project_id": "proj-1"},
+		},
+		{
+			name:     "GetAll v2 keeps scope and filters",
+			status:   200,
+			response: memoryListV1,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.GetAll(ctx, SearchOptions{MemoryOptions: MemoryOptions{
+					APIVersion: &v2,
+					UserID:     stringPtr("alex"),
+					Filters:    map[string]interface{}{"categories": map[string]interface{}{"contains": "travel"}},
+				}})
+			},
+			wantMethod: "POST",
+			wantPath:   "/v2/memories/",
+			wantBody: map[string]interface{}{"filters": map[string]interface{}{"AND": []interface{}{
+				map[string]interface{}{"user_id": "alex"},
+				map[string]interface{}{"categories": map[string]interface{}{"contains": "travel"}},
+			}}},
+		},
+		{
+			name:     "Update",
+			status:   200,
+			response: `[{"id": "mem-1", "memory": "Loves Go"}]`,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.Update(ctx, "mem-1", "Loves Go")
+			},
+			wantMethod: "PUT",
+			wantPath:   "/v1/memories/mem-1/",
+			wantBody:   map[string]interface{}{"text": "Loves Go"},
+		},
+		{
+			name:     "Delete",
+			status:   200,
+			response: `{"message": "Memory deleted successfully!"}`,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.Delete(ctx, "mem-1")
+			},
+			wantMethod: "DELETE",
+			wantPath:   "/v1/memories/mem-1/",
+			check: func(t *testing.T, result interface{}) {
+				if msg := result.(*MessageResponse).Message; msg != "Memory deleted successfully!" {
+					t.Errorf("Delete() message = %q", msg)
+				}
+			},
+		},
+		{
+			name:     "BatchUpdate with message object",
+			status:   200,
+			response: `{"message": "Successfully updated 1 memories"}`,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.BatchUpdate(ctx, []MemoryUpdateBody{{MemoryID: "mem-1", Text: "Loves Go"}})
+			},
+			wantMethod: "PUT",
+			wantPath:   "/v1/batch/",
+			check: func(t *testing.T, result interface{}) {
+				chunks := result.(*BatchResult).Chunks
+				if len(chunks) != 1 || chunks[0].Message != "Successfully updated 1 memories" {
+					t.Errorf("BatchUpdate() = %+v", chunks)
+				}
+			},
+		},
+		{
+			name:     "BatchUpdate with string body",
+			status:   200,
+			response: `"ok"`,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.BatchUpdate(ctx, []MemoryUpdateBody{{MemoryID: "mem-1", Text: "Loves Go"}})
+			},
+			wantMethod: "PUT",
+			wantPath:   "/v1/batch/",
+			check: func(t *testing.T, result interface{}) {
+				chunks := result.(*BatchResult).Chunks
+				if len(chunks) != 1 || chunks[0].Message != "ok" {
+					t.Errorf("BatchUpdate() = %+v, want ok", chunks)
+				}
+			},
+		},
+		{
+			name:   "History",
+			status: 200,
+			response: `[{"id": "h-1", "memory_id": "mem-1", "input": [{"role": "user", "content": "I like Go"}],
+				"old_memory": null, "new_memory": "Likes Go", "user_id": "alex", "categories": [],
+				"event": "ADD", "created_at": "2024-07-20T10:00:00Z", "updated_at": "2024-07-20T10:00:00Z"}]`,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.History(ctx, "mem-1")
+			},
+			wantMethod: "GET",
+			wantPath:   "/v1/memories/mem-1/history/",
+			check: func(t *testing.T, result interface{}) {
+				history := result.([]MemoryHistory)
+				if len(history) != 1 || history[0].Event != EventAdd || history[0].OldMemory != nil || *history[0].NewMemory != "Likes Go" {
+					t.Errorf("History() = %+v, want one ADD entry", history)
+				}
+			},
+		},
+		{
+			name:   "Users pagination envelope",
+			status: 200,
+			response: `{"count": 3, "next": "https://api.mem0.ai/v1/entities/?page=2", "previous": null,
+				"results": [{"id": "1", "name": "alex", "type": "user", "total_memories": 4, "owner": "me",
+				"created_at": "2024-07-20T10:00:00Z", "updated_at": "2024-07-20T10:00:00Z"}]}`,
+			call: func(c *MemoryClient) (interface{}, error) {
+				return c.Users(ctx)
+			},
+			wantMethod: "GET",
+			wantPath:   "/v1/entities/",
+			wantQuery:  "org_id=org-1&project_id=proj-1",
+			check: func(t *testing.T, result interface{}) {
+				users := result.(*AllUsers)
+				if users.Count != 3 || len(users.Results) != 1 || users.Results[0].TotalMemories != 4 || users.Next == nil {
+					t.Errorf("Users() = %+v, want first page of three", users)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, req := newStubClient(t, tt.status, tt.response)
+
+			result, err := tt.call(client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if req.Method != tt.wantMethod || req.Path != tt.wantPath {
+				t.Errorf("request = %s %s, want %s %s", req.Method, req.Path, tt.wantMethod, tt.wantPath)
+			}
+			if tt.wantQuery != "" && req.RawQuery != tt.wantQuery {
+				t.Errorf("query = %q, want %q", req.RawQuery, tt.wantQuery)
+			}
+			for key, want := range tt.wantBody {
+				if got := req.Body[key]; !reflect.DeepEqual(got, want) {
+					t.Errorf("body[%q] = %v, want %v", key, got, want)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestClientMethodErrorBodies(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		status     int
+		response   string
+		call       func(c *MemoryClient) error
+		wantStatus int
+	}{
+		{
+			name:     "Add validation error",
+			status:   400,
+			response: `{"error": "One of user_id, agent_id, app_id or run_id is required"}`,
+			call: func(c *MemoryClient) error {
+				_, err := c.Add(ctx, []Message{{Role: "user", Content: "hi"}})
+				return err
+			},
+			wantStatus: 400,
+		},
+		{
+			name:     "Get not found",
+			status:   404,
+			response: `{"detail": "Not found."}`,
+			call: func(c *MemoryClient) error {
+				_, err := c.Get(ctx, "missing")
+				return err
+			},
+			wantStatus: 404,
+		},
+		{
+			name:     "Search server error",
+			status:   500,
+			response: `Internal Server Error`,
+			call: func(c *MemoryClient) error {
+				_, err := c.Search(ctx, "anything")
+				return err
+			},
+			wantStatus: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newStubClient(t, tt.status, tt.response)
+
+			err := tt.call(client)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.wantStatus || apiErr.Body != tt.response {
+				t.Errorf("APIError = %+v, want status %d with raw body", apiErr, tt.wantStatus)
+			}
+		})
+	}
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		client, _ := newStubClient(t, 200, `{"id": `)
+		if _, err := client.Get(ctx, "mem-1"); err == nil {
+			t.Error("Get() should fail on malformed JSON")
+		}
+	})
+}
+
+func TestImmutableMemory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Add sends flag", func(t *testing.T) {
+		client, captured := newStubClient(t, 200, `[]`)
+		immutable := true
+		if _, err := client.Add(ctx, []Message{{Role: "user", Content: "Account 42 is under legal hold"}}, MemoryOptions{UserID: stringPtr("alex"), Immutable: &immutable}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		if captured.Body["immutable"] != true {
+			t.Errorf("immutable = %v, want true", captured.Body["immutable"])
+		}
+	})
+
+	tests := []struct {
+		name          string
+		status        int
+		response      string
+		call          func(c *MemoryClient) error
+		wantImmutable bool
+	}{
+		{
+			name:     "Update rejected",
+			status:   400,
+			response: `{"error": "Immutable memories cannot be updated"}`,
+			call: func(c *MemoryClient) error {
+				_, err := c.Update(ctx, "mem-1", "changed")
+				return err
+			},
+			wantImmutable: true,
+		},
+		{
+			name:     "Delete rejected",
+			status:   403,
+			response: `{"detail": "Memory is immutable"}`,
+			call: func(c *MemoryClient) error {
+				_, err := c.Delete(ctx, "mem-1")
+				return err
+			},
+			wantImmutable: true,
+		},
+		{
+			name:     "Delete not found",
+			status:   404,
+			response: `{"detail": "Not found."}`,
+			call: func(c *MemoryClient) error {
+				_, err := c.Delete(ctx, "mem-1")
+				return err
+			},
+		},
+		{
+			name:     "Update server error mentioning immutable",
+			status:   500,
+			response: `immutable store unavailable`,
+			call: func(c *MemoryClient) error {
+				_, err := c.Update(ctx, "mem-1", "changed")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newStubClient(t, tt.status, tt.response)
+
+			err := tt.call(client)
+			if got := errors.Is(err, ErrImmutableMemory); got != tt.wantImmutable {
+				t.Errorf("errors.Is(%v, ErrImmutableMemory) = %v, want %v", err, got, tt.wantImmutable)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("error = %v, want *APIError with status %d", err, tt.status)
+			}
+		})
+	}
+}
+
+func TestFieldsProjection(t *testing.T) {
+	ctx := context.Background()
+	v2 := APIVersionV2
+	fields := []string{"id", "memory", "created_at"}
+
+	tests := []struct {
+		name      string
+		call      func(c *MemoryClient) error
+		wantQuery string
+		wantBody  interface{}
+	}{
+		{
+			name: "GetFields",
+			call: func(c *MemoryClient) error {
+				_, err := c.GetFields(ctx, "mem-1", fields...)
+				return err
+			},
+			wantQuery: "fields=id%2Cmemory%2Ccreated_at",
+		},
+		{
+			name: "GetAll v1",
+			call: func(c *MemoryClient) error {
+				_, err := c.GetAll(ctx, SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}, Fields: fields})
+				return err
+			},
+			wantQuery: "fields=id%2Cmemory%2Ccreated_at&org_id=org-1&output_format=v1.1&project_id=proj-1&user_id=alex",
+		},
+		{
+			name: "GetAll v2",
+			call: func(c *MemoryClient) error {
+				_, err := c.GetAll(ctx, SearchOptions{MemoryOptions: MemoryOptions{APIVersion: &v2}, Fields: fields})
+				return err
+			},
+			wantBody: []interface{}{"id", "memory", "created_at"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := `[{"id": "mem-1", "memory": "Likes Go"}]`
+			if tt.name == "GetFields" {
+				response = `{"id": "mem-1", "memory": "Likes Go"}`
+			}
+			client, captured := newStubClient(t, 200, response)
+
+			if err := tt.call(client); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if captured.RawQuery != tt.wantQuery {
+				t.Errorf("query = %q, want %q", captured.RawQuery, tt.wantQuery)
+			}
+			if tt.wantBody != nil && fmt.Sprint(captured.Body["fields"]) != fmt.Sprint(tt.wantBody) {
+				t.Errorf("body fields = %v, want %v", captured.Body["fields"], tt.wantBody)
+			}
+		})
+	}
+
+	t.Run("blank field", func(t *testing.T) {
+		client, _ := newStubClient(t, 200, `{}`)
+		var validationErr *ValidationError
+		if _, err := client.GetFields(ctx, "mem-1", "id", " "); !errors.As(err, &validationErr) {
+			t.Errorf("GetFields() error = %v, want *ValidationError", err)
+		}
+	})
+}
+
+func TestOutputFormats(t *testing.T) {
+	ctx := context.Background()
+	v1 := OutputFormatV1
+
+	tests := []struct {
+		name       string
+		response   string
+		options    SearchOptions
+		wantFormat string
+	}{
+		{
+			name:       "v1.1 envelope by default",
+			response:   `{"results": ` + memoryListV1 + `}`,
+			wantFormat: "v1.1",
+		},
+		{
+			name:       "v1.0 plain list",
+			response:   memoryListV1,
+			options:    SearchOptions{MemoryOptions: MemoryOptions{OutputFormat: &v1}},
+			wantFormat: "v1.0",
+		},
+		{
+			name:       "v1.1 envelope with relations",
+			response:   `{"results": ` + memoryListV1 + `, "relations": []}`,
+			wantFormat: "v1.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, captured := newStubClient(t, 200, tt.response)
+
+			memories, err := client.Search(ctx, "languages", tt.options)
+			if err != nil || len(memories) != 1 || memories[0].ID == "" {
+				t.Fatalf("Search() = %+v, %v; want one memory", memories, err)
+			}
+			if captured.Body["output_format"] != tt.wantFormat {
+				t.Errorf("Search() output_format = %v, want %s", captured.Body["output_format"], tt.wantFormat)
+			}
+
+			memories, err = client.GetAll(ctx, tt.options)
+			if err != nil || len(memories) != 1 {
+				t.Fatalf("GetAll() = %+v, %v; want one memory", memories, err)
+			}
+			if !strings.Contains(captured.RawQuery, "output_format="+tt.wantFormat) {
+				t.Errorf("GetAll() query = %q, want output_format=%s", captured.RawQuery, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestDeleteUsersPartialFailure(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/ping/":
+			w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1"}`))
+		case r.URL.Path == "/v1/entities/":
+			w.Write([]byte(`{"count": 3, "results": [
+				{"id": "1", "name": "alex", "type": "user"},
+				{"id": "2", "name": "planner", "type": "agent"},
+				{"id": "3", "name": "sam", "type": "user"}
+			]}`))
+		case r.URL.Path == "/v2/entities/agent/planner/":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"detail": "internal error"}`))
+		default:
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			w.Write([]byte(`{"message": "Entity deleted successfully."}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	host := srv.URL
+	client, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &host})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+
+	result, err := client.DeleteUsers(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to delete agent planner") {
+		t.Errorf("DeleteUsers() error = %v, want agent planner failure", err)
+	}
+	if result == nil || len(result.Entities) != 3 {
+		t.Fatalf("DeleteUsers() result = %+v, want 3 entities", result)
+	}
+	failed := result.Failed()
+	if len(failed) != 1 || failed[0].Type != "agent" || failed[0].Name != "planner" {
+		t.Errorf("Failed() = %+v, want agent planner", failed)
+	}
+	if result.Message != "Deleted 2 of 3 entities; 1 failed." {
+		t.Errorf("Message = %q", result.Message)
+	}
+	if len(deleted) != 2 {
+		t.Errorf("deleted = %v, want the two users", deleted)
+	}
+}
+
+func TestBatchItemResults(t *testing.T) {
+	client, _ := newStubClient(t, 200, `{"message": "Processed 3 memories", "results": [
+		{"memory_id": "mem-1", "status": "success"},
+		{"memory_id": "mem-2", "status": "error", "error": "Memory mem-2 not found"},
+		{"memory_id": "mem-3", "status": "FAILED"}
+	]}`)
+
+	result, err := client.BatchDelete(context.Background(), []string{"mem-1", "mem-2", "mem-3", "mem-4"})
+	if err == nil || !strings.Contains(err.Error(), "memory mem-2: API request failed: Memory mem-2 not found") {
+		t.Errorf("BatchDelete() error = %v, want mem-2 failure", err)
+	}
+	if got := result.Succeeded(); got != 2 {
+		t.Errorf("Succeeded() = %d, want 2", got)
+	}
+	if failed := result.FailedIDs(); !reflect.DeepEqual(failed, []string{"mem-2", "mem-3"}) {
+		t.Errorf("FailedIDs() = %v, want mem-2 and mem-3", failed)
+	}
+	wantStatus := []string{BatchItemSucceeded, BatchItemFailed, BatchItemFailed, BatchItemSucceeded}
+	for i, item := range result.Items {
+		if item.Status != wantStatus[i] {
+			t.Errorf("Items[%d].Status = %q, want %q", i, item.Status, wantStatus[i])
+		}
+	}
+	if result.Chunks[0].Err != nil || result.Chunks[0].Message != "Processed 3 memories" {
+		t.Errorf("Chunks[0] = %+v, want the request to succeed", result.Chunks[0])
+	}
+}
@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PoolStats are running totals of a client's connection use, shared by the
+// clients derived from it with With
+type PoolStats struct {
+	Requests        int64 // Requests sent
+	ConnsCreated    int64 // Requests that dialed a new connection
+	ConnsReused     int64 // Requests served by a pooled connection
+	OpenConns       int64 // Connections currently open; zero with a custom HTTPClient
+	IdleConns       int64 // Open connections not serving a request, an estimate that assumes HTTP/1.1
+	InFlight        int64 // Requests whose response body is not closed yet
+	UndrainedBodies int64 // Response bodies closed before being read to the end, counted with DetectBodyLeaks
+}
+
+// ConnStats describes how one request obtained its connection
+type ConnStats struct {
+	Method       string
+	Endpoint     string
+	RequestID    string
+	Reused       bool          // The connection came from the pool
+	IdleTime     time.Duration // How long a reused connection sat idle
+	DNS          time.Duration // Zero unless a lookup was made
+	Connect      time.Duration // Zero unless a connection was dialed
+	TLSHandshake time.Duration // Zero unless a handshake was made
+	Wait         time.Duration // From sending the request until it had a connection
+	Pool         PoolStats     // The client's totals once the connection was obtained
+}
+
+// MetricsHook receives the connection statistics of each request, e.g. to
+// export them to Prometheus. It is called on the request's goroutine, so it
+// should not block.
+type MetricsHook interface {
+	ObserveConn(stats ConnStats)
+}
+
+// MetricsHookFunc adapts a function to the MetricsHook interface
+type MetricsHookFunc func(stats ConnStats)
+
+// ObserveConn implements MetricsHook
+func (f MetricsHookFunc) ObserveConn(stats ConnStats) {
+	f(stats)
+}
+
+// PoolStats returns the client's connection totals
+func (c *MemoryClient) PoolStats() PoolStats {
+	if c.conns == nil {
+		return PoolStats{}
+	}
+	return c.conns.stats()
+}
+
+// connTracker counts the connections and requests of a client's transport
+type connTracker struct {
+	requests, created, reused, open, inFlight, undrained atomic.Int64
+}
+
+// stats snapshots the totals
+func (t *connTracker) stats() PoolStats {
+	stats := PoolStats{
+		Requests:        t.requests.Load(),
+		ConnsCreated:    t.created.Load(),
+		ConnsReused:     t.reused.Load(),
+		OpenConns:       t.open.Load(),
+		InFlight:        t.inFlight.Load(),
+		UndrainedBodies: t.undrained.Load(),
+	}
+	stats.IdleConns = max(stats.OpenConns-stats.InFlight, 0)
+	return stats
+}
+
+// instrument counts the connections transport dials and closes
+func (t *connTracker) instrument(transport *http.Transport) *http.Transport {
+	dial := transport.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := dial(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		t.open.Add(1)
+		return &trackedConn{Conn: conn, tracker: t}, nil
+	}
+	return transport
+}
+
+// trackedConn decrements the open connections once when closed
+type trackedConn struct {
+	net.Conn
+	tracker *connTracker
+	once    sync.Once
+}
+
+// Close implements net.Conn
+func (c *trackedConn) Close() error {
+	c.once.Do(func() { c.tracker.open.Add(-1) })
+	return c.Conn.Close()
+}
+
+// connTrace records the httptrace events of one request
+type connTrace struct {
+	mu                            sync.Mutex
+	start                         time.Time
+	dnsStart, connStart, tlsStart time.Time
+	stats                         ConnStats
+	gotConn                       bool
+}
+
+// withConnTrace returns ctx carrying a trace that fills the ConnStats of a
+// request
+func withConnTrace(ctx context.Context) (context.Context, *connTrace) {
+	ct := &connTrace{start: time.Now()}
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { ct.mark(&ct.dnsStart) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { ct.elapsed(ct.dnsStart, &ct.stats.DNS) },
+		ConnectStart:      func(string, string) { ct.mark(&ct.connStart) },
+		ConnectDone:       func(string, string, error) { ct.elapsed(ct.connStart, &ct.stats.Connect) },
+		TLSHandshakeStart: func() { ct.mark(&ct.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { ct.elapsed(ct.tlsStart, &ct.stats.TLSHandshake) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			ct.mu.Lock()
+			defer ct.mu.Unlock()
+			ct.gotConn = true
+			ct.stats.Reused = info.Reused
+			ct.stats.IdleTime = info.IdleTime
+			ct.stats.Wait = time.Since(ct.start)
+		},
+	}), ct
+}
+
+// mark records the current time in at
+func (ct *connTrace) mark(at *time.Time) {
+	ct.mu.Lock()
+	*at = time.Now()
+	ct.mu.Unlock()
+}
+
+// elapsed records the time since start in d
+func (ct *connTrace) elapsed(start time.Time, d *time.Duration) {
+	ct.mu.Lock()
+	*d = time.Since(start)
+	ct.mu.Unlock()
+}
+
+// result returns the request's ConnStats and whether it got a connection
+func (ct *connTrace) result() (ConnStats, bool) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	return ct.stats, ct.gotConn
+}
+
+// trackedBody ends a request's flight when its response body is closed and,
+// with DetectBodyLeaks, flags bodies closed before io.EOF was read
+type trackedBody struct {
+	io.ReadCloser
+	tracker     *connTracker
+	requestID   string
+	detectLeaks bool
+	eof         bool
+	once        sync.Once
+}
+
+// Read implements io.Reader
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.eof = true
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (b *trackedBody) Close() error {
+	b.once.Do(func() {
+		b.tracker.inFlight.Add(-1)
+		// Close must not read: the body may still be streaming. Readers that
+		// stop at the end of a JSON value drain the rest to see io.EOF.
+		if !b.detectLeaks || b.eof {
+			return
+		}
+		b.tracker.undrained.Add(1)
+		fmt.Printf("Warning: response body of request %s was closed before being read to the end; its connection cannot be reused.\n", b.requestID)
+	})
+	return b.ReadCloser.Close()
+}
+
+// observeConn counts how a request got its connection and reports it to the
+// client's MetricsHook
+func (c *MemoryClient) observeConn(trace *connTrace, method, endpoint, requestID string) {
+	stats, ok := trace.result()
+	if !ok {
+		return
+	}
+	if stats.Reused {
+		c.conns.reused.Add(1)
+	} else {
+		c.conns.created.Add(1)
+	}
+	if c.metrics == nil {
+		return
+	}
+	// The query may carry entity IDs; keep it out of metric labels
+	stats.Method, stats.Endpoint, stats.RequestID = method, strings.SplitN(endpoint, "?", 2)[0], requestID
+	stats.Pool = c.conns.stats()
+	c.metrics.ObserveConn(stats)
+}
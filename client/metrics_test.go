@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMetricsHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/ping/" {
+			w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1","user_email":"test@example.com"}`))
+			return
+		}
+		w.Write([]byte(memoryListV1))
+	}))
+	t.Cleanup(srv.Close)
+
+	var mu sync.Mutex
+	var observed []ConnStats
+	host := srv.URL
+	c, err := NewMemoryClient(ClientOptions{
+		APIKey: "test-api-key",
+		Host:   &host,
+		Metrics: MetricsHookFunc(func(stats ConnStats) {
+			mu.Lock()
+			observed = append(observed, stats)
+			mu.Unlock()
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	ctx := context.Background()
+	if _, err := c.GetAll(ctx, SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}}); err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if _, err := c.With().Search(ctx, "go", SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}}); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(observed) != 3 {
+		t.Fatalf("observed %d requests, want ping, GetAll and Search", len(observed))
+	}
+	if ping := observed[0]; ping.Reused || ping.Connect <= 0 || ping.Endpoint != "/v1/ping/" {
+		t.Errorf("ping stats = %+v, want a new connection to /v1/ping/", ping)
+	}
+	getAll := observed[1]
+	if !getAll.Reused || getAll.Method != "GET" || getAll.Endpoint != "/v1/memories/" || getAll.RequestID == "" {
+		t.Errorf("GetAll stats = %+v, want a reused connection to /v1/memories/ without the query", getAll)
+	}
+	if !observed[2].Reused {
+		t.Errorf("derived client's stats = %+v, want the shared pool's connection reused", observed[2])
+	}
+
+	stats := c.PoolStats()
+	want := PoolStats{Requests: 3, ConnsCreated: 1, ConnsReused: 2, OpenConns: 1, IdleConns: 1}
+	if stats != want {
+		t.Errorf("PoolStats() = %+v, want %+v", stats, want)
+	}
+}
+
+func TestDetectBodyLeaks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/ping/" {
+			w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1"}`))
+			return
+		}
+		w.Write([]byte(strings.Repeat(" ", 1024) + "[]"))
+	}))
+	t.Cleanup(srv.Close)
+
+	host := srv.URL
+	c, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &host, DetectBodyLeaks: true})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	// Decoders stop at the end of the JSON value, which still drains the body
+	if _, err := c.GetAll(ctx, SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}}); err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if got := c.PoolStats().UndrainedBodies; got != 0 {
+		t.Fatalf("UndrainedBodies after GetAll = %d, want 0", got)
+	}
+
+	resp, _, err := c.send(ctx, "GET", "/v1/memories/", nil)
+	if err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	if c.PoolStats().InFlight != 1 {
+		t.Errorf("InFlight = %d before closing the body, want 1", c.PoolStats().InFlight)
+	}
+	resp.Body.Close()
+	if stats := c.PoolStats(); stats.UndrainedBodies != 1 || stats.InFlight != 0 {
+		t.Errorf("PoolStats() after an unread body = %+v, want 1 undrained and none in flight", stats)
+	}
+}
+
+func TestClosingStreamingBodyDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/ping/" {
+			w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1"}`))
+			return
+		}
+		w.Write([]byte(`[{"id":"mem-1"}`))
+		w.(http.Flusher).Flush()
+		<-release
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+
+	host := srv.URL
+	c, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &host, DetectBodyLeaks: true})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	resp, _, err := c.send(context.Background(), "GET", "/v1/memories/", nil)
+	if err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	resp.Body.Read(make([]byte, 4))
+
+	closed := make(chan struct{})
+	go func() {
+		resp.Body.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close() blocked on a body that is still streaming")
+	}
+	if got := c.PoolStats().UndrainedBodies; got != 1 {
+		t.Errorf("UndrainedBodies = %d, want 1", got)
+	}
+}
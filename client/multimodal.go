@@ -0,0 +1,135 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxImageSize is the largest image NewImageFromFile encodes, in bytes
+const MaxImageSize = 20 << 20
+
+// imageContentType is the Type of image MultiModalMessages
+const imageContentType = "image_url"
+
+// SupportedImageTypes lists the image MIME types accepted in messages
+var SupportedImageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// NewImageMessage creates a message referencing the image at imageURL, which
+// is an http(s) URL or a base64 data URL of a supported image type
+func NewImageMessage(role, imageURL string) (Message, error) {
+	if err := validateRole(role); err != nil {
+		return Message{}, err
+	}
+	if err := validateImageURL(imageURL); err != nil {
+		return Message{}, err
+	}
+
+	content := MultiModalMessages{Type: imageContentType}
+	content.ImageURL.URL = imageURL
+	return Message{Role: role, Content: content}, nil
+}
+
+// NewImageFromFile creates a user message embedding the image at path as a
+// base64 data URL. The type is detected from the file's content.
+func NewImageFromFile(path string) (Message, error) {
+	data, err := readLimited(path, MaxImageSize)
+	if err != nil {
+		return Message{}, err
+	}
+
+	mimeType := http.DetectContentType(data)
+	if !supportedImageType(mimeType) {
+		return Message{}, NewValidationError("image", fmt.Sprintf("unsupported image type %s for %s; supported: %s", mimeType, filepath.Base(path), strings.Join(SupportedImageTypes, ", ")))
+	}
+	return NewImageMessage("user", "data:"+mimeType+";base64,"+base64.StdEncoding.EncodeToString(data))
+}
+
+// Image returns the image content of the message. It accepts both content
+// built with NewImageMessage and content decoded from JSON.
+func (m Message) Image() (*MultiModalMessages, bool) {
+	switch content := m.Content.(type) {
+	case MultiModalMessages:
+		return &content, content.Type == imageContentType
+	case *MultiModalMessages:
+		return content, content != nil && content.Type == imageContentType
+	case map[string]interface{}:
+		data, err := json.Marshal(content)
+		if err != nil {
+			return nil, false
+		}
+		var decoded MultiModalMessages
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded.Type != imageContentType {
+			return nil, false
+		}
+		return &decoded, true
+	default:
+		return nil, false
+	}
+}
+
+// validateRole checks that role is one the API accepts
+func validateRole(role string) error {
+	if role != "user" && role != "assistant" {
+		return NewValidationError("role", fmt.Sprintf("must be user or assistant, got %q", role))
+	}
+	return nil
+}
+
+// validateImageURL checks that imageURL is an http(s) URL or a data URL of a
+// supported image type
+func validateImageURL(imageURL string) error {
+	if rest, ok := strings.CutPrefix(imageURL, "data:"); ok {
+		mimeType, encoded, found := strings.Cut(rest, ";base64,")
+		if !found {
+			return NewValidationError("image_url", "data URL must be base64 encoded")
+		}
+		if !supportedImageType(mimeType) {
+			return NewValidationError("image_url", fmt.Sprintf("unsupported image type %s; supported: %s", mimeType, strings.Join(SupportedImageTypes, ", ")))
+		}
+		if _, err := base64.StdEncoding.DecodeString(encoded); err != nil {
+			return NewValidationError("image_url", fmt.Sprintf("invalid base64 data: %v", err))
+		}
+		return nil
+	}
+	return validateHTTPURL("image_url", imageURL)
+}
+
+// validateHTTPURL checks that rawURL is an absolute http(s) URL
+func validateHTTPURL(field, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return NewValidationError(field, fmt.Sprintf("must be an http(s) URL, got %q", rawURL))
+	}
+	return nil
+}
+
+func supportedImageType(mimeType string) bool {
+	for _, supported := range SupportedImageTypes {
+		if mimeType == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// readLimited reads the file at path, failing if it's larger than limit
+func readLimited(path string, limit int64) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if info.Size() > limit {
+		return nil, NewValidationError("file", fmt.Sprintf("%s is %d bytes, larger than the %d byte limit", filepath.Base(path), info.Size(), limit))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return data, nil
+}
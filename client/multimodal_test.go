@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// pngHeader is enough of a PNG file for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestNewImageMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		url     string
+		wantErr string
+	}{
+		{name: "https", role: "user", url: "https://example.com/cat.jpg"},
+		{name: "data url", role: "assistant", url: "data:image/png;base64,iVBORw0KGgo="},
+		{name: "bad role", role: "system", url: "https://example.com/cat.jpg", wantErr: "role"},
+		{name: "relative", role: "user", url: "/cat.jpg", wantErr: "http(s) URL"},
+		{name: "ftp", role: "user", url: "ftp://example.com/cat.jpg", wantErr: "http(s) URL"},
+		{name: "unsupported data type", role: "user", url: "data:image/tiff;base64,AAAA", wantErr: "unsupported image type image/tiff"},
+		{name: "not base64", role: "user", url: "data:image/png,rawbytes", wantErr: "base64"},
+		{name: "bad base64", role: "user", url: "data:image/png;base64,!!!", wantErr: "invalid base64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := NewImageMessage(tt.role, tt.url)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewImageMessage() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewImageMessage() error = %v", err)
+			}
+			image, ok := message.Image()
+			if !ok || image.ImageURL.URL != tt.url || message.Role != tt.role {
+				t.Errorf("NewImageMessage() = %+v", message)
+			}
+		})
+	}
+}
+
+func TestNewImageFromFile(t *testing.T) {
+	dir := t.TempDir()
+	imagePath := filepath.Join(dir, "photo.bin")
+	if err := os.WriteFile(imagePath, pngHeader, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	textPath := filepath.Join(dir, "notes.png")
+	if err := os.WriteFile(textPath, []byte("not an image"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := NewImageFromFile(imagePath)
+	if err != nil {
+		t.Fatalf("NewImageFromFile() error = %v", err)
+	}
+	image, ok := message.Image()
+	if !ok || !strings.HasPrefix(image.ImageURL.URL, "data:image/png;base64,") || message.Role != "user" {
+		t.Errorf("NewImageFromFile() = %+v", message)
+	}
+
+	if _, err := NewImageFromFile(textPath); err == nil || !strings.Contains(err.Error(), "unsupported image type") {
+		t.Errorf("NewImageFromFile(text) error = %v", err)
+	}
+	if _, err := NewImageFromFile(filepath.Join(dir, "missing.png")); err == nil {
+		t.Error("NewImageFromFile(missing) expected error")
+	}
+}
+
+func TestImageMessageRoundTrip(t *testing.T) {
+	message, err := NewImageMessage("user", "https://example.com/cat.jpg")
+	if err != nil {
+		t.Fatalf("NewImageMessage() error = %v", err)
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"role":"user","content":{"type":"image_url","image_url":{"url":"https://example.com/cat.jpg"}}}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded Message
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	image, ok := decoded.Image()
+	if !ok || image.ImageURL.URL != "https://example.com/cat.jpg" {
+		t.Errorf("decoded Image() = %+v, %v", image, ok)
+	}
+	if _, ok := (Message{Role: "user", Content: "text"}).Image(); ok {
+		t.Error("Image() of text message reported an image")
+	}
+
+	client, captured := newStubClient(t, http.StatusOK, `[]`)
+	if _, err := client.Add(context.Background(), []Message{message}, MemoryOptions{UserID: stringPtr("alice")}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	sent := captured.Body["messages"].([]interface{})[0].(map[string]interface{})
+	if content := sent["content"].(map[string]interface{}); content["type"] != "image_url" {
+		t.Errorf("sent content = %v", content)
+	}
+}
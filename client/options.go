@@ -0,0 +1,77 @@
+package client
+
+import "time"
+
+// DateFormat is the layout used for start_date and end_date
+const DateFormat = time.RFC3339
+
+// WithStartDate sets StartDate from t, formatted in UTC, and returns o for chaining
+func (o *MemoryOptions) WithStartDate(t time.Time) *MemoryOptions {
+	date := t.UTC().Format(DateFormat)
+	o.StartDate = &date
+	return o
+}
+
+// WithEndDate sets EndDate from t, formatted in UTC, and returns o for chaining
+func (o *MemoryOptions) WithEndDate(t time.Time) *MemoryOptions {
+	date := t.UTC().Format(DateFormat)
+	o.EndDate = &date
+	return o
+}
+
+// WithDateRange sets StartDate and EndDate and returns o for chaining
+func (o *MemoryOptions) WithDateRange(start, end time.Time) *MemoryOptions {
+	return o.WithStartDate(start).WithEndDate(end)
+}
+
+// WithTimestamp sets Timestamp to t as Unix seconds and returns o for chaining
+func (o *MemoryOptions) WithTimestamp(t time.Time) *MemoryOptions {
+	timestamp := t.Unix()
+	o.Timestamp = &timestamp
+	return o
+}
+
+// WithExpiration sets ExpirationDate to the UTC date of t and returns o for
+// chaining. The platform expires memories at day granularity.
+func (o *MemoryOptions) WithExpiration(t time.Time) *MemoryOptions {
+	date := t.UTC().Format(time.DateOnly)
+	o.ExpirationDate = &date
+	return o
+}
+
+// WithTTL sets ExpirationDate to the date ttl from now and returns o for
+// chaining
+func (o *MemoryOptions) WithTTL(ttl time.Duration) *MemoryOptions {
+	return o.WithExpiration(time.Now().Add(ttl))
+}
+
+// parseDate parses a start_date/end_date value as RFC 3339 or YYYY-MM-DD
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range []string{DateFormat, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// WithAccurate enables reranking, keyword search and memory filtering for the
+// most relevant results at the cost of latency, and returns o for chaining
+func (o *SearchOptions) WithAccurate() *SearchOptions {
+	return o.withRetrieval(true)
+}
+
+// WithFast disables reranking, keyword search and memory filtering for the
+// lowest latency, and returns o for chaining
+func (o *SearchOptions) WithFast() *SearchOptions {
+	return o.withRetrieval(false)
+}
+
+// withRetrieval sets every advanced-retrieval flag to enabled
+func (o *SearchOptions) withRetrieval(enabled bool) *SearchOptions {
+	rerank, keyword, filter := enabled, enabled, enabled
+	o.Rerank = &rerank
+	o.KeywordSearch = &keyword
+	o.FilterMemories = &filter
+	return o
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDateHelpers(t *testing.T) {
+	start := time.Date(2024, 7, 1, 12, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+	end := start.Add(48 * time.Hour)
+
+	var opts SearchOptions
+	opts.WithDateRange(start, end).WithTimestamp(start)
+
+	if opts.StartDate == nil || *opts.StartDate != "2024-07-01T15:00:00Z" {
+		t.Errorf("StartDate = %v, want 2024-07-01T15:00:00Z", opts.StartDate)
+	}
+	if opts.EndDate == nil || *opts.EndDate != "2024-07-03T15:00:00Z" {
+		t.Errorf("EndDate = %v, want 2024-07-03T15:00:00Z", opts.EndDate)
+	}
+	if opts.Timestamp == nil || *opts.Timestamp != start.Unix() {
+		t.Errorf("Timestamp = %v, want %d", opts.Timestamp, start.Unix())
+	}
+	if err := opts.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestDatesAreSent(t *testing.T) {
+	opts := MemoryOptions{}
+	opts.WithTimestamp(time.Unix(1720000000, 0))
+
+	payload := (&MemoryClient{}).preparePayload(nil, opts)
+	if payload["timestamp"] != int64(1720000000) {
+		t.Errorf("Add payload timestamp = %v, want 1720000000", payload["timestamp"])
+	}
+
+	search := SearchOptions{}
+	search.WithStartDate(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))
+	searchPayload := map[string]interface{}{}
+	addSearchOptionsToPayload(searchPayload, search)
+	if searchPayload["start_date"] != "2024-07-01T00:00:00Z" {
+		t.Errorf("Search payload start_date = %v", searchPayload["start_date"])
+	}
+	if _, ok := searchPayload["end_date"]; ok {
+		t.Error("Search payload should omit unset end_date")
+	}
+}
+
+func TestDateFiltersForGetAll(t *testing.T) {
+	opts := MemoryOptions{UserID: stringPtr("alex")}
+	opts.WithDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC))
+
+	params := (&MemoryClient{}).prepareParams(opts)
+	if params.Get("start_date") != "2024-07-01T00:00:00Z" || params.Get("end_date") != "2024-07-08T00:00:00Z" {
+		t.Errorf("v1 params = %v, want start_date and end_date", params)
+	}
+
+	filters := v2Filters(opts)
+	want := map[string]interface{}{"AND": []interface{}{
+		map[string]interface{}{"user_id": "alex"},
+		map[string]interface{}{"created_at": map[string]interface{}{"gte": "2024-07-01T00:00:00Z", "lte": "2024-07-08T00:00:00Z"}},
+	}}
+	if !reflect.DeepEqual(filters, want) {
+		t.Errorf("v2Filters() = %v, want %v", filters, want)
+	}
+}
+
+func TestDateValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		end      string
+		errField string
+	}{
+		{name: "date only", start: "2024-07-01", end: "2024-07-02"},
+		{name: "rfc3339", start: "2024-07-01T10:00:00Z", end: "2024-07-01T11:00:00+00:00"},
+		{name: "unparseable start", start: "last week", errField: "start_date"},
+		{name: "end before start", start: "2024-07-02", end: "2024-07-01", errField: "end_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := MemoryOptions{}
+			if tt.start != "" {
+				opts.StartDate = &tt.start
+			}
+			if tt.end != "" {
+				opts.EndDate = &tt.end
+			}
+
+			err := opts.Validate()
+			if tt.errField == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != tt.errField {
+				t.Errorf("Validate() error = %v, want field %s", err, tt.errField)
+			}
+		})
+	}
+}
+
+func TestFilterMemoriesIsSent(t *testing.T) {
+	search := SearchOptions{}
+	search.WithAccurate()
+	payload := map[string]interface{}{}
+	addSearchOptionsToPayload(payload, search)
+	for _, key := range []string{"rerank", "keyword_search", "filter_memories"} {
+		if payload[key] != true {
+			t.Errorf("payload[%q] = %v, want true", key, payload[key])
+		}
+	}
+}
+
+func TestExpirationHelpers(t *testing.T) {
+	opts := MemoryOptions{}
+	opts.WithExpiration(time.Date(2024, 7, 1, 23, 30, 0, 0, time.FixedZone("BRT", -3*60*60)))
+	if opts.ExpirationDate == nil || *opts.ExpirationDate != "2024-07-02" {
+		t.Errorf("ExpirationDate = %v, want 2024-07-02", opts.ExpirationDate)
+	}
+	if payload := (&MemoryClient{}).preparePayload(nil, opts); payload["expiration_date"] != "2024-07-02" {
+		t.Errorf("Add payload expiration_date = %v", payload["expiration_date"])
+	}
+
+	opts.WithTTL(72 * time.Hour)
+	if want := time.Now().UTC().Add(72 * time.Hour).Format(time.DateOnly); *opts.ExpirationDate != want {
+		t.Errorf("WithTTL ExpirationDate = %s, want %s", *opts.ExpirationDate, want)
+	}
+	if err := opts.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	bad := "2024-07-02T00:00:00Z"
+	if err := (MemoryOptions{ExpirationDate: &bad}).Validate(); err == nil {
+		t.Error("Validate() accepted a timestamp as expiration_date")
+	}
+
+	only, include := true, false
+	if err := (SearchOptions{ExpiredOnly: &only, IncludeExpired: &include}).Validate(); err == nil {
+		t.Error("Validate() accepted expired_only with include_expired false")
+	}
+	payload := map[string]interface{}{}
+	addSearchOptionsToPayload(payload, SearchOptions{ExpiredOnly: &only})
+	if payload["expired_only"] != true {
+		t.Errorf("Search payload expired_only = %v", payload["expired_only"])
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// encodeParams converts a struct of options to URL parameters. Each field is
+// named by its url tag, or its json tag when it has none; "-" skips it. Nil
+// pointers, zero values and empty slices are omitted, slices are joined with
+// commas, and maps are skipped since they only travel in request bodies.
+// Fields of embedded structs are flattened; a field of the outer struct wins
+// over an embedded one of the same name.
+func encodeParams(options interface{}) url.Values {
+	params := url.Values{}
+	v := reflect.ValueOf(options)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return params
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		addStructParams(params, v)
+	}
+	return params
+}
+
+// addStructParams adds the fields of v not already in params, then those of
+// its embedded structs
+func addStructParams(params url.Values, v reflect.Value) {
+	var embedded []reflect.Value
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, v.Field(i))
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name := paramName(field)
+		if name == "" || params.Has(name) {
+			continue
+		}
+		if value, ok := paramValue(v.Field(i)); ok {
+			params.Set(name, value)
+		}
+	}
+	for _, inner := range embedded {
+		addStructParams(params, inner)
+	}
+}
+
+// paramName returns the parameter name of field, or "" to skip it
+func paramName(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("url")
+	if !ok {
+		tag = field.Tag.Get("json")
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// paramValue formats v as a parameter value and reports whether it is set
+func paramValue(v reflect.Value) (string, bool) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		if !v.CanInterface() {
+			return formatParam(v.Elem())
+		}
+		return fmt.Sprintf("%v", v.Interface()), true
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", false
+		}
+		return formatParam(v.Elem())
+	}
+	if v.IsZero() {
+		return "", false
+	}
+	return formatParam(v)
+}
+
+// formatParam formats a scalar or a slice of scalars
+func formatParam(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return "", false
+		}
+		values := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			value, ok := formatParam(v.Index(i))
+			if !ok {
+				return "", false
+			}
+			values = append(values, value)
+		}
+		return strings.Join(values, ","), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeParams(t *testing.T) {
+	type inner struct {
+		Name  *string `json:"name,omitempty"`
+		Limit *int    `json:"limit,omitempty"`
+	}
+	type options struct {
+		inner
+		Limit   *int                   `json:"limit,omitempty"`
+		Tags    []string               `json:"tags,omitempty"`
+		Query   string                 `json:"query" url:"q"`
+		Secret  *string                `json:"secret,omitempty" url:"-"`
+		Extra   map[string]interface{} `json:"extra,omitempty"`
+		Count   int                    `json:"count"`
+		Scope   interface{}            `json:"scope,omitempty"`
+		Enabled *bool                  `json:"enabled,omitempty"`
+	}
+
+	got := encodeParams(&options{
+		inner:   inner{Name: stringPtr("alex"), Limit: intPtr(1)},
+		Limit:   intPtr(10),
+		Tags:    []string{"a", "b"},
+		Query:   "tea",
+		Secret:  stringPtr("hidden"),
+		Extra:   map[string]interface{}{"k": "v"},
+		Scope:   7,
+		Enabled: boolPtr(false),
+	})
+	want := url.Values{
+		"name":    {"alex"},
+		"limit":   {"10"},
+		"tags":    {"a,b"},
+		"q":       {"tea"},
+		"scope":   {"7"},
+		"enabled": {"false"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("encodeParams() = %v, want %v", got, want)
+	}
+
+	if got := encodeParams((*options)(nil)); len(got) != 0 {
+		t.Errorf("encodeParams(nil) = %v, want no parameters", got)
+	}
+}
+
+// TestEveryOptionIsSent guards against options that exist but are never
+// sent: each SearchOptions field must become a parameter unless it is tagged
+// url:"-" or only fits in a request body
+func TestEveryOptionIsSent(t *testing.T) {
+	var check func(typ reflect.Type, path string)
+	check = func(typ reflect.Type, path string) {
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.Anonymous {
+				check(field.Type, path+field.Name+".")
+				continue
+			}
+			name := paramName(field)
+			if name == "" || field.Type.Kind() == reflect.Map ||
+				(field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Map) {
+				continue
+			}
+
+			var options SearchOptions
+			value := reflect.ValueOf(&options).Elem().FieldByName(field.Name)
+			switch field.Type.Kind() {
+			case reflect.Pointer:
+				value.Set(reflect.New(field.Type.Elem()))
+			case reflect.Slice:
+				value.Set(reflect.Append(value, reflect.New(field.Type.Elem()).Elem()))
+			case reflect.Interface:
+				value.Set(reflect.ValueOf("x"))
+			}
+			if !encodeParams(options).Has(name) {
+				t.Errorf("%s%s is never sent as %q", path, field.Name, name)
+			}
+		}
+	}
+	check(reflect.TypeOf(SearchOptions{}), "SearchOptions.")
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// MaxPatchAttempts is the number of times PatchMetadata rereads a memory
+// that changed under it before giving up with ErrMetadataConflict
+const MaxPatchAttempts = 3
+
+// PatchMetadata merges patch into the memory's metadata, so callers can tag
+// a memory without clobbering keys set by others. A nil value removes the
+// key. The API only replaces metadata wholesale, so the merge is a
+// read-modify-write: the write is made with WithIfMatchHash on the hash that
+// was read, and the memory is read and merged again when the write fails
+// with a *ConflictError.
+func (c *MemoryClient) PatchMetadata(ctx context.Context, memoryID string, patch map[string]interface{}) ([]Memory, error) {
+	if len(patch) == 0 {
+		return nil, NewValidationError("metadata", "patch is empty")
+	}
+
+	for attempt := 0; attempt < MaxPatchAttempts; attempt++ {
+		current, err := c.Get(ctx, memoryID)
+		if err != nil {
+			return nil, err
+		}
+		metadata := map[string]interface{}{}
+		if existing, ok := current.Metadata.(map[string]interface{}); ok {
+			for key, value := range existing {
+				metadata[key] = value
+			}
+		}
+		for key, value := range patch {
+			if value == nil {
+				delete(metadata, key)
+			} else {
+				metadata[key] = value
+			}
+		}
+
+		writeCtx := WithIdempotencySubKey(WithIfMatchHash(ctx, stringValue(current.Hash)), strconv.Itoa(attempt))
+		memories, err := c.UpdateMemory(writeCtx, memoryID, UpdateRequest{Metadata: metadata})
+		var conflict *ConflictError
+		if errors.As(err, &conflict) {
+			continue
+		}
+		return memories, err
+	}
+	return nil, fmt.Errorf("memory %s: %w", memoryID, ErrMetadataConflict)
+}
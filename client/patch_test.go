@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+// newPatchServer serves one memory whose hash and metadata gain a new
+// revision on every read while changing is set, and records the metadata
+// written to it. The first rejected writes that carry If-Match fail with 412.
+func newPatchServer(t *testing.T, changing bool, rejected int32) (*MemoryClient, *atomic.Int32, *map[string]interface{}) {
+	t.Helper()
+
+	var reads, rejections atomic.Int32
+	var written map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/ping/":
+			w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1","user_email":"test@example.com"}`))
+		case r.Method == http.MethodGet:
+			revision := 1
+			if changing {
+				revision = int(reads.Add(1))
+			}
+			fmt.Fprintf(w, `{"id":"mem-1","memory":"Likes tea","hash":"h%d","metadata":{"source":"chat","stale":true,"revision":%d}}`, revision, revision)
+		case r.Method == http.MethodPut:
+			if r.Header.Get(IfMatchHeader) == "" {
+				t.Error("PUT without If-Match")
+			}
+			if rejections.Add(1) <= rejected {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			var body struct {
+				Metadata map[string]interface{} `json:"metadata"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			written = body.Metadata
+			w.Write([]byte(`[{"id":"mem-1","memory":"Likes tea"}]`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &srv.URL})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	return client, &reads, &written
+}
+
+func TestPatchMetadata(t *testing.T) {
+	client, _, written := newPatchServer(t, false, 0)
+
+	if _, err := client.PatchMetadata(context.Background(), "mem-1", map[string]interface{}{"reviewed": true, "stale": nil}); err != nil {
+		t.Fatalf("PatchMetadata() error = %v", err)
+	}
+	want := map[string]interface{}{"source": "chat", "revision": float64(1), "reviewed": true}
+	if !reflect.DeepEqual(*written, want) {
+		t.Errorf("written metadata = %v, want %v", *written, want)
+	}
+}
+
+func TestPatchMetadataConflict(t *testing.T) {
+	client, reads, written := newPatchServer(t, true, 0)
+
+	_, err := client.PatchMetadata(context.Background(), "mem-1", map[string]interface{}{"reviewed": true})
+	if !errors.Is(err, ErrMetadataConflict) {
+		t.Fatalf("PatchMetadata() error = %v, want ErrMetadataConflict", err)
+	}
+	if *written != nil {
+		t.Errorf("metadata written despite conflict: %v", *written)
+	}
+	// Each attempt reads the memory, and UpdateMemory reads it again to
+	// compare hashes
+	if got := reads.Load(); got != 2*MaxPatchAttempts {
+		t.Errorf("reads = %d, want %d", got, 2*MaxPatchAttempts)
+	}
+
+	var validationErr *ValidationError
+	if _, err := client.PatchMetadata(context.Background(), "mem-1", nil); !errors.As(err, &validationErr) {
+		t.Errorf("PatchMetadata(nil) error = %v, want *ValidationError", err)
+	}
+}
+
+func TestPatchMetadataRetriesRejectedWrite(t *testing.T) {
+	client, _, written := newPatchServer(t, false, 1)
+
+	if _, err := client.PatchMetadata(context.Background(), "mem-1", map[string]interface{}{"reviewed": true}); err != nil {
+		t.Fatalf("PatchMetadata() error = %v", err)
+	}
+	if (*written)["reviewed"] != true {
+		t.Errorf("written metadata = %v, want the patch applied on retry", *written)
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// ForProject returns a client for another organization and project that
+// shares this client's HTTP client, and so its connection pool. Creating one
+// is cheap, so multi-tenant backends can derive a client per request.
+//
+// The derived client starts with this client's API key or TokenSource;
+// SetAPIKey on either client does not affect the other. ForProject is
+// shorthand for With(WithProject(orgID, projectID)).
+func (c *MemoryClient) ForProject(orgID, projectID interface{}) *MemoryClient {
+	return c.With(WithProject(orgID, projectID))
+}
+
+// projectPath returns the endpoint of the client's project
+func (c *MemoryClient) projectPath(ctx context.Context) (string, error) {
+	if err := c.authenticate(ctx); err != nil {
+		return "", err
+	}
+	orgID, projectID := c.scope()
+	if orgID == nil || projectID == nil {
+		return "", NewValidationError("project_id", "organization and project IDs are required for project settings")
+	}
+	return fmt.Sprintf("/api/v1/orgs/organizations/%v/projects/%v/", orgID, projectID), nil
+}
+
+// UpdateProject changes the project's custom instructions and categories.
+// Fields left nil are not changed; Additional carries any other project
+// settings.
+func (c *MemoryClient) UpdateProject(ctx context.Context, payload PromptUpdatePayload) (*MessageResponse, error) {
+	if payload.CustomInstructions != nil {
+		if err := validateCustomInstructions(*payload.CustomInstructions); err != nil {
+			return nil, err
+		}
+	}
+
+	path, err := c.projectPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{}
+	for key, value := range payload.Additional {
+		body[key] = value
+	}
+	if payload.CustomInstructions != nil {
+		body["custom_instructions"] = *payload.CustomInstructions
+	}
+	if payload.CustomCategories != nil {
+		body["custom_categories"] = payload.CustomCategories
+	}
+
+	var result MessageResponse
+	if err := c.fetchInto(ctx, "PATCH", path, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
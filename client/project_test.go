@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestForProject(t *testing.T) {
+	parent, captured := newStubClient(t, 200, `[]`)
+	ctx := context.Background()
+
+	tenant := parent.ForProject("org-2", "proj-2")
+	if tenant.httpClient != parent.httpClient {
+		t.Error("derived client does not share the HTTP client")
+	}
+
+	if _, err := tenant.Search(ctx, "tea", SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}}); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if captured.Body["org_id"] != "org-2" || captured.Body["project_id"] != "proj-2" {
+		t.Errorf("derived Search() scope = %v/%v, want org-2/proj-2", captured.Body["org_id"], captured.Body["project_id"])
+	}
+
+	if _, err := parent.Search(ctx, "tea", SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}}); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if captured.Body["org_id"] != "org-1" || captured.Body["project_id"] != "proj-1" {
+		t.Errorf("parent Search() scope = %v/%v, want org-1/proj-1", captured.Body["org_id"], captured.Body["project_id"])
+	}
+
+	tenant.ForceReauth()
+	if orgID, projectID := tenant.scope(); orgID != "org-2" || projectID != "proj-2" {
+		t.Errorf("scope after ForceReauth = %v/%v, want org-2/proj-2", orgID, projectID)
+	}
+}
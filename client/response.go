@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RequestIDHeader is the header carrying the correlation ID of a request
+const RequestIDHeader = "X-Request-Id"
+
+type requestIDContext struct{}
+
+type responseContext struct{}
+
+// Response describes the HTTP exchange behind a call
+type Response struct {
+	RequestID  string      // Correlation ID sent, or the one echoed by the API
+	StatusCode int         // Zero when no response was received
+	Header     http.Header // Response headers
+	RateLimit  *RateLimit  // Nil when the API sent no rate limit headers
+	Body       []byte      // Raw response body
+}
+
+// RateLimit holds the rate limit headers of a response. Fields whose header
+// is missing or malformed are zero.
+type RateLimit struct {
+	Limit      int           // X-RateLimit-Limit
+	Remaining  int           // X-RateLimit-Remaining
+	Reset      time.Time     // X-RateLimit-Reset, as seconds from now or a Unix time
+	RetryAfter time.Duration // Retry-After, in seconds
+}
+
+// WithRequestID returns a context whose calls send id in the X-Request-Id
+// header. Without one, every request gets a random ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContext{}, id)
+}
+
+// RequestID returns the ID set on ctx with WithRequestID
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContext{}).(string)
+	return id, ok && id != ""
+}
+
+// CaptureResponse returns a context that records the outcome of the calls
+// made with it into response. Calls issuing several requests record the
+// last one. The context must not be shared by concurrent calls.
+func CaptureResponse(ctx context.Context, response *Response) context.Context {
+	return context.WithValue(ctx, responseContext{}, response)
+}
+
+// capturedResponse returns the Response registered with CaptureResponse
+func capturedResponse(ctx context.Context) *Response {
+	response, _ := ctx.Value(responseContext{}).(*Response)
+	return response
+}
+
+// parseRateLimit reads the rate limit headers. Reset values larger than a
+// year are taken as Unix times, smaller ones as seconds from now.
+func parseRateLimit(header http.Header, now time.Time) *RateLimit {
+	limit, hasLimit := headerInt(header, "X-RateLimit-Limit")
+	remaining, hasRemaining := headerInt(header, "X-RateLimit-Remaining")
+	reset, hasReset := headerInt(header, "X-RateLimit-Reset")
+	retryAfter, hasRetryAfter := headerInt(header, "Retry-After")
+	if !hasLimit && !hasRemaining && !hasReset && !hasRetryAfter {
+		return nil
+	}
+
+	rateLimit := &RateLimit{
+		Limit:      limit,
+		Remaining:  remaining,
+		RetryAfter: time.Duration(retryAfter) * time.Second,
+	}
+	if hasReset {
+		if reset > 365*24*60*60 {
+			rateLimit.Reset = time.Unix(int64(reset), 0)
+		} else {
+			rateLimit.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	return rateLimit
+}
+
+// headerInt parses a non-negative integer header
+func headerInt(header http.Header, name string) (int, bool) {
+	value, err := strconv.Atoi(header.Get(name))
+	if err != nil || value < 0 {
+		return 0, false
+	}
+	return value, true
+}
+
+// AddWithResponse is Add that also returns the HTTP response
+func (c *MemoryClient) AddWithResponse(ctx context.Context, messages []Message, options ...MemoryOptions) ([]Memory, *Response, error) {
+	response := &Response{}
+	memories, err := c.Add(CaptureResponse(ctx, response), messages, options...)
+	return memories, response, err
+}
+
+// UpdateWithResponse is Update that also returns the HTTP response
+func (c *MemoryClient) UpdateWithResponse(ctx context.Context, memoryID, message string) ([]Memory, *Response, error) {
+	response := &Response{}
+	memories, err := c.Update(CaptureResponse(ctx, response), memoryID, message)
+	return memories, response, err
+}
+
+// GetWithResponse is Get that also returns the HTTP response
+func (c *MemoryClient) GetWithResponse(ctx context.Context, memoryID string) (*Memory, *Response, error) {
+	response := &Response{}
+	memory, err := c.Get(CaptureResponse(ctx, response), memoryID)
+	return memory, response, err
+}
+
+// GetAllWithResponse is GetAll that also returns the HTTP response
+func (c *MemoryClient) GetAllWithResponse(ctx context.Context, options ...SearchOptions) ([]Memory, *Response, error) {
+	response := &Response{}
+	memories, err := c.GetAll(CaptureResponse(ctx, response), options...)
+	return memories, response, err
+}
+
+// SearchWithResponse is Search that also returns the HTTP response
+func (c *MemoryClient) SearchWithResponse(ctx context.Context, query string, options ...SearchOptions) ([]Memory, *Response, error) {
+	response := &Response{}
+	memories, err := c.Search(CaptureResponse(ctx, response), query, options...)
+	return memories, response, err
+}
+
+// DeleteWithResponse is Delete that also returns the HTTP response
+func (c *MemoryClient) DeleteWithResponse(ctx context.Context, memoryID string) (*MessageResponse, *Response, error) {
+	response := &Response{}
+	result, err := c.Delete(CaptureResponse(ctx, response), memoryID)
+	return result, response, err
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestIDHeader(t *testing.T) {
+	client, captured := newStubClient(t, http.StatusOK, `[]`)
+	options := SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alice")}}
+
+	var response Response
+	if _, err := client.Search(CaptureResponse(context.Background(), &response), "tea", options); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	generated := captured.Header.Get(RequestIDHeader)
+	if !uuidPattern.MatchString(generated) {
+		t.Errorf("generated request ID = %q, want a v4 UUID", generated)
+	}
+	if response.RequestID != generated || response.StatusCode != http.StatusOK {
+		t.Errorf("captured response = %+v, want request ID %s and status 200", response, generated)
+	}
+
+	ctx := WithRequestID(context.Background(), "support-123")
+	if _, err := client.Search(ctx, "tea", options); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got := captured.Header.Get(RequestIDHeader); got != "support-123" {
+		t.Errorf("request ID = %q, want support-123", got)
+	}
+}
+
+func TestRequestIDInErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/ping/" {
+			w.Write([]byte(`{"status":"ok","user_email":"test@example.com"}`))
+			return
+		}
+		w.Header().Set(RequestIDHeader, "upstream-9")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"detail":"boom"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &srv.URL})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+
+	var response Response
+	ctx := CaptureResponse(WithRequestID(context.Background(), "local-1"), &response)
+	_, err = client.Get(ctx, "mem-1")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Get() error = %v, want *APIError", err)
+	}
+	if apiErr.RequestID != "upstream-9" || !strings.Contains(err.Error(), "request id upstream-9") {
+		t.Errorf("APIError = %v, want echoed request ID upstream-9", err)
+	}
+	if response.RequestID != "upstream-9" || response.StatusCode != http.StatusInternalServerError {
+		t.Errorf("captured response = %+v", response)
+	}
+}
+
+func TestWithResponseVariants(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/ping/" {
+			w.Write([]byte(`{"status":"ok","user_email":"test@example.com"}`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "30")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"detail":"slow down"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &srv.URL})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+
+	_, response, err := client.SearchWithResponse(context.Background(), "tea", SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alice")}})
+	if err == nil {
+		t.Fatal("SearchWithResponse() expected error")
+	}
+	if response.StatusCode != http.StatusTooManyRequests || string(response.Body) != `{"detail":"slow down"}` {
+		t.Errorf("response = %d %s", response.StatusCode, response.Body)
+	}
+	if response.Header.Get("Retry-After") != "30" {
+		t.Errorf("Header = %v, want Retry-After", response.Header)
+	}
+	if rl := response.RateLimit; rl == nil || rl.Limit != 100 || rl.Remaining != 0 || rl.RetryAfter.Seconds() != 30 || rl.Reset.IsZero() {
+		t.Errorf("RateLimit = %+v", rl)
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	if rl := parseRateLimit(http.Header{}, now); rl != nil {
+		t.Errorf("parseRateLimit(no headers) = %+v, want nil", rl)
+	}
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Reset", "1700000060")
+	if rl := parseRateLimit(header, now); rl == nil || !rl.Reset.Equal(now.Add(time.Minute)) {
+		t.Errorf("parseRateLimit(unix reset) = %+v", rl)
+	}
+
+	header.Set("X-RateLimit-Reset", "60")
+	if rl := parseRateLimit(header, now); rl == nil || !rl.Reset.Equal(now.Add(time.Minute)) {
+		t.Errorf("parseRateLimit(relative reset) = %+v", rl)
+	}
+}
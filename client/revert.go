@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Metadata keys RevertMemory sets on the restored memory
+const (
+	MetadataRevertedFrom = "reverted_from_history_id"
+	MetadataRevertedAt   = "reverted_at"
+)
+
+// RevertMemory undoes the change recorded by the history entry historyID,
+// restoring the text the memory had before it. The memory's metadata is kept
+// and tagged with MetadataRevertedFrom and MetadataRevertedAt.
+func (c *MemoryClient) RevertMemory(ctx context.Context, memoryID, historyID string) ([]Memory, error) {
+	history, err := c.History(ctx, memoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry *MemoryHistory
+	for i := range history {
+		if history[i].ID == historyID {
+			entry = &history[i]
+			break
+		}
+	}
+	if entry == nil {
+		return nil, NewValidationError("history_id", fmt.Sprintf("history entry %s not found for memory %s", historyID, memoryID))
+	}
+	if entry.OldMemory == nil || *entry.OldMemory == "" {
+		return nil, NewValidationError("history_id", fmt.Sprintf("%s event %s has no previous text to restore", entry.Event, historyID))
+	}
+
+	memory, err := c.Get(ctx, memoryID)
+	if err != nil {
+		return nil, err
+	}
+	metadata := map[string]interface{}{}
+	if existing, ok := memory.Metadata.(map[string]interface{}); ok {
+		for key, value := range existing {
+			metadata[key] = value
+		}
+	}
+	metadata[MetadataRevertedFrom] = historyID
+	metadata[MetadataRevertedAt] = time.Now().UTC().Format(time.RFC3339)
+
+	return c.UpdateMemory(WithIdempotencySubKey(ctx, "revert-"+historyID), memoryID, UpdateRequest{Text: entry.OldMemory, Metadata: metadata})
+}
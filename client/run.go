@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Run scopes calls to one session of a user, stamping a generated run_id on
+// every memory it adds and searches
+type Run struct {
+	client Client
+	UserID string
+	ID     string // Generated run_id
+}
+
+// EndRunOptions configures EndRun
+type EndRunOptions struct {
+	Summarize *SummarizeOptions // Optional: summarize the run into the user's memories outside the run
+	Expire    bool              // Delete the run's memories once summarized
+}
+
+// NewRun starts a run for userID with a new run_id
+func NewRun(c Client, userID string) *Run {
+	return &Run{client: c, UserID: userID, ID: newUUID()}
+}
+
+// Add creates memories in the run
+func (r *Run) Add(ctx context.Context, messages []Message, options ...MemoryOptions) ([]Memory, error) {
+	opts := MemoryOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if err := r.scope(&opts); err != nil {
+		return nil, err
+	}
+	return r.client.Add(ctx, messages, opts)
+}
+
+// Search searches the memories of the run
+func (r *Run) Search(ctx context.Context, query string, options ...SearchOptions) ([]Memory, error) {
+	opts := SearchOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if err := r.scope(&opts.MemoryOptions); err != nil {
+		return nil, err
+	}
+	return r.client.Search(ctx, query, opts)
+}
+
+// GetAll lists the memories of the run
+func (r *Run) GetAll(ctx context.Context, options ...SearchOptions) ([]Memory, error) {
+	opts := SearchOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if err := r.scope(&opts.MemoryOptions); err != nil {
+		return nil, err
+	}
+	return r.client.GetAll(ctx, opts)
+}
+
+// EndRun finishes the run. With Summarize set, the run's memories are
+// condensed and the summary is added to the user's memories without the
+// run_id, so it outlives the run; the summary is returned. With Expire set,
+// the run's memories are then deleted.
+func (r *Run) EndRun(ctx context.Context, options ...EndRunOptions) (string, error) {
+	opts := EndRunOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	var summary string
+	if opts.Summarize != nil {
+		summarizeOptions := *opts.Summarize
+		if err := r.scope(&summarizeOptions.MemoryOptions); err != nil {
+			return "", err
+		}
+		var err error
+		summary, err = Summarize(ctx, r.client, summarizeOptions)
+		if err != nil {
+			return "", err
+		}
+		if summary != "" {
+			infer := false
+			_, err := r.client.Add(ctx, []Message{{Role: "user", Content: summary}}, MemoryOptions{
+				UserID:   &r.UserID,
+				Infer:    &infer,
+				Metadata: map[string]interface{}{"run_id": r.ID, "type": "run_summary"},
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if opts.Expire {
+		deleteOptions := MemoryOptions{}
+		if err := r.scope(&deleteOptions); err != nil {
+			return "", err
+		}
+		if _, err := r.client.DeleteAll(ctx, deleteOptions); err != nil {
+			return summary, err
+		}
+	}
+	return summary, nil
+}
+
+// scope sets the run's user_id and run_id on options. IDs the caller set
+// must match the run's, since the call would otherwise silently act on
+// another user or run.
+func (r *Run) scope(options *MemoryOptions) error {
+	if r.UserID == "" {
+		return NewValidationError("user_id", "user ID is required")
+	}
+	if options.UserID != nil && *options.UserID != r.UserID {
+		return NewValidationError("user_id", fmt.Sprintf("%q is outside the scope of run %s of user %s", *options.UserID, r.ID, r.UserID))
+	}
+	if options.RunID != nil && *options.RunID != r.ID {
+		return NewValidationError("run_id", fmt.Sprintf("%q is outside the scope of run %s", *options.RunID, r.ID))
+	}
+	options.UserID = &r.UserID
+	options.RunID = &r.ID
+	return nil
+}
@@ -0,0 +1,99 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+func TestRunScopesCalls(t *testing.T) {
+	mock := &clienttest.MockClient{}
+	run := client.NewRun(mock, "alice")
+	other := client.NewRun(mock, "alice")
+	if run.ID == "" || run.ID == other.ID {
+		t.Fatalf("run IDs = %q, %q, want distinct generated IDs", run.ID, other.ID)
+	}
+
+	ctx := context.Background()
+	run.Add(ctx, []client.Message{{Role: "user", Content: "hi"}}, client.MemoryOptions{Metadata: map[string]interface{}{"k": "v"}})
+	run.Search(ctx, "hi")
+	run.GetAll(ctx)
+
+	add := mock.CallsTo("Add")[0].Args[1].([]client.MemoryOptions)[0]
+	if *add.UserID != "alice" || *add.RunID != run.ID || add.Metadata["k"] != "v" {
+		t.Errorf("Add options = %+v", add)
+	}
+	for _, method := range []string{"Search", "GetAll"} {
+		calls := mock.CallsTo(method)
+		options := calls[0].Args[len(calls[0].Args)-1].([]client.SearchOptions)[0]
+		if *options.UserID != "alice" || *options.RunID != run.ID {
+			t.Errorf("%s options = %+v", method, options)
+		}
+	}
+
+	if _, err := client.NewRun(mock, "").Add(ctx, nil); err == nil {
+		t.Error("Add() without user ID expected error")
+	}
+}
+
+func TestRunRejectsOtherIDs(t *testing.T) {
+	mock := &clienttest.MockClient{}
+	run := client.NewRun(mock, "alice")
+	ctx := context.Background()
+
+	var validationErr *client.ValidationError
+	_, err := run.Add(ctx, []client.Message{{Role: "user", Content: "hi"}}, client.MemoryOptions{UserID: strPtr("bob")})
+	if !errors.As(err, &validationErr) || validationErr.Field != "user_id" {
+		t.Errorf("Add() for another user error = %v, want a user_id ValidationError", err)
+	}
+	_, err = run.Search(ctx, "hi", client.SearchOptions{MemoryOptions: client.MemoryOptions{RunID: strPtr("other-run")}})
+	if !errors.As(err, &validationErr) || validationErr.Field != "run_id" {
+		t.Errorf("Search() in another run error = %v, want a run_id ValidationError", err)
+	}
+	if calls := mock.Calls(); len(calls) != 0 {
+		t.Errorf("calls = %v, want none to reach the client", calls)
+	}
+
+	if _, err := run.GetAll(ctx, client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: strPtr("alice"), RunID: &run.ID}}); err != nil {
+		t.Errorf("GetAll() with the run's own IDs error = %v", err)
+	}
+}
+
+func TestEndRun(t *testing.T) {
+	mock := &clienttest.MockClient{
+		GetAllFunc: func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+			return []client.Memory{{ID: "m1", Memory: strPtr("Booked a flight to Lisbon")}}, nil
+		},
+	}
+	run := client.NewRun(mock, "alice")
+
+	summary, err := run.EndRun(context.Background(), client.EndRunOptions{Summarize: &client.SummarizeOptions{}, Expire: true})
+	if err != nil {
+		t.Fatalf("EndRun() error = %v", err)
+	}
+	if summary != "- Booked a flight to Lisbon" {
+		t.Errorf("summary = %q", summary)
+	}
+
+	add := mock.CallsTo("Add")
+	if len(add) != 1 {
+		t.Fatalf("Add calls = %d, want 1", len(add))
+	}
+	options := add[0].Args[1].([]client.MemoryOptions)[0]
+	if options.RunID != nil || *options.UserID != "alice" || *options.Infer || options.Metadata["run_id"] != run.ID {
+		t.Errorf("summary Add options = %+v, want user-scoped without run_id", options)
+	}
+
+	deleteAll := mock.CallsTo("DeleteAll")
+	if len(deleteAll) != 1 || *deleteAll[0].Args[0].([]client.MemoryOptions)[0].RunID != run.ID {
+		t.Errorf("DeleteAll calls = %+v, want run-scoped delete", deleteAll)
+	}
+
+	mock.Reset()
+	if summary, err := run.EndRun(context.Background()); err != nil || summary != "" || len(mock.Calls()) != 0 {
+		t.Errorf("EndRun() without options = %q, %v, calls %v", summary, err, mock.Calls())
+	}
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Entity types a Scope can be bound to
+const (
+	EntityUser  = "user"
+	EntityAgent = "agent"
+	EntityApp   = "app"
+	EntityRun   = "run"
+)
+
+// Scope is a view of a client restricted to one user, agent, app or run. It
+// sets the entity ID on every call and rejects options naming another
+// entity of the same type, so calls cannot reach someone else's memories.
+type Scope struct {
+	client Client
+	Entity string // EntityUser, EntityAgent, EntityApp or EntityRun
+	ID     string
+}
+
+// ForUser scopes c to the memories of userID
+func ForUser(c Client, userID string) *Scope {
+	return &Scope{client: c, Entity: EntityUser, ID: userID}
+}
+
+// ForAgent scopes c to the memories of agentID
+func ForAgent(c Client, agentID string) *Scope {
+	return &Scope{client: c, Entity: EntityAgent, ID: agentID}
+}
+
+// ForApp scopes c to the memories of appID
+func ForApp(c Client, appID string) *Scope {
+	return &Scope{client: c, Entity: EntityApp, ID: appID}
+}
+
+// ForRun scopes c to the memories of runID
+func ForRun(c Client, runID string) *Scope {
+	return &Scope{client: c, Entity: EntityRun, ID: runID}
+}
+
+// Add creates memories for the scope's entity
+func (s *Scope) Add(ctx context.Context, messages []Message, options ...MemoryOptions) ([]Memory, error) {
+	opts := MemoryOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if err := s.scope(&opts); err != nil {
+		return nil, err
+	}
+	return s.client.Add(ctx, messages, opts)
+}
+
+// Search searches the memories of the scope's entity
+func (s *Scope) Search(ctx context.Context, query string, options ...SearchOptions) ([]Memory, error) {
+	opts := SearchOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if err := s.scope(&opts.MemoryOptions); err != nil {
+		return nil, err
+	}
+	return s.client.Search(ctx, query, opts)
+}
+
+// GetAll lists the memories of the scope's entity
+func (s *Scope) GetAll(ctx context.Context, options ...SearchOptions) ([]Memory, error) {
+	opts := SearchOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if err := s.scope(&opts.MemoryOptions); err != nil {
+		return nil, err
+	}
+	return s.client.GetAll(ctx, opts)
+}
+
+// Delete removes a memory after checking that it belongs to the scope's
+// entity
+func (s *Scope) Delete(ctx context.Context, memoryID string) (*MessageResponse, error) {
+	memory, err := s.client.Get(ctx, memoryID)
+	if err != nil {
+		return nil, err
+	}
+	if owner := s.owner(memory); owner == nil || *owner != s.ID {
+		return nil, NewValidationError("memory_id", fmt.Sprintf("memory %s does not belong to %s %s", memoryID, s.Entity, s.ID))
+	}
+	return s.client.Delete(ctx, memoryID)
+}
+
+// DeleteAll removes every memory of the scope's entity
+func (s *Scope) DeleteAll(ctx context.Context, options ...MemoryOptions) (*DeleteAllResult, error) {
+	opts := MemoryOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if err := s.scope(&opts); err != nil {
+		return nil, err
+	}
+	return s.client.DeleteAll(ctx, opts)
+}
+
+// scope sets the entity ID on options, rejecting a different ID of the same
+// entity type
+func (s *Scope) scope(options *MemoryOptions) error {
+	field := s.Entity + "_id"
+	if s.ID == "" {
+		return NewValidationError(field, fmt.Sprintf("%s ID is required", s.Entity))
+	}
+
+	var target **string
+	switch s.Entity {
+	case EntityUser:
+		target = &options.UserID
+	case EntityAgent:
+		target = &options.AgentID
+	case EntityApp:
+		target = &options.AppID
+	case EntityRun:
+		target = &options.RunID
+	default:
+		return NewValidationError("entity", fmt.Sprintf("unknown entity type %q", s.Entity))
+	}
+	if *target != nil && **target != s.ID {
+		return NewValidationError(field, fmt.Sprintf("%q is outside the scope of %s %s", **target, s.Entity, s.ID))
+	}
+	id := s.ID
+	*target = &id
+	return nil
+}
+
+// owner returns the memory's ID for the scope's entity type
+func (s *Scope) owner(memory *Memory) *string {
+	switch s.Entity {
+	case EntityUser:
+		return memory.UserID
+	case EntityAgent:
+		return memory.AgentID
+	case EntityApp:
+		return memory.AppID
+	case EntityRun:
+		return memory.RunID
+	}
+	return nil
+}
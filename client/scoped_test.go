@@ -0,0 +1,80 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+func TestScopeSetsEntity(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		scope func(c client.Client) *client.Scope
+		field func(o client.MemoryOptions) *string
+	}{
+		{name: "user", scope: func(c client.Client) *client.Scope { return client.ForUser(c, "alex") }, field: func(o client.MemoryOptions) *string { return o.UserID }},
+		{name: "agent", scope: func(c client.Client) *client.Scope { return client.ForAgent(c, "alex") }, field: func(o client.MemoryOptions) *string { return o.AgentID }},
+		{name: "app", scope: func(c client.Client) *client.Scope { return client.ForApp(c, "alex") }, field: func(o client.MemoryOptions) *string { return o.AppID }},
+		{name: "run", scope: func(c client.Client) *client.Scope { return client.ForRun(c, "alex") }, field: func(o client.MemoryOptions) *string { return o.RunID }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &clienttest.MockClient{}
+			scope := tt.scope(mock)
+
+			scope.Add(ctx, []client.Message{{Role: "user", Content: "hi"}})
+			scope.Search(ctx, "hi")
+			scope.GetAll(ctx)
+			scope.DeleteAll(ctx)
+
+			options := []client.MemoryOptions{
+				mock.CallsTo("Add")[0].Args[1].([]client.MemoryOptions)[0],
+				mock.CallsTo("Search")[0].Args[1].([]client.SearchOptions)[0].MemoryOptions,
+				mock.CallsTo("GetAll")[0].Args[0].([]client.SearchOptions)[0].MemoryOptions,
+				mock.CallsTo("DeleteAll")[0].Args[0].([]client.MemoryOptions)[0],
+			}
+			for i, o := range options {
+				if id := tt.field(o); id == nil || *id != "alex" {
+					t.Errorf("call %d options = %+v, want %s_id alex", i, o, tt.name)
+				}
+			}
+		})
+	}
+}
+
+func TestScopeRejectsOtherEntities(t *testing.T) {
+	ctx := context.Background()
+	mock := &clienttest.MockClient{
+		GetFunc: func(ctx context.Context, memoryID string) (*client.Memory, error) {
+			owner := map[string]string{"mem-alex": "alex", "mem-sam": "sam"}[memoryID]
+			return &client.Memory{ID: memoryID, UserID: &owner}, nil
+		},
+	}
+	alex := client.ForUser(mock, "alex")
+
+	var validationErr *client.ValidationError
+	if _, err := alex.Add(ctx, nil, client.MemoryOptions{UserID: strPtr("sam")}); !errors.As(err, &validationErr) {
+		t.Errorf("Add(sam) error = %v, want *ValidationError", err)
+	}
+	if _, err := alex.Delete(ctx, "mem-sam"); !errors.As(err, &validationErr) {
+		t.Errorf("Delete(mem-sam) error = %v, want *ValidationError", err)
+	}
+	if len(mock.CallsTo("Add")) != 0 || len(mock.CallsTo("Delete")) != 0 {
+		t.Error("out-of-scope calls reached the client")
+	}
+
+	if _, err := alex.Delete(ctx, "mem-alex"); err != nil {
+		t.Errorf("Delete(mem-alex) error = %v", err)
+	}
+	if _, err := alex.GetAll(ctx, client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: strPtr("alex"), AgentID: strPtr("bot")}}); err != nil {
+		t.Errorf("GetAll() with matching user error = %v", err)
+	}
+	if _, err := client.ForUser(mock, "").Search(ctx, "hi"); !errors.As(err, &validationErr) {
+		t.Errorf("Search() without ID error = %v, want *ValidationError", err)
+	}
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of this SDK
+const Version = "0.1.0"
+
+// ErrSDKTooOld is returned by CheckCompatibility when the server advertises a
+// minimum SDK version newer than Version
+var ErrSDKTooOld = errors.New("SDK version is older than the server supports")
+
+// SDKVersion returns the version of this SDK, as sent in the User-Agent
+func SDKVersion() string {
+	return Version
+}
+
+// CheckCompatibility pings the server and reports ErrSDKTooOld when it
+// advertises a minimum SDK version newer than Version. Servers that do not
+// advertise one, or advertise one that does not parse, are assumed to
+// support this SDK.
+func (c *MemoryClient) CheckCompatibility(ctx context.Context) error {
+	if err := c.Ping(ctx); err != nil {
+		return err
+	}
+	return c.compatibility()
+}
+
+// compatibility compares Version with the minimum SDK version recorded by the
+// last Ping
+func (c *MemoryClient) compatibility() error {
+	c.authMu.Lock()
+	minimum := c.minSDKVersion
+	c.authMu.Unlock()
+
+	if minimum == "" {
+		return nil
+	}
+	if older, ok := versionLess(Version, minimum); ok && older {
+		return fmt.Errorf("%w: server requires go-mem0 %s or later, this is %s", ErrSDKTooOld, strings.TrimPrefix(minimum, "v"), Version)
+	}
+	return nil
+}
+
+// versionLess reports whether semantic version a precedes b, comparing major,
+// minor and patch numbers only. ok is false when either does not parse.
+func versionLess(a, b string) (less, ok bool) {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	if !okA || !okB {
+		return false, false
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] < vb[i], true
+		}
+	}
+	return false, true
+}
+
+// parseVersion splits "v1.2.3", "1.2" or "1.2.3-rc.1" into major, minor and
+// patch, missing parts being zero
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	fields := strings.Split(version, ".")
+	if len(fields) > len(parts) {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
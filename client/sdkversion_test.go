@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVersionLess(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		less, ok bool
+	}{
+		{"0.1.0", "0.2.0", true, true},
+		{"0.2.0", "0.1.9", false, true},
+		{"1.0.0", "v1.0.0", false, true},
+		{"1.2", "1.2.1", true, true},
+		{"1.2.3-rc.1", "1.2.3", false, true},
+		{"0.10.0", "0.9.0", false, true},
+		{"0.1.0", "latest", false, false},
+		{"0.1.0", "1.2.3.4", false, false},
+	}
+	for _, tt := range tests {
+		less, ok := versionLess(tt.a, tt.b)
+		if less != tt.less || ok != tt.ok {
+			t.Errorf("versionLess(%q, %q) = %v, %v; want %v, %v", tt.a, tt.b, less, ok, tt.less, tt.ok)
+		}
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	minimum := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body := `{"status":"ok","org_id":"org-1","project_id":"proj-1"`
+		if minimum != "" {
+			body += `,"min_sdk_version":"` + minimum + `"`
+		}
+		w.Write([]byte(body + "}"))
+	}))
+	t.Cleanup(srv.Close)
+
+	host := srv.URL
+	c, err := NewMemoryClient(ClientOptions{APIKey: "test-api-key", Host: &host, CheckVersion: true})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if err := c.CheckCompatibility(ctx); err != nil {
+		t.Errorf("CheckCompatibility() without a minimum = %v, want nil", err)
+	}
+	minimum = SDKVersion()
+	if err := c.CheckCompatibility(ctx); err != nil {
+		t.Errorf("CheckCompatibility() at the minimum = %v, want nil", err)
+	}
+	minimum = "v99.0.0"
+	if err := c.CheckCompatibility(ctx); !errors.Is(err, ErrSDKTooOld) {
+		t.Errorf("CheckCompatibility() below the minimum = %v, want ErrSDKTooOld", err)
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// SearchAccurate searches with reranking, keyword search and memory filtering
+// enabled. Other fields of options are kept.
+func SearchAccurate(ctx context.Context, c Client, query string, options ...SearchOptions) ([]Memory, error) {
+	opts := SearchOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	return c.Search(ctx, query, *opts.WithAccurate())
+}
+
+// SearchFast searches with reranking, keyword search and memory filtering
+// disabled. Other fields of options are kept.
+func SearchFast(ctx context.Context, c Client, query string, options ...SearchOptions) ([]Memory, error) {
+	opts := SearchOptions{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	return c.Search(ctx, query, *opts.WithFast())
+}
+
+// SearchMany runs the same search for each user concurrently, limited by
+// opts, and merges the results ordered by descending score. Memories of users
+// whose search failed are omitted and the error joins those failures.
+func SearchMany(ctx context.Context, c Client, query string, userIDs []string, options SearchOptions, opts ...BatchOption) ([]Memory, error) {
+	results := make([][]Memory, len(userIDs))
+	errs := make([]error, len(userIDs))
+	runBatch(ctx, len(userIDs), newBatchConfig(opts), func(ctx context.Context, i int) {
+		userOptions := options
+		userOptions.UserID = &userIDs[i]
+		results[i], errs[i] = c.Search(ctx, query, userOptions)
+	}, func(i int, err error) {
+		errs[i] = err
+	})
+
+	var merged []Memory
+	var failures []error
+	for i, memories := range results {
+		if errs[i] != nil {
+			failures = append(failures, fmt.Errorf("user %s: %w", userIDs[i], errs[i]))
+			continue
+		}
+		merged = append(merged, memories...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return scoreOf(merged[i]) > scoreOf(merged[j])
+	})
+	return merged, errors.Join(failures...)
+}
+
+// scoreOf returns the search score of memory, zero when absent
+func scoreOf(memory Memory) float64 {
+	if memory.Score == nil {
+		return 0
+	}
+	return *memory.Score
+}
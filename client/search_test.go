@@ -0,0 +1,98 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+func TestSearchPresets(t *testing.T) {
+	tests := []struct {
+		name    string
+		search  func(context.Context, client.Client, string, ...client.SearchOptions) ([]client.Memory, error)
+		enabled bool
+	}{
+		{name: "accurate", search: client.SearchAccurate, enabled: true},
+		{name: "fast", search: client.SearchFast, enabled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got client.SearchOptions
+			mock := &clienttest.MockClient{
+				SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+					got = options[0]
+					return nil, nil
+				},
+			}
+
+			limit := 5
+			_, err := tt.search(context.Background(), mock, "diet", client.SearchOptions{
+				MemoryOptions: client.MemoryOptions{UserID: strPtr("alex")},
+				Limit:         &limit,
+				Rerank:        boolPtr(!tt.enabled),
+			})
+			if err != nil {
+				t.Fatalf("search error = %v", err)
+			}
+
+			for name, flag := range map[string]*bool{"Rerank": got.Rerank, "KeywordSearch": got.KeywordSearch, "FilterMemories": got.FilterMemories} {
+				if flag == nil || *flag != tt.enabled {
+					t.Errorf("%s = %v, want %v", name, flag, tt.enabled)
+				}
+			}
+			if got.UserID == nil || *got.UserID != "alex" || got.Limit == nil || *got.Limit != 5 {
+				t.Errorf("other options not kept: %+v", got)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestSearchMany(t *testing.T) {
+	scores := map[string][]float64{"alex": {0.5, 0.2}, "sam": {0.9}, "kim": nil}
+	mock := &clienttest.MockClient{
+		SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+			user := *options[0].UserID
+			if user == "broken" {
+				return nil, errors.New("boom")
+			}
+			var memories []client.Memory
+			for i, score := range scores[user] {
+				score := score
+				memories = append(memories, client.Memory{ID: fmt.Sprintf("%s-%d", user, i), UserID: options[0].UserID, Score: &score})
+			}
+			return memories, nil
+		},
+	}
+
+	limit := 3
+	memories, err := client.SearchMany(context.Background(), mock, "diet", []string{"alex", "broken", "sam", "kim"},
+		client.SearchOptions{Limit: &limit}, client.WithConcurrency(2))
+	if err == nil || !strings.Contains(err.Error(), "user broken: boom") {
+		t.Errorf("SearchMany() error = %v, want failure for broken", err)
+	}
+
+	var ids []string
+	for _, memory := range memories {
+		ids = append(ids, memory.ID)
+	}
+	if want := "sam-0,alex-0,alex-1"; strings.Join(ids, ",") != want {
+		t.Errorf("SearchMany() ids = %v, want %s", ids, want)
+	}
+
+	for _, call := range mock.CallsTo("Search") {
+		options := call.Args[1].([]client.SearchOptions)[0]
+		if options.Limit == nil || *options.Limit != 3 {
+			t.Errorf("Search options = %+v, want shared limit", options)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultSessionMaxTurns is the buffer size at which a Session flushes when
+// SessionOptions doesn't set one
+const DefaultSessionMaxTurns = 10
+
+// SessionOptions configures a Session
+type SessionOptions struct {
+	Options  MemoryOptions                         // Options of every Add; UserID and RunID are set per conversation
+	MaxTurns int                                   // Flush once this many turns are buffered, DefaultSessionMaxTurns when zero
+	MaxAge   time.Duration                         // Flush turns buffered longer than this in the background; zero disables
+	Overlap  int                                   // Turns already sent that are repeated before new ones as context
+	OnError  func(userID, runID string, err error) // Optional: reports background flush failures
+}
+
+// Session buffers conversation turns per (userID, runID) and sends them to
+// Add in batches. Each Add carries only the turns not sent yet, preceded by
+// the last Overlap turns that were, so the API sees enough context without
+// the whole history being resent. Session is safe for concurrent use.
+type Session struct {
+	client  Client
+	options SessionOptions
+
+	mu      sync.Mutex
+	windows map[sessionKey]*sessionWindow
+}
+
+type sessionKey struct {
+	userID string
+	runID  string
+}
+
+// sessionWindow is the buffered state of one conversation
+type sessionWindow struct {
+	flushMu sync.Mutex // Serializes Adds so turns are sent in order
+	pending []Message
+	sent    []Message // Last Overlap turns already sent
+	timer   *time.Timer
+}
+
+// NewSession creates a Session adding memories through c
+func NewSession(c Client, options SessionOptions) *Session {
+	if options.MaxTurns <= 0 {
+		options.MaxTurns = DefaultSessionMaxTurns
+	}
+	if options.Overlap < 0 {
+		options.Overlap = 0
+	}
+	return &Session{client: c, options: options, windows: make(map[sessionKey]*sessionWindow)}
+}
+
+// Append buffers turns of the conversation of userID and runID, which may be
+// empty, and flushes when MaxTurns is reached
+func (s *Session) Append(ctx context.Context, userID, runID string, messages ...Message) error {
+	if userID == "" {
+		return NewValidationError("user_id", "user ID is required")
+	}
+	key := sessionKey{userID: userID, runID: runID}
+
+	s.mu.Lock()
+	window := s.windows[key]
+	if window == nil {
+		window = &sessionWindow{}
+		s.windows[key] = window
+	}
+	window.pending = append(window.pending, messages...)
+	full := len(window.pending) >= s.options.MaxTurns
+	if !full && window.timer == nil && len(window.pending) > 0 {
+		s.startTimer(key, window)
+	}
+	s.mu.Unlock()
+
+	if full {
+		return s.Flush(ctx, userID, runID)
+	}
+	return nil
+}
+
+// Flush sends the buffered turns of one conversation. On failure the turns
+// stay buffered and are sent with the next flush.
+func (s *Session) Flush(ctx context.Context, userID, runID string) error {
+	key := sessionKey{userID: userID, runID: runID}
+	s.mu.Lock()
+	window := s.windows[key]
+	s.mu.Unlock()
+	if window == nil {
+		return nil
+	}
+
+	window.flushMu.Lock()
+	defer window.flushMu.Unlock()
+
+	s.mu.Lock()
+	batch := window.pending
+	overlap := window.sent
+	window.pending = nil
+	if window.timer != nil {
+		window.timer.Stop()
+		window.timer = nil
+	}
+	s.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+
+	messages := make([]Message, 0, len(overlap)+len(batch))
+	messages = append(append(messages, overlap...), batch...)
+	options := s.options.Options
+	options.UserID = &userID
+	if runID != "" {
+		options.RunID = &runID
+	}
+	_, err := s.client.Add(ctx, messages, options)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		window.pending = append(batch, window.pending...)
+		if window.timer == nil {
+			s.startTimer(key, window)
+		}
+		return err
+	}
+	window.sent = messages[max(0, len(messages)-s.options.Overlap):]
+	return nil
+}
+
+// End flushes one conversation and forgets it
+func (s *Session) End(ctx context.Context, userID, runID string) error {
+	if err := s.Flush(ctx, userID, runID); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := sessionKey{userID: userID, runID: runID}
+	if window := s.windows[key]; window != nil && len(window.pending) == 0 {
+		delete(s.windows, key)
+	}
+	return nil
+}
+
+// Close flushes every conversation and stops the background timers. It
+// returns the joined errors of the conversations that failed.
+func (s *Session) Close(ctx context.Context) error {
+	s.mu.Lock()
+	keys := make([]sessionKey, 0, len(s.windows))
+	for key := range s.windows {
+		keys = append(keys, key)
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, key := range keys {
+		if err := s.End(ctx, key.userID, key.runID); err != nil {
+			errs = append(errs, fmt.Errorf("user %s run %q: %w", key.userID, key.runID, err))
+		}
+	}
+
+	s.mu.Lock()
+	for _, window := range s.windows {
+		if window.timer != nil {
+			window.timer.Stop()
+			window.timer = nil
+		}
+	}
+	s.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// startTimer schedules a background flush of window after MaxAge. s.mu must
+// be held.
+func (s *Session) startTimer(key sessionKey, window *sessionWindow) {
+	if s.options.MaxAge <= 0 {
+		return
+	}
+	window.timer = time.AfterFunc(s.options.MaxAge, func() {
+		err := s.Flush(context.Background(), key.userID, key.runID)
+		if err != nil && s.options.OnError != nil {
+			s.options.OnError(key.userID, key.runID, err)
+		}
+	})
+}
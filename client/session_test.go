@@ -0,0 +1,115 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+// recordingAdds returns a mock whose Adds are recorded as "user/run: turn,turn"
+func recordingAdds(fail *bool) (*clienttest.MockClient, func() []string) {
+	var mu sync.Mutex
+	var adds []string
+	mock := &clienttest.MockClient{
+		AddFunc: func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if fail != nil && *fail {
+				return nil, errors.New("unavailable")
+			}
+			var turns []string
+			for _, message := range messages {
+				turns = append(turns, message.Content.(string))
+			}
+			runID := ""
+			if options[0].RunID != nil {
+				runID = *options[0].RunID
+			}
+			adds = append(adds, fmt.Sprintf("%s/%s: %s", *options[0].UserID, runID, strings.Join(turns, ",")))
+			return nil, nil
+		},
+	}
+	return mock, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), adds...)
+	}
+}
+
+func turns(texts ...string) []client.Message {
+	messages := make([]client.Message, len(texts))
+	for i, text := range texts {
+		messages[i] = client.Message{Role: "user", Content: text}
+	}
+	return messages
+}
+
+func TestSessionFlushesIncrementally(t *testing.T) {
+	mock, adds := recordingAdds(nil)
+	session := client.NewSession(mock, client.SessionOptions{MaxTurns: 3, Overlap: 1})
+	ctx := context.Background()
+
+	session.Append(ctx, "alice", "run-1", turns("a1", "a2")...)
+	session.Append(ctx, "bob", "", turns("b1")...)
+	if got := adds(); len(got) != 0 {
+		t.Fatalf("Add called before MaxTurns: %v", got)
+	}
+
+	session.Append(ctx, "alice", "run-1", turns("a3")...)
+	session.Append(ctx, "alice", "run-1", turns("a4", "a5", "a6")...)
+	if err := session.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	got := strings.Join(adds(), "\n")
+	for _, want := range []string{"alice/run-1: a1,a2,a3", "alice/run-1: a3,a4,a5,a6", "bob/: b1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("adds =\n%s\nmissing %q", got, want)
+		}
+	}
+	if len(adds()) != 3 {
+		t.Errorf("adds = %v, want 3", adds())
+	}
+}
+
+func TestSessionKeepsTurnsOnFailure(t *testing.T) {
+	fail := true
+	mock, adds := recordingAdds(&fail)
+	session := client.NewSession(mock, client.SessionOptions{MaxTurns: 2})
+	ctx := context.Background()
+
+	if err := session.Append(ctx, "alice", "", turns("a1", "a2")...); err == nil {
+		t.Fatal("Append() expected flush error")
+	}
+	fail = false
+	session.Append(ctx, "alice", "", turns("a3")...)
+	if got := adds(); len(got) != 1 || got[0] != "alice/: a1,a2,a3" {
+		t.Errorf("adds = %v, want retried turns in order", got)
+	}
+
+	if err := session.Append(ctx, "", "", turns("x")...); err == nil {
+		t.Error("Append() without user ID expected error")
+	}
+}
+
+func TestSessionFlushesAfterMaxAge(t *testing.T) {
+	mock, adds := recordingAdds(nil)
+	session := client.NewSession(mock, client.SessionOptions{MaxTurns: 100, MaxAge: 10 * time.Millisecond})
+	defer session.Close(context.Background())
+
+	session.Append(context.Background(), "alice", "", turns("a1")...)
+	deadline := time.Now().Add(2 * time.Second)
+	for len(adds()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := adds(); len(got) != 1 || got[0] != "alice/: a1" {
+		t.Errorf("adds = %v, want background flush", got)
+	}
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultStatsPageSize is the number of memories Stats fetches per request
+const DefaultStatsPageSize = 100
+
+// StatsDateLayout formats the keys of MemoryStats.ByDate
+const StatsDateLayout = "2006-01-02"
+
+// MemoryStats counts memories for dashboards. Memories without an entity or
+// category are not counted under that breakdown, and a memory with several
+// categories is counted under each of them.
+type MemoryStats struct {
+	Total      int            `json:"total"`
+	ByUser     map[string]int `json:"by_user"`
+	ByAgent    map[string]int `json:"by_agent"`
+	ByCategory map[string]int `json:"by_category"`
+	ByDate     map[string]int `json:"by_date"` // Creation day in UTC, formatted with StatsDateLayout
+	Oldest     *time.Time     `json:"oldest,omitempty"`
+	Newest     *time.Time     `json:"newest,omitempty"`
+}
+
+// Stats counts the memories matching filters by user, agent, category and
+// creation date. The platform has no aggregate endpoint, so Stats pages
+// through GetAll, DefaultStatsPageSize memories at a time unless filters set
+// a page size; large projects take one request per page.
+func Stats(ctx context.Context, c Client, filters ...SearchOptions) (*MemoryStats, error) {
+	options := SearchOptions{}
+	if len(filters) > 0 {
+		options = filters[0]
+	}
+	pageSize := DefaultStatsPageSize
+	if options.PageSize != nil && *options.PageSize > 0 {
+		pageSize = *options.PageSize
+	}
+
+	stats := &MemoryStats{
+		ByUser:     map[string]int{},
+		ByAgent:    map[string]int{},
+		ByCategory: map[string]int{},
+		ByDate:     map[string]int{},
+	}
+	for page := 1; ; page++ {
+		opts := options
+		opts.Page, opts.PageSize = &page, &pageSize
+		memories, err := c.GetAll(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, memory := range memories {
+			stats.add(memory)
+		}
+		if len(memories) < pageSize {
+			return stats, nil
+		}
+	}
+}
+
+// add counts memory
+func (s *MemoryStats) add(memory Memory) {
+	s.Total++
+	if memory.UserID != nil && *memory.UserID != "" {
+		s.ByUser[*memory.UserID]++
+	}
+	if memory.AgentID != nil && *memory.AgentID != "" {
+		s.ByAgent[*memory.AgentID]++
+	}
+	for _, category := range memory.Categories {
+		s.ByCategory[category]++
+	}
+	if memory.CreatedAt == nil {
+		return
+	}
+	created := memory.CreatedAt.UTC()
+	s.ByDate[created.Format(StatsDateLayout)]++
+	if s.Oldest == nil || created.Before(*s.Oldest) {
+		s.Oldest = &created
+	}
+	if s.Newest == nil || created.After(*s.Newest) {
+		s.Newest = &created
+	}
+}
@@ -0,0 +1,65 @@
+package client_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+func TestStats(t *testing.T) {
+	day := func(d int) *time.Time {
+		at := time.Date(2024, 3, d, 12, 0, 0, 0, time.UTC)
+		return &at
+	}
+	memories := []client.Memory{
+		{ID: "1", UserID: strPtr("alex"), Categories: []string{"food"}, CreatedAt: day(1)},
+		{ID: "2", UserID: strPtr("alex"), Categories: []string{"food", "travel"}, CreatedAt: day(2)},
+		{ID: "3", UserID: strPtr("sam"), AgentID: strPtr("planner"), CreatedAt: day(2)},
+		{ID: "4", AgentID: strPtr("planner")},
+		{ID: "5", UserID: strPtr("sam"), CreatedAt: day(5)},
+	}
+
+	var pages []int
+	mock := &clienttest.MockClient{
+		GetAllFunc: func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+			page, size := *options[0].Page, *options[0].PageSize
+			pages = append(pages, page)
+			start := min((page-1)*size, len(memories))
+			return memories[start:min(start+size, len(memories))], nil
+		},
+	}
+
+	pageSize := 2
+	stats, err := client.Stats(context.Background(), mock, client.SearchOptions{MemoryOptions: client.MemoryOptions{PageSize: &pageSize}})
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(pages, []int{1, 2, 3}) {
+		t.Errorf("pages = %v, want [1 2 3]", pages)
+	}
+	if stats.Total != 5 {
+		t.Errorf("Total = %d, want 5", stats.Total)
+	}
+	checks := []struct {
+		name      string
+		got, want map[string]int
+	}{
+		{"ByUser", stats.ByUser, map[string]int{"alex": 2, "sam": 2}},
+		{"ByAgent", stats.ByAgent, map[string]int{"planner": 2}},
+		{"ByCategory", stats.ByCategory, map[string]int{"food": 2, "travel": 1}},
+		{"ByDate", stats.ByDate, map[string]int{"2024-03-01": 1, "2024-03-02": 2, "2024-03-05": 1}},
+	}
+	for _, check := range checks {
+		if !reflect.DeepEqual(check.got, check.want) {
+			t.Errorf("%s = %v, want %v", check.name, check.got, check.want)
+		}
+	}
+	if !stats.Oldest.Equal(*day(1)) || !stats.Newest.Equal(*day(5)) {
+		t.Errorf("Oldest, Newest = %v, %v", stats.Oldest, stats.Newest)
+	}
+}
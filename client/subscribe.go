@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SubscribeOptions configures Subscribe
+type SubscribeOptions struct {
+	// Addr is the listen address of a local webhook receiver, e.g. ":8080".
+	// When both Addr and Listener are empty, Subscribe polls instead.
+	Addr     string
+	Listener net.Listener // Optional: serve webhooks on this listener instead of Addr
+	Path     string       // Webhook path, "/" when empty
+	// Verify authenticates webhook deliveries, e.g. WebhookToken(secret).
+	// Without it the receiver accepts any POST as a memory event, so it
+	// must not be reachable by untrusted clients.
+	Verify WebhookVerifier
+
+	Watch   WatchOptions // Poll settings when no receiver is configured
+	Buffer  int          // Channel capacity
+	OnError func(error)  // Optional: called when polling or the receiver stops with an error
+}
+
+// Subscribe delivers memory events of the given types, all when none are
+// given, on the returned channel until ctx is cancelled, then closes it.
+// With a receiver configured it serves webhook deliveries, which must be
+// registered with Mem0 to reach it and should be authenticated with
+// options.Verify; otherwise it polls c with WatchMemories. Listen errors are
+// returned immediately.
+func Subscribe(ctx context.Context, c Client, options SubscribeOptions, events ...WebhookEvent) (<-chan MemoryEvent, error) {
+	wanted := map[WebhookEvent]bool{}
+	for _, event := range events {
+		wanted[event] = true
+	}
+	ch := make(chan MemoryEvent, options.Buffer)
+	deliver := func(event MemoryEvent) {
+		if len(wanted) > 0 && !wanted[event.Event] {
+			return
+		}
+		select {
+		case ch <- event:
+		case <-ctx.Done():
+		}
+	}
+	report := func(err error) {
+		if err != nil && options.OnError != nil {
+			options.OnError(err)
+		}
+	}
+
+	listener := options.Listener
+	if listener == nil && options.Addr != "" {
+		var err error
+		if listener, err = net.Listen("tcp", options.Addr); err != nil {
+			return nil, err
+		}
+	}
+
+	if listener == nil {
+		go func() {
+			defer close(ch)
+			if err := WatchMemories(ctx, c, options.Watch, deliver); !errors.Is(err, context.Canceled) {
+				report(err)
+			}
+		}()
+		return ch, nil
+	}
+
+	path := options.Path
+	if path == "" {
+		path = "/"
+	}
+
+	// Deliveries still running when the server stops must not send on the
+	// closed channel
+	var mu sync.RWMutex
+	closed := false
+	var verify []WebhookVerifier
+	if options.Verify != nil {
+		verify = append(verify, options.Verify)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, WebhookHandler(func(event MemoryEvent) {
+		mu.RLock()
+		defer mu.RUnlock()
+		if !closed {
+			deliver(event)
+		}
+	}, verify...))
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			report(err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+
+		mu.Lock()
+		closed = true
+		close(ch)
+		mu.Unlock()
+	}()
+	return ch, nil
+}
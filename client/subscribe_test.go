@@ -0,0 +1,152 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+func TestSubscribeWebhooks(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Subscribe(ctx, nil, client.SubscribeOptions{Listener: listener, Path: "/webhooks/mem0", Buffer: 1},
+		client.WebhookEventMemoryDeleted)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	url := "http://" + listener.Addr().String() + "/webhooks/mem0"
+	for _, body := range []string{
+		`{"event_details": {"id": "mem-1", "event": "ADD"}}`,
+		`{"event_details": {"id": "mem-1", "event": "DELETE"}}`,
+	} {
+		resp, err := http.Post(url, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Post() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("delivery status = %d, want 204", resp.StatusCode)
+		}
+	}
+
+	select {
+	case event := <-events:
+		if event.Event != client.WebhookEventMemoryDeleted || event.Memory.ID != "mem-1" {
+			t.Errorf("event = %+v, want the delete of mem-1", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("received an event after cancel, want the channel closed")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+func TestSubscribeVerifiesWebhooks(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Subscribe(ctx, nil, client.SubscribeOptions{Listener: listener, Verify: client.WebhookToken("s3cret"), Buffer: 1})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	url := "http://" + listener.Addr().String() + "/"
+	body := `{"event_details": {"id": "mem-1", "event": "DELETE"}}`
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unauthenticated delivery status = %d, want 401", resp.StatusCode)
+	}
+	resp, err = http.Post(url+"?token=s3cret", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	resp.Body.Close()
+
+	select {
+	case event := <-events:
+		if event.Memory.ID != "mem-1" {
+			t.Errorf("event = %+v, want the authenticated delivery", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+	}
+	select {
+	case event := <-events:
+		t.Errorf("unexpected second event %+v", event)
+	default:
+	}
+}
+
+func TestSubscribePolling(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	memoryClient, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Subscribe(ctx, memoryClient, client.SubscribeOptions{
+		Watch: client.WatchOptions{
+			SearchOptions: client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: strPtr("alex")}},
+			Interval:      5 * time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	// Let the first poll take its baseline before adding
+	time.Sleep(20 * time.Millisecond)
+	if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "I like tea"}}, client.MemoryOptions{UserID: strPtr("alex")}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	select {
+	case event := <-events:
+		if event.Event != client.WebhookEventMemoryAdded {
+			t.Errorf("event = %+v, want memory_add", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestSubscribeListenError(t *testing.T) {
+	if _, err := client.Subscribe(context.Background(), nil, client.SubscribeOptions{Addr: "not-an-address"}); err == nil {
+		t.Error("Subscribe() error = nil, want listen error")
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// TokenCounter returns the number of tokens in text
+type TokenCounter func(text string) int
+
+// EstimateTokens approximates the token count of text at four characters per
+// token, which is close enough for budgeting English prompts
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// Summarizer condenses memories into a summary of at most maxTokens tokens
+type Summarizer interface {
+	Summarize(ctx context.Context, memories []Memory, maxTokens int) (string, error)
+}
+
+// SummarizerFunc adapts a function to the Summarizer interface
+type SummarizerFunc func(ctx context.Context, memories []Memory, maxTokens int) (string, error)
+
+// Summarize implements Summarizer
+func (f SummarizerFunc) Summarize(ctx context.Context, memories []Memory, maxTokens int) (string, error) {
+	return f(ctx, memories, maxTokens)
+}
+
+// SummarizeOptions configures Summarize
+type SummarizeOptions struct {
+	SearchOptions
+	MaxTokens   int          // Token budget, unlimited when zero
+	Summarizer  Summarizer   // Optional: e.g. an LLM; a bullet list is used when nil
+	CountTokens TokenCounter // Optional: EstimateTokens when nil
+}
+
+// Summarize fetches the memories of a user or run and condenses them into a
+// string suitable for a system prompt
+func Summarize(ctx context.Context, c Client, options SummarizeOptions) (string, error) {
+	if options.UserID == nil && options.RunID == nil {
+		return "", NewValidationError("user_id", "user_id or run_id is required")
+	}
+
+	memories, err := c.GetAll(ctx, options.SearchOptions)
+	if err != nil {
+		return "", err
+	}
+	if options.Summarizer != nil {
+		return options.Summarizer.Summarize(ctx, memories, options.MaxTokens)
+	}
+
+	countTokens := options.CountTokens
+	if countTokens == nil {
+		countTokens = EstimateTokens
+	}
+	return bulletSummary(memories, options.MaxTokens, countTokens), nil
+}
+
+// bulletSummary lists memories newest first, one per line, stopping before
+// the budget is exceeded
+func bulletSummary(memories []Memory, maxTokens int, countTokens TokenCounter) string {
+	sorted := make([]Memory, len(memories))
+	copy(sorted, memories)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].UpdatedAt, sorted[j].UpdatedAt
+		return a != nil && (b == nil || a.After(*b))
+	})
+
+	var b strings.Builder
+	used := 0
+	for _, memory := range sorted {
+		text := strings.TrimSpace(memory.Text())
+		if text == "" {
+			continue
+		}
+		line := "- " + text + "\n"
+		tokens := countTokens(line)
+		if maxTokens > 0 && used+tokens > maxTokens {
+			break
+		}
+		used += tokens
+		b.WriteString(line)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,86 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+func TestSummarize(t *testing.T) {
+	older := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	mock := &clienttest.MockClient{
+		GetAllFunc: func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+			return []client.Memory{
+				{ID: "mem-1", Memory: strPtr("Lives in Lisbon"), UpdatedAt: &older},
+				{ID: "mem-2", Memory: strPtr("Is a vegetarian"), UpdatedAt: &newer},
+				{ID: "mem-3", Data: &client.MemoryData{Memory: "Plays chess"}},
+			}, nil
+		},
+	}
+	ctx := context.Background()
+
+	summary, err := client.Summarize(ctx, mock, client.SummarizeOptions{
+		SearchOptions: client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: strPtr("alex")}},
+	})
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	want := "- Is a vegetarian\n- Lives in Lisbon\n- Plays chess"
+	if summary != want {
+		t.Errorf("Summarize() = %q, want %q", summary, want)
+	}
+
+	budgeted, err := client.Summarize(ctx, mock, client.SummarizeOptions{
+		SearchOptions: client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: strPtr("alex")}},
+		MaxTokens:     2,
+		CountTokens:   func(string) int { return 1 },
+	})
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if budgeted != "- Is a vegetarian\n- Lives in Lisbon" {
+		t.Errorf("Summarize() with budget = %q", budgeted)
+	}
+}
+
+func TestSummarizeWithSummarizer(t *testing.T) {
+	mock := &clienttest.MockClient{
+		GetAllFunc: func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+			return []client.Memory{{ID: "mem-1"}, {ID: "mem-2"}}, nil
+		},
+	}
+
+	var gotCount, gotBudget int
+	summary, err := client.Summarize(context.Background(), mock, client.SummarizeOptions{
+		SearchOptions: client.SearchOptions{MemoryOptions: client.MemoryOptions{RunID: strPtr("run-1")}},
+		MaxTokens:     100,
+		Summarizer: client.SummarizerFunc(func(ctx context.Context, memories []client.Memory, maxTokens int) (string, error) {
+			gotCount, gotBudget = len(memories), maxTokens
+			return "summary", nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if summary != "summary" || gotCount != 2 || gotBudget != 100 {
+		t.Errorf("Summarize() = %q with %d memories and budget %d", summary, gotCount, gotBudget)
+	}
+}
+
+func TestSummarizeRequiresEntity(t *testing.T) {
+	mock := &clienttest.MockClient{}
+	_, err := client.Summarize(context.Background(), mock, client.SummarizeOptions{})
+
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Summarize() error = %v, want *ValidationError", err)
+	}
+	if len(mock.Calls()) != 0 {
+		t.Errorf("Summarize() made calls: %v", mock.Calls())
+	}
+}
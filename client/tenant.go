@@ -0,0 +1,45 @@
+package client
+
+import "context"
+
+// Tenant is the identity a request acts for. Empty fields are unset.
+type Tenant struct {
+	OrgID     string
+	ProjectID string
+	UserID    string
+	AgentID   string
+	AppID     string
+	RunID     string
+}
+
+type tenantContext struct{}
+
+// WithTenant returns a context whose Add, Search, GetAll and DeleteAll calls
+// default to tenant's organization, project and entity IDs. Middleware can
+// set the identity once per request and every call made with the context
+// inherits it. The tenant overrides the client's defaults and organization
+// and project; options passed to a call override the tenant.
+func WithTenant(ctx context.Context, tenant Tenant) context.Context {
+	return context.WithValue(ctx, tenantContext{}, tenant)
+}
+
+// TenantFrom returns the tenant set on ctx with WithTenant
+func TenantFrom(ctx context.Context) (Tenant, bool) {
+	tenant, ok := ctx.Value(tenantContext{}).(Tenant)
+	return tenant, ok
+}
+
+// entityOptions returns the tenant's entity IDs as options
+func (t Tenant) entityOptions() MemoryOptions {
+	var opts MemoryOptions
+	for _, field := range []struct {
+		dest **string
+		id   string
+	}{{&opts.UserID, t.UserID}, {&opts.AgentID, t.AgentID}, {&opts.AppID, t.AppID}, {&opts.RunID, t.RunID}} {
+		if field.id != "" {
+			id := field.id
+			*field.dest = &id
+		}
+	}
+	return opts
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWithTenant(t *testing.T) {
+	c, captured := newStubClient(t, http.StatusOK, `[]`)
+	c.defaults = &MemoryOptions{UserID: stringPtr("default-user")}
+	ctx := WithTenant(context.Background(), Tenant{OrgID: "org-2", ProjectID: "proj-2", UserID: "alex"})
+
+	if _, err := c.Search(ctx, "tea"); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if captured.Body["org_id"] != "org-2" || captured.Body["project_id"] != "proj-2" || captured.Body["user_id"] != "alex" {
+		t.Errorf("Search() body = %v, want the tenant's org, project and user", captured.Body)
+	}
+
+	// The call's options override the tenant
+	if _, err := c.Add(ctx, []Message{{Role: "user", Content: "hi"}}, MemoryOptions{UserID: stringPtr("sam"), OrgID: "org-3", ProjectID: "proj-3"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if captured.Body["org_id"] != "org-3" || captured.Body["user_id"] != "sam" {
+		t.Errorf("Add() body = %v, want the call's org and user", captured.Body)
+	}
+
+	// A tenant naming only a user keeps the client's project
+	if _, err := c.GetAll(WithTenant(context.Background(), Tenant{UserID: "alex"})); err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if !strings.Contains(captured.RawQuery, "org_id=org-1") || !strings.Contains(captured.RawQuery, "user_id=alex") {
+		t.Errorf("GetAll() query = %q, want the client's org and the tenant's user", captured.RawQuery)
+	}
+
+	if tenant, ok := TenantFrom(ctx); !ok || tenant.UserID != "alex" {
+		t.Errorf("TenantFrom() = %+v, %v", tenant, ok)
+	}
+}
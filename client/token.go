@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Authorization schemes for ClientOptions.AuthScheme
+const (
+	AuthSchemeToken  = "Token"  // Mem0 platform default
+	AuthSchemeBearer = "Bearer" // Gateways and self-hosted deployments
+)
+
+// TokenSource supplies the API key for each request, so long-running services
+// can rotate keys pulled from a secrets manager without recreating the client.
+// Token is called on every request; implementations should cache the key.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token implements TokenSource
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// CredentialsProvider looks up the API key, typically in a secrets manager,
+// so it never has to live in plain configuration. The credentials package
+// has providers for environment variables, files, Vault and cloud secret
+// managers.
+type CredentialsProvider interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// SetAPIKey replaces the API key of subsequent requests and stops using any
+// TokenSource. It is safe to call while requests are in flight. If the new
+// key belongs to another organization or project, call ForceReauth too.
+func (c *MemoryClient) SetAPIKey(apiKey string) error {
+	if err := validateAPIKey(apiKey); err != nil {
+		return err
+	}
+
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	c.apiKey = apiKey
+	c.tokenSource = nil
+	return nil
+}
+
+// authorization returns the header name and value that carry the API key
+func (c *MemoryClient) authorization(ctx context.Context) (string, string, error) {
+	c.keyMu.Lock()
+	apiKey, source := c.apiKey, c.tokenSource
+	c.keyMu.Unlock()
+
+	if source != nil {
+		token, err := source.Token(ctx)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get API key: %w", err)
+		}
+		if err := validateAPIKey(token); err != nil {
+			return "", "", err
+		}
+		apiKey = token
+	}
+
+	header, scheme := c.authHeader, c.authScheme
+	if header == "" {
+		header = "Authorization"
+		if scheme == "" {
+			scheme = AuthSchemeToken
+		}
+	}
+	if scheme == "" {
+		return header, apiKey, nil
+	}
+	return header, fmt.Sprintf("%s %s", scheme, apiKey), nil
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// newKeyRecordingServer answers every request and records the Authorization
+// header of the last one
+func newKeyRecordingServer(t *testing.T) (string, *atomic.Value) {
+	t.Helper()
+
+	var last atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last.Store(r.Header.Get("Authorization"))
+		if r.URL.Path == "/v1/ping/" {
+			w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1","user_email":"test@example.com"}`))
+			return
+		}
+		w.Write([]byte(`{"id": "mem-1"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, &last
+}
+
+func TestSetAPIKey(t *testing.T) {
+	host, last := newKeyRecordingServer(t)
+	client, err := NewMemoryClient(ClientOptions{APIKey: "old-key", Host: &host})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.Get(ctx, "mem-1")
+		}()
+		go func() {
+			defer wg.Done()
+			client.SetAPIKey("new-key")
+		}()
+	}
+	wg.Wait()
+
+	client.Get(ctx, "mem-1")
+	if got := last.Load(); got != "Token new-key" {
+		t.Errorf("Authorization = %v, want Token new-key", got)
+	}
+
+	var validationErr *ValidationError
+	if err := client.SetAPIKey("  "); !errors.As(err, &validationErr) {
+		t.Errorf("SetAPIKey(blank) error = %v, want *ValidationError", err)
+	}
+}
+
+func TestTokenSource(t *testing.T) {
+	host, last := newKeyRecordingServer(t)
+	var key atomic.Value
+	key.Store("first-key")
+	source := TokenSourceFunc(func(ctx context.Context) (string, error) {
+		return key.Load().(string), nil
+	})
+
+	client, err := NewMemoryClient(ClientOptions{Host: &host, TokenSource: source})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	client.Get(ctx, "mem-1")
+	if got := last.Load(); got != "Token first-key" {
+		t.Errorf("Authorization = %v, want Token first-key", got)
+	}
+
+	key.Store("rotated-key")
+	client.Get(ctx, "mem-1")
+	if got := last.Load(); got != "Token rotated-key" {
+		t.Errorf("Authorization = %v, want Token rotated-key", got)
+	}
+
+	t.Run("errors fail the request", func(t *testing.T) {
+		unavailable := errors.New("vault sealed")
+		client, _ := NewMemoryClient(ClientOptions{Host: &host, TokenSource: TokenSourceFunc(func(ctx context.Context) (string, error) {
+			return "", unavailable
+		})})
+		if _, err := client.Get(ctx, "mem-1"); !errors.Is(err, unavailable) {
+			t.Errorf("Get() error = %v, want %v", err, unavailable)
+		}
+	})
+}
+
+func TestAuthScheme(t *testing.T) {
+	tests := []struct {
+		name       string
+		scheme     string
+		header     string
+		wantHeader string
+		wantValue  string
+	}{
+		{name: "default", wantHeader: "Authorization", wantValue: "Token m0-key"},
+		{name: "bearer", scheme: AuthSchemeBearer, wantHeader: "Authorization", wantValue: "Bearer m0-key"},
+		{name: "custom header", header: "X-Api-Key", wantHeader: "X-Api-Key", wantValue: "m0-key"},
+		{name: "custom header with scheme", header: "X-Gateway-Auth", scheme: AuthSchemeBearer, wantHeader: "X-Gateway-Auth", wantValue: "Bearer m0-key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Clone()
+				w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1","user_email":"test@example.com"}`))
+			}))
+			defer srv.Close()
+
+			if _, err := NewMemoryClient(ClientOptions{APIKey: "m0-key", Host: &srv.URL, AuthScheme: tt.scheme, AuthHeader: tt.header}); err != nil {
+				t.Fatalf("NewMemoryClient() error = %v", err)
+			}
+			if value := got.Get(tt.wantHeader); value != tt.wantValue {
+				t.Errorf("%s = %q, want %q", tt.wantHeader, value, tt.wantValue)
+			}
+			if tt.wantHeader != "Authorization" && got.Get("Authorization") != "" {
+				t.Errorf("Authorization = %q, want unset", got.Get("Authorization"))
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxIdleConnsPerHost is the idle connection pool size per host used
+// when TransportOptions doesn't set one. net/http defaults to 2, which forces
+// new connections under concurrent load.
+const DefaultMaxIdleConnsPerHost = 100
+
+// TransportOptions tunes the HTTP transport of the client. Zero values keep
+// the net/http defaults, except MaxIdleConnsPerHost which defaults to
+// DefaultMaxIdleConnsPerHost. Ignored when ClientOptions.HTTPClient is set.
+type TransportOptions struct {
+	MaxIdleConns          int           // Idle connections across all hosts
+	MaxIdleConnsPerHost   int           // Idle connections kept per host
+	MaxConnsPerHost       int           // Limit on total connections per host, unlimited when zero
+	IdleConnTimeout       time.Duration // How long an idle connection is kept
+	KeepAlive             time.Duration // TCP keep-alive period; negative disables keep-alives
+	DialTimeout           time.Duration // Timeout for establishing TCP connections
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	ForceAttemptHTTP2     *bool // Defaults to true, as in net/http
+	DisableKeepAlives     bool  // Use a new connection per request
+}
+
+// newTransport builds an *http.Transport from options, starting from a clone
+// of http.DefaultTransport
+func newTransport(options *TransportOptions) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	if options == nil {
+		return transport
+	}
+
+	if options.MaxIdleConns > 0 {
+		transport.MaxIdleConns = options.MaxIdleConns
+	}
+	if options.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = options.MaxIdleConnsPerHost
+	}
+	if options.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = options.MaxConnsPerHost
+	}
+	if options.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = options.IdleConnTimeout
+	}
+	if options.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = options.TLSHandshakeTimeout
+	}
+	if options.ResponseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = options.ResponseHeaderTimeout
+	}
+	if options.ForceAttemptHTTP2 != nil {
+		transport.ForceAttemptHTTP2 = *options.ForceAttemptHTTP2
+	}
+	transport.DisableKeepAlives = options.DisableKeepAlives
+
+	if options.KeepAlive != 0 || options.DialTimeout > 0 {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		if options.KeepAlive != 0 {
+			dialer.KeepAlive = options.KeepAlive
+		}
+		if options.DialTimeout > 0 {
+			dialer.Timeout = options.DialTimeout
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	return transport
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewTransportDefaults(t *testing.T) {
+	transport := newTransport(nil)
+	if transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Errorf("MaxIdleConnsPerHost = %d, want %d", transport.MaxIdleConnsPerHost, DefaultMaxIdleConnsPerHost)
+	}
+	if !transport.ForceAttemptHTTP2 {
+		t.Error("ForceAttemptHTTP2 = false, want true")
+	}
+	if transport == http.DefaultTransport {
+		t.Error("newTransport() returned http.DefaultTransport, want a clone")
+	}
+}
+
+func TestNewTransportOptions(t *testing.T) {
+	disabled := false
+	transport := newTransport(&TransportOptions{
+		MaxIdleConns:        50,
+		MaxIdleConnsPerHost: 20,
+		MaxConnsPerHost:     10,
+		IdleConnTimeout:     time.Minute,
+		KeepAlive:           15 * time.Second,
+		ForceAttemptHTTP2:   &disabled,
+		DisableKeepAlives:   true,
+	})
+
+	if transport.MaxIdleConns != 50 || transport.MaxIdleConnsPerHost != 20 || transport.MaxConnsPerHost != 10 {
+		t.Errorf("pool sizes = %d/%d/%d, want 50/20/10", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != time.Minute {
+		t.Errorf("IdleConnTimeout = %v, want 1m", transport.IdleConnTimeout)
+	}
+	if transport.ForceAttemptHTTP2 {
+		t.Error("ForceAttemptHTTP2 = true, want false")
+	}
+	if !transport.DisableKeepAlives {
+		t.Error("DisableKeepAlives = false, want true")
+	}
+	if transport.DialContext == nil {
+		t.Error("DialContext not set for custom KeepAlive")
+	}
+}
+
+func TestNewMemoryClientTransport(t *testing.T) {
+	client, _ := newStubClient(t, http.StatusOK, `{}`)
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok || transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Errorf("Transport = %T, want tuned *http.Transport", client.httpClient.Transport)
+	}
+
+	custom := &http.Client{}
+	overridden, err := NewMemoryClient(ClientOptions{
+		APIKey:     "test-api-key",
+		Host:       &client.host,
+		HTTPClient: custom,
+		Transport:  &TransportOptions{MaxConnsPerHost: 8},
+	})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	if overridden.httpClient != custom {
+		t.Error("HTTPClient option was replaced by Transport options")
+	}
+}
@@ -1,6 +1,10 @@
 package client
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // API version and output format enums
 type APIVersion string
@@ -16,6 +20,9 @@ const (
 	OutputFormatV1   OutputFormat = "v1.0"
 	OutputFormatV1_1 OutputFormat = "v1.1"
 
+	// DefaultOutputFormat is requested when OutputFormat is not set
+	DefaultOutputFormat = OutputFormatV1_1
+
 	FeedbackPositive     Feedback = "POSITIVE"
 	FeedbackNegative     Feedback = "NEGATIVE"
 	FeedbackVeryNegative Feedback = "VERY_NEGATIVE"
@@ -41,13 +48,14 @@ type MultiModalMessages struct {
 // Message represents a chat message
 type Message struct {
 	Role    string      `json:"role"`    // "user" or "assistant"
-	Content interface{} `json:"content"` // string or MultiModalMessages
+	Content interface{} `json:"content"` // string, MultiModalMessages or DocumentContent
 }
 
-// MemoryOptions contains options for memory operations
+// MemoryOptions contains options for memory operations. Fields tagged
+// url:"-" are never sent as query parameters.
 type MemoryOptions struct {
-	APIVersion         *APIVersion              `json:"api_version,omitempty"`
-	Version            *APIVersion              `json:"version,omitempty"`
+	APIVersion         *APIVersion              `json:"api_version,omitempty" url:"-"`
+	Version            *APIVersion              `json:"version,omitempty" url:"-"` // Deprecated: use APIVersion or WithAPIVersion
 	UserID             *string                  `json:"user_id,omitempty"`
 	AgentID            *string                  `json:"agent_id,omitempty"`
 	AppID              *string                  `json:"app_id,omitempty"`
@@ -58,19 +66,23 @@ type MemoryOptions struct {
 	ProjectName        *string                  `json:"project_name,omitempty"` // Deprecated
 	OrgID              interface{}              `json:"org_id,omitempty"`       // string or number
 	ProjectID          interface{}              `json:"project_id,omitempty"`   // string or number
-	Infer              *bool                    `json:"infer,omitempty"`
-	Page               *int                     `json:"page,omitempty"`
-	PageSize           *int                     `json:"page_size,omitempty"`
-	Includes           *string                  `json:"includes,omitempty"`
-	Excludes           *string                  `json:"excludes,omitempty"`
+	Infer              *bool                    `json:"infer,omitempty" url:"-"`
+	Page               *int                     `json:"page,omitempty" url:"-"`
+	PageSize           *int                     `json:"page_size,omitempty" url:"-"`
+	Includes           *string                  `json:"includes,omitempty" url:"-"`
+	Excludes           *string                  `json:"excludes,omitempty" url:"-"`
 	EnableGraph        *bool                    `json:"enable_graph,omitempty"`
 	StartDate          *string                  `json:"start_date,omitempty"`
 	EndDate            *string                  `json:"end_date,omitempty"`
 	CustomCategories   []map[string]interface{} `json:"custom_categories,omitempty"`
-	CustomInstructions *string                  `json:"custom_instructions,omitempty"`
-	Timestamp          *int64                   `json:"timestamp,omitempty"`
-	OutputFormat       *OutputFormat            `json:"output_format,omitempty"`
-	AsyncMode          *bool                    `json:"async_mode,omitempty"`
+	CustomInstructions *string                  `json:"custom_instructions,omitempty" url:"-"`
+	Timestamp          *int64                   `json:"timestamp,omitempty" url:"-"`
+	OutputFormat       *OutputFormat            `json:"output_format,omitempty" url:"-"`
+	AsyncMode          *bool                    `json:"async_mode,omitempty" url:"-"`
+	ExpirationDate     *string                  `json:"expiration_date,omitempty" url:"-"` // YYYY-MM-DD
+	Immutable          *bool                    `json:"immutable,omitempty" url:"-"`       // Locks added memories against Update and Delete
+
+	ConfirmDeleteAll bool `json:"-"` // Allows DeleteAll without an entity ID or filters, deleting the whole project
 }
 
 // SearchOptions extends MemoryOptions with search-specific fields
@@ -85,6 +97,10 @@ type SearchOptions struct {
 	Fields                  []string `json:"fields,omitempty"`
 	Categories              []string `json:"categories,omitempty"`
 	Rerank                  *bool    `json:"rerank,omitempty"`
+	FilterMemories          *bool    `json:"filter_memories,omitempty"`
+	IncludeExpired          *bool    `json:"include_expired,omitempty"`
+	ExpiredOnly             *bool    `json:"expired_only,omitempty"`
+	IncludeEmbeddings       *bool    `json:"include_embeddings,omitempty"` // Return each memory's Embedding, where the API supports it
 }
 
 // ProjectOptions contains options for project operations
@@ -99,23 +115,37 @@ type MemoryData struct {
 
 // Memory represents a memory object
 type Memory struct {
-	ID         string      `json:"id"`
-	Messages   []Message   `json:"messages,omitempty"`
-	Event      *Event      `json:"event,omitempty"`
-	Data       *MemoryData `json:"data,omitempty"`
-	Memory     *string     `json:"memory,omitempty"`
-	UserID     *string     `json:"user_id,omitempty"`
-	Hash       *string     `json:"hash,omitempty"`
-	Categories []string    `json:"categories,omitempty"`
-	CreatedAt  *time.Time  `json:"created_at,omitempty"`
-	UpdatedAt  *time.Time  `json:"updated_at,omitempty"`
-	MemoryType *string     `json:"memory_type,omitempty"`
-	Score      *float64    `json:"score,omitempty"`
-	Metadata   interface{} `json:"metadata,omitempty"`
-	Owner      *string     `json:"owner,omitempty"`
-	AgentID    *string     `json:"agent_id,omitempty"`
-	AppID      *string     `json:"app_id,omitempty"`
-	RunID      *string     `json:"run_id,omitempty"`
+	ID             string      `json:"id"`
+	Messages       []Message   `json:"messages,omitempty"`
+	Event          *Event      `json:"event,omitempty"`
+	Data           *MemoryData `json:"data,omitempty"`
+	Memory         *string     `json:"memory,omitempty"`
+	UserID         *string     `json:"user_id,omitempty"`
+	Hash           *string     `json:"hash,omitempty"`
+	Categories     []string    `json:"categories,omitempty"`
+	CreatedAt      *time.Time  `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time  `json:"updated_at,omitempty"`
+	MemoryType     *string     `json:"memory_type,omitempty"`
+	Score          *float64    `json:"score,omitempty"`
+	Metadata       interface{} `json:"metadata,omitempty"`
+	Owner          *string     `json:"owner,omitempty"`
+	AgentID        *string     `json:"agent_id,omitempty"`
+	AppID          *string     `json:"app_id,omitempty"`
+	RunID          *string     `json:"run_id,omitempty"`
+	ExpirationDate *string     `json:"expiration_date,omitempty"`
+	Immutable      *bool       `json:"immutable,omitempty"`
+	Embedding      []float64   `json:"embedding,omitempty"` // Only with SearchOptions.IncludeEmbeddings
+}
+
+// Text returns the memory's content, falling back to Data for Add events
+func (m Memory) Text() string {
+	if m.Memory != nil {
+		return *m.Memory
+	}
+	if m.Data != nil {
+		return m.Data.Memory
+	}
+	return ""
 }
 
 // MemoryHistory represents memory change history
@@ -132,6 +162,13 @@ type MemoryHistory struct {
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+// UpdateRequest describes the changes UpdateMemory makes to a memory. Nil
+// fields are left unchanged.
+type UpdateRequest struct {
+	Text     *string                `json:"text,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"` // Replaces the memory's metadata
+}
+
 // MemoryUpdateBody represents data for batch memory updates
 type MemoryUpdateBody struct {
 	MemoryID string `json:"memoryId"`
@@ -157,6 +194,13 @@ type AllUsers struct {
 	Previous interface{} `json:"previous"`
 }
 
+// UsersOptions selects a page of entities and filters them by type
+type UsersOptions struct {
+	Page     *int    `json:"page,omitempty"`
+	PageSize *int    `json:"page_size,omitempty"`
+	Type     *string `json:"type,omitempty"` // EntityUser, EntityAgent, EntityApp or EntityRun
+}
+
 // ProjectResponse represents project data
 type ProjectResponse struct {
 	CustomInstructions *string                `json:"custom_instructions,omitempty"`
@@ -164,6 +208,13 @@ type ProjectResponse struct {
 	Additional         map[string]interface{} `json:"-"` // For other fields
 }
 
+// Category is a custom memory category. Description tells the platform
+// which memories belong in it.
+type Category struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
 // PromptUpdatePayload represents data for updating project prompts
 type PromptUpdatePayload struct {
 	CustomInstructions *string                  `json:"custom_instructions,omitempty"`
@@ -205,6 +256,41 @@ type DeleteUsersParams struct {
 	AgentID *string `json:"agent_id,omitempty"`
 	AppID   *string `json:"app_id,omitempty"`
 	RunID   *string `json:"run_id,omitempty"`
+
+	Concurrency int `json:"-"` // Optional: deletions in flight, DefaultBatchConcurrency when zero
+}
+
+// DeleteUsersResult is the outcome of DeleteUsers
+type DeleteUsersResult struct {
+	Message  string
+	Entities []EntityDeletion // One per entity, in deletion order
+}
+
+// EntityDeletion is the outcome of deleting one entity
+type EntityDeletion struct {
+	Type string // "user", "agent", "app" or "run"
+	Name string
+	Err  error
+}
+
+// Failed returns the entities that could not be deleted
+func (r *DeleteUsersResult) Failed() []EntityDeletion {
+	var failed []EntityDeletion
+	for _, entity := range r.Entities {
+		if entity.Err != nil {
+			failed = append(failed, entity)
+		}
+	}
+	return failed
+}
+
+// Err joins the errors of the failed deletions, or returns nil
+func (r *DeleteUsersResult) Err() error {
+	var errs []error
+	for _, entity := range r.Failed() {
+		errs = append(errs, fmt.Errorf("failed to delete %s %s: %w", entity.Type, entity.Name, entity.Err))
+	}
+	return errors.Join(errs...)
 }
 
 // DeleteUserData represents deprecated user deletion data
@@ -223,6 +309,12 @@ type MessageResponse struct {
 	Message string `json:"message"`
 }
 
+// DeleteAllResult is the outcome of DeleteAll
+type DeleteAllResult struct {
+	MessageResponse
+	Deleted int `json:"deleted"` // Memories removed, as reported by the API
+}
+
 type PingResponse struct {
 	Status    string `json:"status"`
 	OrgID     string `json:"org_id,omitempty"`
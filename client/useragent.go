@@ -0,0 +1,40 @@
+package client
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// defaultUserAgent identifies the SDK, Go version and platform, e.g.
+// "go-mem0/0.1.0 (go1.24.1; linux/amd64)"
+var defaultUserAgent = fmt.Sprintf("go-mem0/%s (%s; %s/%s)", Version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+
+// WithAppInfo appends "name/version" to the User-Agent of the derived
+// client, so the platform and proxies can attribute its traffic to the
+// application. Calling it again replaces the suffix.
+func WithAppInfo(name, version string) Option {
+	return func(c *MemoryClient) {
+		c.userAgent = defaultUserAgent
+		if name = strings.TrimSpace(name); name != "" {
+			c.userAgent += " " + appToken(name, version)
+		}
+	}
+}
+
+// appToken formats name and version as a User-Agent product token,
+// replacing characters not allowed in one
+func appToken(name, version string) string {
+	clean := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if r <= ' ' || r >= 0x7f || strings.ContainsRune(`()<>@,;:\"/[]?={}`, r) {
+				return '-'
+			}
+			return r
+		}, strings.TrimSpace(s))
+	}
+	if version = strings.TrimSpace(version); version == "" {
+		return clean(name)
+	}
+	return clean(name) + "/" + clean(version)
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	c, captured := newStubClient(t, http.StatusOK, `[]`)
+	ctx := context.Background()
+
+	if _, err := c.Search(ctx, "tea"); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	ua := captured.Header.Get("User-Agent")
+	if !regexp.MustCompile(`^go-mem0/\d+\.\d+\.\d+ \(go[^;]+; \w+/\w+\)$`).MatchString(ua) {
+		t.Errorf("User-Agent = %q, want go-mem0/<version> (<go version>; <os>/<arch>)", ua)
+	}
+
+	app := c.With(WithAppInfo("my agent", "2.1"))
+	if _, err := app.Search(ctx, "tea"); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got, want := captured.Header.Get("User-Agent"), ua+" my-agent/2.1"; got != want {
+		t.Errorf("User-Agent = %q, want %q", got, want)
+	}
+
+	if got := app.With(WithAppInfo("other", "")).userAgent; got != ua+" other" {
+		t.Errorf("replaced User-Agent = %q, want %q", got, ua+" other")
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultUsersPageSize is the page size ForEachUser requests when options
+// set none
+const DefaultUsersPageSize = 100
+
+// ForEachUser calls fn for every entity matching options, fetching one page
+// at a time until the API reports no next page. Iteration starts at
+// options.Page, or the first page, and stops at the first error from the API
+// or fn.
+func ForEachUser(ctx context.Context, c Client, options UsersOptions, fn func(User) error) error {
+	page, pageSize := 1, DefaultUsersPageSize
+	if options.Page != nil {
+		page = *options.Page
+	}
+	if options.PageSize != nil {
+		pageSize = *options.PageSize
+	}
+
+	for {
+		opts := options
+		opts.Page, opts.PageSize = &page, &pageSize
+		users, err := c.Users(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, user := range users.Results {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+		if users.Next == nil {
+			return nil
+		}
+		page++
+	}
+}
+
+// GetEntity returns the entity of the given type and name, including its
+// memory count. Only entities of that type are listed, and paging stops at
+// the match.
+func (c *MemoryClient) GetEntity(ctx context.Context, entityType, name string) (*User, error) {
+	if name == "" {
+		return nil, NewValidationError("name", "entity name is required")
+	}
+
+	var entity *User
+	found := errors.New("found")
+	err := ForEachUser(ctx, c, UsersOptions{Type: &entityType}, func(user User) error {
+		if user.Name != name {
+			return nil
+		}
+		entity = &user
+		return found
+	})
+	if err != nil && err != found {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, fmt.Errorf("%s %s: %w", entityType, name, ErrEntityNotFound)
+	}
+	return entity, nil
+}
+
+// GetUser returns the user entity named name
+func (c *MemoryClient) GetUser(ctx context.Context, name string) (*User, error) {
+	return c.GetEntity(ctx, EntityUser, name)
+}
+
+// MemoriesForEntity lists the memories of the entity of the given type and
+// name, setting the matching ID filter on options
+func (c *MemoryClient) MemoriesForEntity(ctx context.Context, entityType, name string, options ...SearchOptions) ([]Memory, error) {
+	scope := &Scope{client: c, Entity: entityType, ID: name}
+	return scope.GetAll(ctx, options...)
+}
@@ -19,3 +19,11 @@ func parseResponse(response interface{}, target interface{}) error {
 
 	return nil
 }
+
+// outputFormat returns the response format to request for o
+func outputFormat(o MemoryOptions) OutputFormat {
+	if o.OutputFormat != nil {
+		return *o.OutputFormat
+	}
+	return DefaultOutputFormat
+}
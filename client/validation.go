@@ -0,0 +1,182 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxIncludesLength is the longest Includes or Excludes value, in characters,
+// that Validate accepts
+const MaxIncludesLength = 1000
+
+// Validate rejects invalid option combinations before a request is sent
+func (o MemoryOptions) Validate() error {
+	if err := o.validateAPIVersion(); err != nil {
+		return err
+	}
+	if (o.Page == nil) != (o.PageSize == nil) {
+		if o.Page == nil {
+			return NewValidationError("page", "page is required when page_size is set")
+		}
+		return NewValidationError("page_size", "page_size is required when page is set")
+	}
+	if o.Page != nil && *o.Page < 1 {
+		return NewValidationError("page", fmt.Sprintf("must be at least 1, got %d", *o.Page))
+	}
+	if o.PageSize != nil && *o.PageSize < 1 {
+		return NewValidationError("page_size", fmt.Sprintf("must be at least 1, got %d", *o.PageSize))
+	}
+	if o.OrgName != nil && o.OrgID != nil {
+		return NewValidationError("org_id", "org_name and org_id cannot both be set; use org_id")
+	}
+	if o.ProjectName != nil && o.ProjectID != nil {
+		return NewValidationError("project_id", "project_name and project_id cannot both be set; use project_id")
+	}
+	if err := validateDate("start_date", o.StartDate); err != nil {
+		return err
+	}
+	if err := validateDate("end_date", o.EndDate); err != nil {
+		return err
+	}
+	if o.ExpirationDate != nil {
+		if _, err := time.Parse(time.DateOnly, *o.ExpirationDate); err != nil {
+			return NewValidationError("expiration_date", fmt.Sprintf("must be a YYYY-MM-DD date, got %q", *o.ExpirationDate))
+		}
+	}
+	if err := validateIncludes("includes", o.Includes); err != nil {
+		return err
+	}
+	if err := validateIncludes("excludes", o.Excludes); err != nil {
+		return err
+	}
+	if o.CustomInstructions != nil {
+		if err := validateCustomInstructions(*o.CustomInstructions); err != nil {
+			return err
+		}
+	}
+	if o.StartDate != nil && o.EndDate != nil {
+		start, _ := parseDate(*o.StartDate)
+		end, _ := parseDate(*o.EndDate)
+		if end.Before(start) {
+			return NewValidationError("end_date", "must not be before start_date")
+		}
+	}
+	return nil
+}
+
+// Validate rejects invalid option combinations before a request is sent
+func (o SearchOptions) Validate() error {
+	if err := o.MemoryOptions.Validate(); err != nil {
+		return err
+	}
+	if o.Threshold != nil && (*o.Threshold < 0 || *o.Threshold > 1) {
+		return NewValidationError("threshold", fmt.Sprintf("must be between 0 and 1, got %v", *o.Threshold))
+	}
+	if o.Limit != nil && *o.Limit < 1 {
+		return NewValidationError("limit", fmt.Sprintf("must be at least 1, got %d", *o.Limit))
+	}
+	if o.TopK != nil && *o.TopK < 1 {
+		return NewValidationError("top_k", fmt.Sprintf("must be at least 1, got %d", *o.TopK))
+	}
+	if o.IncludeExpired != nil && o.ExpiredOnly != nil && !*o.IncludeExpired && *o.ExpiredOnly {
+		return NewValidationError("expired_only", "expired_only contradicts include_expired false")
+	}
+	if err := validateFields(o.Fields); err != nil {
+		return err
+	}
+	if version, _ := o.apiVersion(); o.Filters != nil && version != APIVersionV2 {
+		return NewValidationError("filters", "filters require api_version v2")
+	}
+	return nil
+}
+
+// Validate rejects invalid pagination and unknown entity types
+func (o UsersOptions) Validate() error {
+	if (o.Page == nil) != (o.PageSize == nil) {
+		if o.Page == nil {
+			return NewValidationError("page", "page is required when page_size is set")
+		}
+		return NewValidationError("page_size", "page_size is required when page is set")
+	}
+	if o.Page != nil && *o.Page < 1 {
+		return NewValidationError("page", fmt.Sprintf("must be at least 1, got %d", *o.Page))
+	}
+	if o.PageSize != nil && *o.PageSize < 1 {
+		return NewValidationError("page_size", fmt.Sprintf("must be at least 1, got %d", *o.PageSize))
+	}
+	if o.Type != nil {
+		switch *o.Type {
+		case EntityUser, EntityAgent, EntityApp, EntityRun:
+		default:
+			return NewValidationError("type", fmt.Sprintf("unknown entity type %q", *o.Type))
+		}
+	}
+	return nil
+}
+
+// Validate rejects requests that change nothing or blank the memory's text
+func (r UpdateRequest) Validate() error {
+	if r.Text == nil && r.Metadata == nil {
+		return NewValidationError("text", "text or metadata is required")
+	}
+	if r.Text != nil && strings.TrimSpace(*r.Text) == "" {
+		return NewValidationError("text", "must not be empty")
+	}
+	return nil
+}
+
+// Validate rejects categories without a usable name or description
+func (c Category) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return NewValidationError("name", "category name is required")
+	}
+	if len(c.Name) > MaxCategoryNameLength {
+		return NewValidationError("name", fmt.Sprintf("must be at most %d characters, got %d", MaxCategoryNameLength, len(c.Name)))
+	}
+	if strings.ContainsFunc(c.Name, func(r rune) bool {
+		return !(r == '_' || r == '-' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		return NewValidationError("name", fmt.Sprintf("may only contain letters, digits, '_' and '-', got %q", c.Name))
+	}
+	if strings.TrimSpace(c.Description) == "" {
+		return NewValidationError("description", fmt.Sprintf("category %s needs a description", c.Name))
+	}
+	return nil
+}
+
+// validateDate checks that an optional date field parses
+func validateDate(field string, value *string) error {
+	if value == nil {
+		return nil
+	}
+	if _, ok := parseDate(*value); !ok {
+		return NewValidationError(field, fmt.Sprintf("must be an RFC 3339 timestamp or YYYY-MM-DD date, got %q", *value))
+	}
+	return nil
+}
+
+// validateIncludes checks that an optional includes/excludes rule is neither
+// blank nor too long. A blank exclusion would silently exclude nothing.
+func validateIncludes(field string, value *string) error {
+	if value == nil {
+		return nil
+	}
+	if strings.TrimSpace(*value) == "" {
+		return NewValidationError(field, "must not be empty")
+	}
+	if n := len([]rune(*value)); n > MaxIncludesLength {
+		return NewValidationError(field, fmt.Sprintf("must be at most %d characters, got %d", MaxIncludesLength, n))
+	}
+	return nil
+}
+
+// validateFields rejects blank names in a fields projection
+func validateFields(fields []string) error {
+	for _, field := range fields {
+		if strings.TrimSpace(field) == "" {
+			return NewValidationError("fields", "field names must not be empty")
+		}
+	}
+	return nil
+}
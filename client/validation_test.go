@@ -1,6 +1,11 @@
 package client
 
 import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -143,6 +148,27 @@ func TestPreparePayload(t *testing.T) {
 	}
 }
 
+func TestPreparePayloadIncludesExcludes(t *testing.T) {
+	options := MemoryOptions{
+		Includes: stringPtr("food preferences"),
+		Excludes: stringPtr("passwords and API keys"),
+	}
+	payload := (&MemoryClient{}).preparePayload(nil, options)
+	if payload["includes"] != "food preferences" {
+		t.Errorf("payload includes = %v, want food preferences", payload["includes"])
+	}
+	if payload["excludes"] != "passwords and API keys" {
+		t.Errorf("payload excludes = %v, want passwords and API keys", payload["excludes"])
+	}
+
+	payload = (&MemoryClient{}).preparePayload(nil, MemoryOptions{})
+	for _, key := range []string{"includes", "excludes"} {
+		if _, ok := payload[key]; ok {
+			t.Errorf("payload should omit unset %s", key)
+		}
+	}
+}
+
 func TestPrepareParams(t *testing.T) {
 	client := &MemoryClient{}
 
@@ -167,6 +193,64 @@ func TestPrepareParams(t *testing.T) {
 	}
 }
 
+func TestPrepareParamsSearchOptions(t *testing.T) {
+	limit, topK, threshold := 5, 3, 0.25
+	options := SearchOptions{
+		MemoryOptions: MemoryOptions{
+			UserID:    stringPtr("alex"),
+			AgentID:   stringPtr("planner"),
+			AppID:     stringPtr("web"),
+			RunID:     stringPtr("run-1"),
+			OrgID:     "org-1",
+			ProjectID: 42,
+			StartDate: stringPtr("2024-07-01"),
+			EndDate:   stringPtr("2024-07-08"),
+		},
+		Limit:                   &limit,
+		TopK:                    &topK,
+		Threshold:               &threshold,
+		EnableGraph:             boolPtr(true),
+		OnlyMetadataBasedSearch: boolPtr(false),
+		KeywordSearch:           boolPtr(true),
+		Fields:                  []string{"id", "memory"},
+		Categories:              []string{"food", "travel"},
+		Rerank:                  boolPtr(true),
+		FilterMemories:          boolPtr(false),
+		IncludeExpired:          boolPtr(true),
+		ExpiredOnly:             boolPtr(false),
+	}
+
+	want := url.Values{
+		"user_id":                    {"alex"},
+		"agent_id":                   {"planner"},
+		"app_id":                     {"web"},
+		"run_id":                     {"run-1"},
+		"org_id":                     {"org-1"},
+		"project_id":                 {"42"},
+		"start_date":                 {"2024-07-01"},
+		"end_date":                   {"2024-07-08"},
+		"limit":                      {"5"},
+		"top_k":                      {"3"},
+		"threshold":                  {"0.25"},
+		"enable_graph":               {"true"},
+		"only_metadata_based_search": {"false"},
+		"keyword_search":             {"true"},
+		"fields":                     {"id,memory"},
+		"categories":                 {"food,travel"},
+		"rerank":                     {"true"},
+		"filter_memories":            {"false"},
+		"include_expired":            {"true"},
+		"expired_only":               {"false"},
+	}
+	if got := (&MemoryClient{}).prepareParams(options); !reflect.DeepEqual(got, want) {
+		t.Errorf("prepareParams() = %v, want %v", got, want)
+	}
+
+	if got := (&MemoryClient{}).prepareParams(SearchOptions{}); len(got) != 0 {
+		t.Errorf("prepareParams(empty) = %v, want no parameters", got)
+	}
+}
+
 func TestErrorTypes(t *testing.T) {
 	t.Run("APIError", func(t *testing.T) {
 		err := NewAPIError("test message", 400, "response body")
@@ -237,3 +321,156 @@ func TestValidateMemoryObject(t *testing.T) {
 	// Test with invalid user ID should be handled by the test framework
 	// We can't easily test t.Error calls without more complex setup
 }
+
+func TestOptionsValidate(t *testing.T) {
+	v1 := APIVersionV1
+	v2 := APIVersionV2
+	v3 := APIVersion("v3")
+	page := 1
+	zero := 0
+	threshold := 0.5
+	badThreshold := 1.5
+
+	tests := []struct {
+		name     string
+		options  SearchOptions
+		errField string
+	}{
+		{
+			name:    "empty options",
+			options: SearchOptions{},
+		},
+		{
+			name:    "page with page size",
+			options: SearchOptions{MemoryOptions: MemoryOptions{Page: &page, PageSize: &page}},
+		},
+		{
+			name:     "page without page size",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{Page: &page}},
+			errField: "page_size",
+		},
+		{
+			name:     "page size without page",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{PageSize: &page}},
+			errField: "page",
+		},
+		{
+			name:     "zero page",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{Page: &zero, PageSize: &page}},
+			errField: "page",
+		},
+		{
+			name:     "org name and org id",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{OrgName: stringPtr("org"), OrgID: "org-1"}},
+			errField: "org_id",
+		},
+		{
+			name:     "project name and project id",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{ProjectName: stringPtr("proj"), ProjectID: 7}},
+			errField: "project_id",
+		},
+		{
+			name:    "threshold in range",
+			options: SearchOptions{Threshold: &threshold},
+		},
+		{
+			name:     "threshold out of range",
+			options:  SearchOptions{Threshold: &badThreshold},
+			errField: "threshold",
+		},
+		{
+			name:     "zero limit",
+			options:  SearchOptions{Limit: &zero},
+			errField: "limit",
+		},
+		{
+			name:     "zero top k",
+			options:  SearchOptions{TopK: &zero},
+			errField: "top_k",
+		},
+		{
+			name:     "filters without api version",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{Filters: map[string]interface{}{"user_id": "alex"}}},
+			errField: "filters",
+		},
+		{
+			name:     "filters with v1",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{APIVersion: &v1, Filters: map[string]interface{}{"user_id": "alex"}}},
+			errField: "filters",
+		},
+		{
+			name:    "filters with v2",
+			options: SearchOptions{MemoryOptions: MemoryOptions{APIVersion: &v2, Filters: map[string]interface{}{"user_id": "alex"}}},
+		},
+		{
+			name:    "filters with deprecated version v2",
+			options: SearchOptions{MemoryOptions: MemoryOptions{Version: &v2, Filters: map[string]interface{}{"user_id": "alex"}}},
+		},
+		{
+			name:    "includes and excludes",
+			options: SearchOptions{MemoryOptions: MemoryOptions{Includes: stringPtr("food preferences"), Excludes: stringPtr("credentials")}},
+		},
+		{
+			name:     "blank excludes",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{Excludes: stringPtr("  ")}},
+			errField: "excludes",
+		},
+		{
+			name:     "includes too long",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{Includes: stringPtr(strings.Repeat("x", MaxIncludesLength+1))}},
+			errField: "includes",
+		},
+		{
+			name:     "unknown api version",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{APIVersion: &v3}},
+			errField: "api_version",
+		},
+		{
+			name:     "conflicting versions",
+			options:  SearchOptions{MemoryOptions: MemoryOptions{APIVersion: &v2, Version: &v1}},
+			errField: "version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if tt.errField == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Validate() error = %v, want *ValidationError", err)
+			}
+			if validationErr.Field != tt.errField {
+				t.Errorf("Validate() error field = %v, want %v", validationErr.Field, tt.errField)
+			}
+		})
+	}
+}
+
+func TestMethodsValidateBeforeRequest(t *testing.T) {
+	// No host is reachable, so any request would fail with a non-validation error
+	client := &MemoryClient{host: "http://127.0.0.1:0", httpClient: http.DefaultClient, headers: map[string]string{}}
+	threshold := 2.0
+
+	_, err := client.Search(context.Background(), "query", SearchOptions{Threshold: &threshold})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Search() error = %v, want *ValidationError", err)
+	}
+
+	page := 1
+	_, err = client.Add(context.Background(), nil, MemoryOptions{Page: &page})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Add() error = %v, want *ValidationError", err)
+	}
+
+	entityType := "group"
+	_, err = client.Users(context.Background(), UsersOptions{Type: &entityType})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Users() error = %v, want *ValidationError", err)
+	}
+}
@@ -0,0 +1,118 @@
+package client
+
+import "fmt"
+
+// route is the HTTP method and path of an operation in one API version
+type route struct {
+	Method string
+	Path   string
+}
+
+// Routing tables for the operations the API serves in more than one version.
+// Every other operation only exists in v1.
+var (
+	searchRoutes = map[APIVersion]route{
+		APIVersionV1: {Method: "POST", Path: "/v1/memories/search/"},
+		APIVersionV2: {Method: "POST", Path: "/v2/memories/search/"},
+	}
+	getAllRoutes = map[APIVersion]route{
+		APIVersionV1: {Method: "GET", Path: "/v1/memories/"},
+		APIVersionV2: {Method: "POST", Path: "/v2/memories/"},
+	}
+	deleteAllRoutes = map[APIVersion]route{
+		APIVersionV1: {Method: "DELETE", Path: "/v1/memories/"},
+		APIVersionV2: {Method: "DELETE", Path: "/v2/memories/"},
+	}
+)
+
+// WithAPIVersion selects the API version of the call and returns o for
+// chaining. It replaces the deprecated Version field.
+func (o *MemoryOptions) WithAPIVersion(version APIVersion) *MemoryOptions {
+	o.APIVersion = &version
+	o.Version = nil
+	return o
+}
+
+// apiVersion resolves the API version of the call from APIVersion, then the
+// deprecated Version, and reports whether the caller chose it explicitly.
+// Calls that set neither use v1.
+func (o MemoryOptions) apiVersion() (APIVersion, bool) {
+	switch {
+	case o.APIVersion != nil:
+		return *o.APIVersion, true
+	case o.Version != nil:
+		return *o.Version, true
+	default:
+		return APIVersionV1, false
+	}
+}
+
+// validateAPIVersion rejects unknown versions and conflicting version fields
+func (o MemoryOptions) validateAPIVersion() error {
+	for field, version := range map[string]*APIVersion{"api_version": o.APIVersion, "version": o.Version} {
+		if version != nil && *version != APIVersionV1 && *version != APIVersionV2 {
+			return NewValidationError(field, fmt.Sprintf("must be %s or %s, got %q", APIVersionV1, APIVersionV2, *version))
+		}
+	}
+	if o.APIVersion != nil && o.Version != nil && *o.APIVersion != *o.Version {
+		return NewValidationError("version", "version conflicts with api_version; use WithAPIVersion")
+	}
+	return nil
+}
+
+// route picks the endpoint of operation for the call's API version. Choosing
+// v1 explicitly where v2 is available prints a deprecation warning once per
+// operation.
+func (c *MemoryClient) route(operation string, routes map[APIVersion]route, opts MemoryOptions) route {
+	version, explicit := opts.apiVersion()
+	if explicit && version == APIVersionV1 {
+		if _, warned := c.deprecationWarnings.LoadOrStore(operation, true); !warned {
+			fmt.Printf("Warning: the v1 %s endpoint is deprecated; use WithAPIVersion(APIVersionV2) instead.\n", operation)
+		}
+	}
+	return routes[version]
+}
+
+// v2Filters returns the filters of a v2 call. Entity IDs set alongside
+// Filters are combined with them, so the scope is never dropped.
+func v2Filters(o MemoryOptions) map[string]interface{} {
+	var clauses []interface{}
+	entities := []struct {
+		key   string
+		value *string
+	}{{"user_id", o.UserID}, {"agent_id", o.AgentID}, {"app_id", o.AppID}, {"run_id", o.RunID}}
+	for _, entity := range entities {
+		if entity.value != nil {
+			clauses = append(clauses, map[string]interface{}{entity.key: *entity.value})
+		}
+	}
+	if date := dateFilter(o); date != nil {
+		clauses = append(clauses, date)
+	}
+	if len(clauses) == 0 {
+		return o.Filters
+	}
+	if o.Filters != nil {
+		clauses = append(clauses, o.Filters)
+	}
+	if len(clauses) == 1 {
+		return clauses[0].(map[string]interface{})
+	}
+	return map[string]interface{}{"AND": clauses}
+}
+
+// dateFilter returns the v2 filter clause restricting created_at to the
+// StartDate/EndDate window, or nil when neither is set
+func dateFilter(o MemoryOptions) map[string]interface{} {
+	if o.StartDate == nil && o.EndDate == nil {
+		return nil
+	}
+	window := map[string]interface{}{}
+	if o.StartDate != nil {
+		window["gte"] = *o.StartDate
+	}
+	if o.EndDate != nil {
+		window["lte"] = *o.EndDate
+	}
+	return map[string]interface{}{"created_at": window}
+}
@@ -0,0 +1,44 @@
+package client
+
+import "testing"
+
+func TestRoute(t *testing.T) {
+	v1, v2 := APIVersionV1, APIVersionV2
+
+	tests := []struct {
+		name     string
+		options  MemoryOptions
+		want     route
+		wantWarn bool
+	}{
+		{name: "default", options: MemoryOptions{}, want: getAllRoutes[APIVersionV1]},
+		{name: "explicit v1", options: MemoryOptions{APIVersion: &v1}, want: getAllRoutes[APIVersionV1], wantWarn: true},
+		{name: "v2", options: *(&MemoryOptions{}).WithAPIVersion(APIVersionV2), want: getAllRoutes[APIVersionV2]},
+		{name: "deprecated version field", options: MemoryOptions{Version: &v2}, want: getAllRoutes[APIVersionV2]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &MemoryClient{}
+			if got := c.route("get all", getAllRoutes, tt.options); got != tt.want {
+				t.Errorf("route() = %+v, want %+v", got, tt.want)
+			}
+			if _, warned := c.deprecationWarnings.Load("get all"); warned != tt.wantWarn {
+				t.Errorf("warned = %v, want %v", warned, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestWithAPIVersionClearsVersion(t *testing.T) {
+	v1 := APIVersionV1
+	options := MemoryOptions{Version: &v1}
+	options.WithAPIVersion(APIVersionV2)
+
+	if options.Version != nil || *options.APIVersion != APIVersionV2 {
+		t.Errorf("options = %+v, want api_version v2 only", options)
+	}
+	if err := options.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
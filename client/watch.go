@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// MemoryEvent describes a change to a memory
+type MemoryEvent struct {
+	Event     WebhookEvent `json:"event"`
+	Memory    Memory       `json:"memory"`
+	Previous  *Memory      `json:"previous,omitempty"` // State before an update or delete
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// WatchOptions configures WatchMemories
+type WatchOptions struct {
+	SearchOptions
+	Interval        time.Duration // Poll interval, 5 seconds when zero
+	IncludeExisting bool          // Report memories present at start as added
+}
+
+// WatchMemories polls GetAll and calls handle for every memory added, updated
+// or deleted between polls, until ctx is cancelled or a poll fails. It
+// returns ctx.Err() on cancellation.
+func WatchMemories(ctx context.Context, c Client, options WatchOptions, handle func(MemoryEvent)) error {
+	interval := options.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	var previous map[string]Memory
+	if !options.IncludeExisting {
+		memories, err := c.GetAll(ctx, options.SearchOptions)
+		if err != nil {
+			return err
+		}
+		previous = indexMemories(memories)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if previous != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+
+		memories, err := c.GetAll(ctx, options.SearchOptions)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		current := indexMemories(memories)
+		for _, event := range diffMemories(previous, memories, current, time.Now()) {
+			handle(event)
+		}
+		previous = current
+	}
+}
+
+// indexMemories maps memories by ID
+func indexMemories(memories []Memory) map[string]Memory {
+	index := make(map[string]Memory, len(memories))
+	for _, memory := range memories {
+		index[memory.ID] = memory
+	}
+	return index
+}
+
+// diffMemories compares two snapshots. Events follow the order of the
+// current listing, with deletions last in ID order.
+func diffMemories(previous map[string]Memory, memories []Memory, current map[string]Memory, now time.Time) []MemoryEvent {
+	var events []MemoryEvent
+	for _, memory := range memories {
+		old, existed := previous[memory.ID]
+		switch {
+		case !existed:
+			events = append(events, MemoryEvent{Event: WebhookEventMemoryAdded, Memory: memory, Timestamp: now})
+		case memoryChanged(old, memory):
+			events = append(events, MemoryEvent{Event: WebhookEventMemoryUpdated, Memory: memory, Previous: &old, Timestamp: now})
+		}
+	}
+	var deleted []string
+	for id := range previous {
+		if _, exists := current[id]; !exists {
+			deleted = append(deleted, id)
+		}
+	}
+	sort.Strings(deleted)
+	for _, id := range deleted {
+		old := previous[id]
+		events = append(events, MemoryEvent{Event: WebhookEventMemoryDeleted, Memory: old, Previous: &old, Timestamp: now})
+	}
+	return events
+}
+
+// memoryChanged reports whether a memory's content changed between snapshots
+func memoryChanged(old, memory Memory) bool {
+	if old.Hash != nil && memory.Hash != nil {
+		return *old.Hash != *memory.Hash
+	}
+	if (old.Memory == nil) != (memory.Memory == nil) || (old.Memory != nil && *old.Memory != *memory.Memory) {
+		return true
+	}
+	if old.UpdatedAt != nil && memory.UpdatedAt != nil {
+		return !old.UpdatedAt.Equal(*memory.UpdatedAt)
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+)
+
+func TestWatchMemoriesReportsChanges(t *testing.T) {
+	snapshots := [][]client.Memory{
+		{{ID: "mem-1", Memory: strPtr("Likes tea")}, {ID: "mem-2", Memory: strPtr("Lives in Lisbon")}},
+		{{ID: "mem-1", Memory: strPtr("Likes coffee")}, {ID: "mem-3", Memory: strPtr("Has a cat")}},
+	}
+
+	var mu sync.Mutex
+	polls := 0
+	mock := &clienttest.MockClient{
+		GetAllFunc: func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			snapshot := snapshots[min(polls, len(snapshots)-1)]
+			polls++
+			return snapshot, nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var events []client.MemoryEvent
+	err := client.WatchMemories(ctx, mock, client.WatchOptions{Interval: time.Millisecond}, func(event client.MemoryEvent) {
+		events = append(events, event)
+		if len(events) == 3 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("WatchMemories() error = %v, want context.Canceled", err)
+	}
+
+	want := []struct {
+		event client.WebhookEvent
+		id    string
+	}{
+		{client.WebhookEventMemoryUpdated, "mem-1"},
+		{client.WebhookEventMemoryAdded, "mem-3"},
+		{client.WebhookEventMemoryDeleted, "mem-2"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, w := range want {
+		if events[i].Event != w.event || events[i].Memory.ID != w.id {
+			t.Errorf("event %d = %s %s, want %s %s", i, events[i].Event, events[i].Memory.ID, w.event, w.id)
+		}
+	}
+	if events[0].Previous == nil || *events[0].Previous.Memory != "Likes tea" {
+		t.Errorf("update event Previous = %+v, want old text", events[0].Previous)
+	}
+}
+
+func TestWatchMemoriesIncludeExisting(t *testing.T) {
+	mock := &clienttest.MockClient{
+		GetAllFunc: func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+			return []client.Memory{{ID: "mem-1", Memory: strPtr("Likes tea")}}, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var events []client.MemoryEvent
+	client.WatchMemories(ctx, mock, client.WatchOptions{Interval: time.Millisecond, IncludeExisting: true}, func(event client.MemoryEvent) {
+		events = append(events, event)
+		cancel()
+	})
+
+	if len(events) != 1 || events[0].Event != client.WebhookEventMemoryAdded {
+		t.Errorf("events = %+v, want one add for the existing memory", events)
+	}
+}
+
+func TestWatchMemoriesStopsOnError(t *testing.T) {
+	mock := &clienttest.MockClient{
+		GetAllFunc: func(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error) {
+			return nil, client.NewAPIError("unauthorized", 401, "")
+		},
+	}
+
+	err := client.WatchMemories(context.Background(), mock, client.WatchOptions{}, func(client.MemoryEvent) {})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("WatchMemories() error = %v, want APIError", err)
+	}
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MaxWebhookBodySize bounds the webhook deliveries WebhookHandler reads
+const MaxWebhookBodySize = 1 << 20
+
+// ErrWebhookUnauthenticated is returned by a WebhookVerifier for deliveries
+// that do not prove they come from Mem0
+var ErrWebhookUnauthenticated = errors.New("webhook delivery is not authenticated")
+
+// WebhookVerifier authenticates a webhook delivery before it is decoded.
+// body is the delivery's payload. Any error rejects the delivery with 401
+// Unauthorized.
+type WebhookVerifier func(r *http.Request, body []byte) error
+
+// WebhookToken returns a WebhookVerifier that accepts deliveries carrying
+// token in the "token" query parameter or as a Bearer Authorization header.
+// Register the webhook URL with the token, e.g.
+// https://your-host/webhooks/mem0?token=..., and keep it secret.
+func WebhookToken(token string) WebhookVerifier {
+	return func(r *http.Request, body []byte) error {
+		got := r.URL.Query().Get("token")
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			got = bearer
+		}
+		if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return ErrWebhookUnauthenticated
+		}
+		return nil
+	}
+}
+
+// ParseWebhook decodes a webhook delivery into a MemoryEvent. It accepts the
+// platform's payload,
+//
+//	{"event_details": {"id": "...", "data": {"memory": "..."}, "event": "ADD"}}
+//
+// and MemoryEvent JSON as produced by WatchMemories. Deliveries without a
+// timestamp are stamped with the time of parsing.
+func ParseWebhook(body []byte) (MemoryEvent, error) {
+	var payload struct {
+		MemoryEvent
+		EventDetails *struct {
+			Memory
+			Event Event `json:"event"`
+		} `json:"event_details"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return MemoryEvent{}, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	event := payload.MemoryEvent
+	if details := payload.EventDetails; details != nil {
+		event = MemoryEvent{Memory: details.Memory}
+		switch details.Event {
+		case EventAdd:
+			event.Event = WebhookEventMemoryAdded
+		case EventUpdate:
+			event.Event = WebhookEventMemoryUpdated
+		case EventDelete:
+			event.Event = WebhookEventMemoryDeleted
+		case "":
+		default:
+			// Keep events added by newer API versions; IsKnown reports them
+			event.Event = WebhookEvent("memory_" + strings.ToLower(string(details.Event)))
+		}
+	}
+	if event.Event == "" || event.Memory.ID == "" {
+		return MemoryEvent{}, fmt.Errorf("invalid webhook payload: event and memory ID are required")
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	return event, nil
+}
+
+// WebhookHandler returns an http.Handler that receives webhook deliveries,
+// decodes them with ParseWebhook and passes them to handle. Invalid
+// deliveries are answered with 400 Bad Request, and deliveries a verifier
+// rejects with 401 Unauthorized. Without a verifier any request is trusted,
+// so the handler must then sit behind something that authenticates it.
+func WebhookHandler(handle func(MemoryEvent), verify ...WebhookVerifier) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, MaxWebhookBodySize))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		for _, verifier := range verify {
+			if err := verifier(r, body); err != nil {
+				http.Error(w, "unauthenticated", http.StatusUnauthorized)
+				return
+			}
+		}
+		event, err := ParseWebhook(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		handle(event)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
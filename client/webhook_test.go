@@ -0,0 +1,125 @@
+package client_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+func TestParseWebhook(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantEvent client.WebhookEvent
+		wantID    string
+		wantText  string
+		wantErr   bool
+	}{
+		{
+			name:      "platform payload",
+			body:      `{"event_details": {"id": "mem-1", "data": {"memory": "Name is Alex"}, "event": "ADD"}}`,
+			wantEvent: client.WebhookEventMemoryAdded,
+			wantID:    "mem-1",
+			wantText:  "Name is Alex",
+		},
+		{
+			name:      "platform payload with unknown event",
+			body:      `{"event_details": {"id": "mem-1", "event": "archive"}}`,
+			wantEvent: "memory_archive",
+			wantID:    "mem-1",
+		},
+		{
+			name:      "memory event",
+			body:      `{"event": "memory_delete", "memory": {"id": "mem-2", "memory": "Likes tea"}, "timestamp": "2024-07-20T10:00:00Z"}`,
+			wantEvent: client.WebhookEventMemoryDeleted,
+			wantID:    "mem-2",
+			wantText:  "Likes tea",
+		},
+		{name: "missing memory ID", body: `{"event_details": {"event": "ADD"}}`, wantErr: true},
+		{name: "not JSON", body: `event=ADD`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := client.ParseWebhook([]byte(tt.body))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseWebhook() = %+v, want error", event)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWebhook() error = %v", err)
+			}
+			if event.Event != tt.wantEvent || event.Memory.ID != tt.wantID || event.Memory.Text() != tt.wantText || event.Timestamp.IsZero() {
+				t.Errorf("ParseWebhook() = %+v, want %s of %s %q", event, tt.wantEvent, tt.wantID, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var received []client.MemoryEvent
+	handler := client.WebhookHandler(func(event client.MemoryEvent) {
+		received = append(received, event)
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/mem0",
+		strings.NewReader(`{"event_details": {"id": "mem-1", "event": "UPDATE"}}`)))
+	if rec.Code != http.StatusNoContent || len(received) != 1 || received[0].Event != client.WebhookEventMemoryUpdated {
+		t.Errorf("delivery = %d, %+v; want 204 and one update", rec.Code, received)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/mem0", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusBadRequest || len(received) != 1 {
+		t.Errorf("invalid delivery = %d, want 400 and no event", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks/mem0", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET = %d, want 405", rec.Code)
+	}
+}
+
+func TestWebhookToken(t *testing.T) {
+	var received int
+	handler := client.WebhookHandler(func(event client.MemoryEvent) {
+		received++
+	}, client.WebhookToken("s3cret"))
+	body := `{"event_details": {"id": "mem-1", "event": "ADD"}}`
+
+	tests := []struct {
+		name   string
+		target string
+		header string
+		want   int
+	}{
+		{"no token", "/webhooks/mem0", "", http.StatusUnauthorized},
+		{"wrong token", "/webhooks/mem0?token=guess", "", http.StatusUnauthorized},
+		{"query token", "/webhooks/mem0?token=s3cret", "", http.StatusNoContent},
+		{"bearer token", "/webhooks/mem0", "Bearer s3cret", http.StatusNoContent},
+		{"wrong bearer overrides query", "/webhooks/mem0?token=s3cret", "Bearer guess", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(body))
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+	if received != 2 {
+		t.Errorf("received %d events, want only the authenticated two", received)
+	}
+}
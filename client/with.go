@@ -0,0 +1,89 @@
+package client
+
+import "time"
+
+// Option overrides a setting of a client derived with With
+type Option func(*MemoryClient)
+
+// WithHost sends the derived client's requests to host. The derived client
+// pings the new host before its first request that needs the organization
+// and project, unless they were set explicitly.
+func WithHost(host string) Option {
+	return func(c *MemoryClient) {
+		c.host = host
+		c.telemetryID = ""
+		c.minSDKVersion = ""
+		if c.pingedScope {
+			c.organizationID = nil
+			c.projectID = nil
+			c.pingedScope = false
+		}
+	}
+}
+
+// WithProject sends the derived client's requests for another organization
+// and project
+func WithProject(orgID, projectID interface{}) Option {
+	return func(c *MemoryClient) {
+		c.organizationID = orgID
+		c.projectID = projectID
+		c.pingedScope = false
+	}
+}
+
+// WithTimeout bounds each request of the derived client by timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *MemoryClient) {
+		httpClient := *c.httpClient
+		httpClient.Timeout = timeout
+		c.httpClient = &httpClient
+	}
+}
+
+// With returns a shallow copy of the client with opts applied. The copy
+// shares the client's HTTP transport, and so its connection pool, which
+// makes deriving a client per request cheap, e.g. to route each tenant to
+// its own host or project.
+//
+// The copy starts with the client's API key or TokenSource and its Ping
+// result; SetAPIKey and ForceReauth on either client do not affect the
+// other.
+func (c *MemoryClient) With(opts ...Option) *MemoryClient {
+	c.keyMu.Lock()
+	apiKey, source := c.apiKey, c.tokenSource
+	c.keyMu.Unlock()
+
+	c.authMu.Lock()
+	telemetryID, pingedScope, minSDKVersion := c.telemetryID, c.pingedScope, c.minSDKVersion
+	orgID, projectID := c.organizationID, c.projectID
+	c.authMu.Unlock()
+
+	derived := &MemoryClient{
+		apiKey:           apiKey,
+		tokenSource:      source,
+		authScheme:       c.authScheme,
+		authHeader:       c.authHeader,
+		host:             c.host,
+		organizationName: c.organizationName,
+		projectName:      c.projectName,
+		organizationID:   orgID,
+		projectID:        projectID,
+		headers:          c.headers,
+		httpClient:       c.httpClient,
+		telemetryID:      telemetryID,
+		metadataSchema:   c.metadataSchema,
+		defaults:         c.defaults,
+		userAgent:        c.userAgent,
+		codec:            c.codec,
+		metrics:          c.metrics,
+		conns:            c.conns,
+		detectBodyLeaks:  c.detectBodyLeaks,
+		pingedScope:      pingedScope,
+		minSDKVersion:    minSDKVersion,
+		dedupeSearches:   c.dedupeSearches,
+	}
+	for _, opt := range opts {
+		opt(derived)
+	}
+	return derived
+}
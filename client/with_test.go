@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWith(t *testing.T) {
+	parent, parentRequest := newStubClient(t, 200, `[]`)
+	other, otherRequest := newStubClient(t, 200, `[]`)
+	ctx := context.Background()
+	search := func(c *MemoryClient) {
+		t.Helper()
+		if _, err := c.Search(ctx, "tea", SearchOptions{MemoryOptions: MemoryOptions{UserID: stringPtr("alex")}}); err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+	}
+
+	derived := parent.With(WithHost(other.host), WithTimeout(5*time.Second))
+	if derived.httpClient.Transport != parent.httpClient.Transport {
+		t.Error("derived client does not share the transport")
+	}
+	if derived.httpClient.Timeout != 5*time.Second || parent.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("timeouts = %v (derived), %v (parent), want 5s, %v", derived.httpClient.Timeout, parent.httpClient.Timeout, DefaultTimeout)
+	}
+
+	search(derived)
+	if otherRequest.Path == "" || parentRequest.Path != "" {
+		t.Error("derived Search() did not go to the new host only")
+	}
+	if derived.telemetry() == "" {
+		t.Error("derived client did not ping the new host")
+	}
+
+	tenant := derived.With(WithProject("org-2", "proj-2"))
+	search(tenant)
+	if otherRequest.Body["org_id"] != "org-2" || otherRequest.Body["project_id"] != "proj-2" {
+		t.Errorf("tenant Search() scope = %v/%v, want org-2/proj-2", otherRequest.Body["org_id"], otherRequest.Body["project_id"])
+	}
+
+	search(parent)
+	if parentRequest.Body["org_id"] != "org-1" {
+		t.Errorf("parent Search() org = %v, want org-1", parentRequest.Body["org_id"])
+	}
+}
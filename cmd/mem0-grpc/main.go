@@ -0,0 +1,119 @@
+// Command mem0-grpc serves the Mem0 memory operations over gRPC so services
+// in other languages can use them through standard gRPC tooling.
+//
+// The service definition is in proto/mem0/v1/memory.proto. With --tls-cert
+// and --tls-key the server uses TLS; adding --client-ca requires and verifies
+// client certificates (mTLS).
+//
+//	MEM0_API_KEY=m0-... mem0-grpc --listen :9090 \
+//		--tls-cert server.pem --tls-key server-key.pem --client-ca ca.pem
+package main
+
+//go:generate buf generate
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+	"github.com/murilopl/go-mem0/client"
+	mem0v1 "github.com/murilopl/go-mem0/cmd/mem0-grpc/gen/mem0/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run parses flags and serves until SIGINT or SIGTERM
+func run() error {
+	_ = godotenv.Load()
+
+	listen := flag.String("listen", ":9090", "address to listen on")
+	apiKey := flag.String("api-key", os.Getenv("MEM0_API_KEY"), "Mem0 API key (default $MEM0_API_KEY)")
+	host := flag.String("host", os.Getenv("MEM0_HOST"), "Mem0 API host (default $MEM0_HOST or https://api.mem0.ai)")
+	certFile := flag.String("tls-cert", "", "server certificate file")
+	keyFile := flag.String("tls-key", "", "server private key file")
+	clientCA := flag.String("client-ca", "", "CA bundle for verifying client certificates; enables mTLS")
+	flag.Parse()
+
+	options := client.ClientOptions{APIKey: *apiKey}
+	if *host != "" {
+		options.Host = host
+	}
+	memoryClient, err := client.NewMemoryClient(options)
+	if err != nil {
+		return err
+	}
+
+	var serverOptions []grpc.ServerOption
+	if *certFile != "" || *keyFile != "" {
+		tlsConfig, err := loadTLSConfig(*certFile, *keyFile, *clientCA)
+		if err != nil {
+			return err
+		}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else if *clientCA != "" {
+		return fmt.Errorf("--client-ca requires --tls-cert and --tls-key")
+	}
+
+	srv := grpc.NewServer(serverOptions...)
+	mem0v1.RegisterMemoryServiceServer(srv, newServer(memoryClient))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	lis, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	log.Printf("mem0-grpc listening on %s", lis.Addr())
+	return srv.Serve(lis)
+}
+
+// loadTLSConfig builds the server TLS configuration. A non-empty clientCA
+// makes client certificates mandatory.
+func loadTLSConfig(certFile, keyFile, clientCA string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCA == "" {
+		return config, nil
+	}
+
+	pem, err := os.ReadFile(clientCA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", clientCA)
+	}
+	config.ClientCAs = pool
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+	return config, nil
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	mem0v1 "github.com/murilopl/go-mem0/cmd/mem0-grpc/gen/mem0/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// server implements mem0v1.MemoryServiceServer on top of a client.Client
+type server struct {
+	mem0v1.UnimplementedMemoryServiceServer
+	client client.Client
+}
+
+// newServer creates a server that forwards to c
+func newServer(c client.Client) *server {
+	return &server{client: c}
+}
+
+// Add implements mem0v1.MemoryServiceServer
+func (s *server) Add(ctx context.Context, req *mem0v1.AddRequest) (*mem0v1.AddResponse, error) {
+	if len(req.GetMessages()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "messages is required")
+	}
+	options, err := scopeOptions(req.GetScope())
+	if err != nil {
+		return nil, err
+	}
+	if req.Metadata != nil {
+		options.Metadata = req.Metadata.AsMap()
+	}
+	options.Infer = req.Infer
+
+	messages := make([]client.Message, len(req.GetMessages()))
+	for i, m := range req.GetMessages() {
+		messages[i] = client.Message{Role: m.GetRole(), Content: m.GetContent()}
+	}
+
+	memories, err := s.client.Add(ctx, messages, options)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &mem0v1.AddResponse{Memories: toProtoMemories(memories)}, nil
+}
+
+// Search implements mem0v1.MemoryServiceServer
+func (s *server) Search(ctx context.Context, req *mem0v1.SearchRequest) (*mem0v1.SearchResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	options, err := scopeOptions(req.GetScope())
+	if err != nil {
+		return nil, err
+	}
+	search := client.SearchOptions{MemoryOptions: options, Threshold: req.Threshold}
+	if req.GetLimit() > 0 {
+		limit := int(req.GetLimit())
+		search.Limit = &limit
+	}
+
+	memories, err := s.client.Search(ctx, req.GetQuery(), search)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &mem0v1.SearchResponse{Memories: toProtoMemories(memories)}, nil
+}
+
+// Get implements mem0v1.MemoryServiceServer
+func (s *server) Get(ctx context.Context, req *mem0v1.GetRequest) (*mem0v1.Memory, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	memory, err := s.client.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoMemory(*memory), nil
+}
+
+// List implements mem0v1.MemoryServiceServer
+func (s *server) List(ctx context.Context, req *mem0v1.ListRequest) (*mem0v1.ListResponse, error) {
+	options, err := scopeOptions(req.GetScope())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetPage() > 0 || req.GetPageSize() > 0 {
+		page, pageSize := int(req.GetPage()), int(req.GetPageSize())
+		options.Page = &page
+		options.PageSize = &pageSize
+	}
+
+	memories, err := s.client.GetAll(ctx, client.SearchOptions{MemoryOptions: options})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &mem0v1.ListResponse{Memories: toProtoMemories(memories)}, nil
+}
+
+// Update implements mem0v1.MemoryServiceServer
+func (s *server) Update(ctx context.Context, req *mem0v1.UpdateRequest) (*mem0v1.UpdateResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	memories, err := s.client.Update(ctx, req.GetId(), req.GetText())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &mem0v1.UpdateResponse{Memories: toProtoMemories(memories)}, nil
+}
+
+// Delete implements mem0v1.MemoryServiceServer
+func (s *server) Delete(ctx context.Context, req *mem0v1.DeleteRequest) (*mem0v1.DeleteResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	resp, err := s.client.Delete(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &mem0v1.DeleteResponse{Message: resp.Message}, nil
+}
+
+// DeleteAll implements mem0v1.MemoryServiceServer
+func (s *server) DeleteAll(ctx context.Context, req *mem0v1.DeleteAllRequest) (*mem0v1.DeleteResponse, error) {
+	options, err := scopeOptions(req.GetScope())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.DeleteAll(ctx, options)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &mem0v1.DeleteResponse{Message: resp.Message}, nil
+}
+
+// History implements mem0v1.MemoryServiceServer
+func (s *server) History(ctx context.Context, req *mem0v1.HistoryRequest) (*mem0v1.HistoryResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	history, err := s.client.History(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	entries := make([]*mem0v1.HistoryEntry, len(history))
+	for i, h := range history {
+		entries[i] = &mem0v1.HistoryEntry{
+			Id:        h.ID,
+			MemoryId:  h.MemoryID,
+			Event:     string(h.Event),
+			OldMemory: stringValue(h.OldMemory),
+			NewMemory: stringValue(h.NewMemory),
+			CreatedAt: timestamppb.New(h.CreatedAt),
+		}
+	}
+	return &mem0v1.HistoryResponse{Entries: entries}, nil
+}
+
+// scopeOptions converts a scope to MemoryOptions. A scope without any entity
+// ID is rejected so one caller cannot act on every memory of the project.
+func scopeOptions(scope *mem0v1.Scope) (client.MemoryOptions, error) {
+	var options client.MemoryOptions
+	if id := scope.GetUserId(); id != "" {
+		options.UserID = &id
+	}
+	if id := scope.GetAgentId(); id != "" {
+		options.AgentID = &id
+	}
+	if id := scope.GetAppId(); id != "" {
+		options.AppID = &id
+	}
+	if id := scope.GetRunId(); id != "" {
+		options.RunID = &id
+	}
+	if options.UserID == nil && options.AgentID == nil && options.AppID == nil && options.RunID == nil {
+		return options, status.Error(codes.InvalidArgument, "scope requires at least one of user_id, agent_id, app_id or run_id")
+	}
+	return options, nil
+}
+
+// toStatus maps client errors to gRPC status errors
+func toStatus(err error) error {
+	var validationErr *client.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, validationErr.Error())
+	}
+
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		code := codes.Unknown
+		switch {
+		case apiErr.StatusCode == http.StatusBadRequest:
+			code = codes.InvalidArgument
+		case apiErr.StatusCode == http.StatusUnauthorized:
+			code = codes.Unauthenticated
+		case apiErr.StatusCode == http.StatusForbidden:
+			code = codes.PermissionDenied
+		case apiErr.StatusCode == http.StatusNotFound:
+			code = codes.NotFound
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			code = codes.ResourceExhausted
+		case apiErr.StatusCode >= 500:
+			code = codes.Unavailable
+		}
+		return status.Error(code, apiErr.Error())
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// toProtoMemories converts memories to their protobuf form
+func toProtoMemories(memories []client.Memory) []*mem0v1.Memory {
+	result := make([]*mem0v1.Memory, len(memories))
+	for i, memory := range memories {
+		result[i] = toProtoMemory(memory)
+	}
+	return result
+}
+
+// toProtoMemory converts a memory to its protobuf form. Metadata that is not
+// a JSON object is dropped.
+func toProtoMemory(memory client.Memory) *mem0v1.Memory {
+	m := &mem0v1.Memory{
+		Id:         memory.ID,
+		Memory:     memory.Text(),
+		UserId:     stringValue(memory.UserID),
+		AgentId:    stringValue(memory.AgentID),
+		AppId:      stringValue(memory.AppID),
+		RunId:      stringValue(memory.RunID),
+		Hash:       stringValue(memory.Hash),
+		Categories: memory.Categories,
+		Score:      memory.Score,
+		CreatedAt:  timestampValue(memory.CreatedAt),
+		UpdatedAt:  timestampValue(memory.UpdatedAt),
+	}
+	if memory.Event != nil {
+		m.Event = string(*memory.Event)
+	}
+	if metadata, ok := memory.Metadata.(map[string]interface{}); ok {
+		if s, err := structpb.NewStruct(metadata); err == nil {
+			m.Metadata = s
+		}
+	}
+	return m
+}
+
+// stringValue dereferences s, returning "" for nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// timestampValue converts t, returning nil for nil
+func timestampValue(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
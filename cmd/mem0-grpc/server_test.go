@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	mem0v1 "github.com/murilopl/go-mem0/cmd/mem0-grpc/gen/mem0/v1"
+	"github.com/murilopl/go-mem0/mem0test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newTestClient serves the memory service on an in-memory listener backed
+// by a fake Mem0 API
+func newTestClient(t *testing.T) mem0v1.MemoryServiceClient {
+	t.Helper()
+
+	fake := mem0test.NewServer()
+	t.Cleanup(fake.Close)
+	memoryClient, err := fake.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	mem0v1.RegisterMemoryServiceServer(srv, newServer(memoryClient))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return mem0v1.NewMemoryServiceClient(conn)
+}
+
+func TestMemoryService(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	scope := &mem0v1.Scope{UserId: "alex"}
+
+	metadata, _ := structpb.NewStruct(map[string]interface{}{"source": "chat"})
+	added, err := c.Add(ctx, &mem0v1.AddRequest{
+		Messages: []*mem0v1.Message{{Role: "user", Content: "I am a vegetarian"}},
+		Scope:    scope,
+		Metadata: metadata,
+	})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if len(added.Memories) != 1 || added.Memories[0].Event != "ADD" {
+		t.Fatalf("Add() = %v", added.Memories)
+	}
+	id := added.Memories[0].Id
+
+	found, err := c.Search(ctx, &mem0v1.SearchRequest{Query: "vegetarian", Scope: scope, Limit: 5})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(found.Memories) != 1 || found.Memories[0].Score == nil {
+		t.Errorf("Search() = %v", found.Memories)
+	}
+
+	got, err := c.Get(ctx, &mem0v1.GetRequest{Id: id})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Memory != "I am a vegetarian" || got.UserId != "alex" || got.Metadata.AsMap()["source"] != "chat" {
+		t.Errorf("Get() = %v", got)
+	}
+
+	if _, err := c.Update(ctx, &mem0v1.UpdateRequest{Id: id, Text: "I am vegan"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	history, err := c.History(ctx, &mem0v1.HistoryRequest{Id: id})
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history.Entries) != 2 || history.Entries[1].NewMemory != "I am vegan" {
+		t.Errorf("History() = %v", history.Entries)
+	}
+
+	list, err := c.List(ctx, &mem0v1.ListRequest{Scope: scope})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list.Memories) != 1 {
+		t.Errorf("List() = %v", list.Memories)
+	}
+
+	if _, err := c.Delete(ctx, &mem0v1.DeleteRequest{Id: id}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	_, err = c.Get(ctx, &mem0v1.GetRequest{Id: id})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Get() after delete error = %v, want NotFound", err)
+	}
+}
+
+func TestMemoryServiceValidation(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"add without messages", func() error {
+			_, err := c.Add(ctx, &mem0v1.AddRequest{Scope: &mem0v1.Scope{UserId: "alex"}})
+			return err
+		}},
+		{"add without scope", func() error {
+			_, err := c.Add(ctx, &mem0v1.AddRequest{Messages: []*mem0v1.Message{{Role: "user", Content: "hi"}}})
+			return err
+		}},
+		{"search without query", func() error {
+			_, err := c.Search(ctx, &mem0v1.SearchRequest{Scope: &mem0v1.Scope{UserId: "alex"}})
+			return err
+		}},
+		{"delete all without scope", func() error {
+			_, err := c.DeleteAll(ctx, &mem0v1.DeleteAllRequest{})
+			return err
+		}},
+		{"get without id", func() error {
+			_, err := c.Get(ctx, &mem0v1.GetRequest{})
+			return err
+		}},
+		{"invalid threshold", func() error {
+			threshold := 2.0
+			_, err := c.Search(ctx, &mem0v1.SearchRequest{Query: "q", Scope: &mem0v1.Scope{UserId: "alex"}, Threshold: &threshold})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.call()); code != codes.InvalidArgument {
+				t.Errorf("code = %v, want InvalidArgument", code)
+			}
+		})
+	}
+}
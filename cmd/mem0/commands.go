@@ -0,0 +1,342 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newAddCmd() *cobra.Command {
+	var entity entityFlags
+	var role string
+	var infer bool
+
+	cmd := &cobra.Command{
+		Use:   "add [text...]",
+		Short: "Add a message as memory (reads stdin when no text is given)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text := strings.Join(args, " ")
+			if text == "" {
+				data, err := io.ReadAll(cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("failed to read stdin: %w", err)
+				}
+				text = strings.TrimSpace(string(data))
+			}
+			if text == "" {
+				return fmt.Errorf("no message text given")
+			}
+
+			c, err := a.newClient()
+			if err != nil {
+				return err
+			}
+			opts := entity.memoryOptions()
+			if cmd.Flags().Changed("infer") {
+				opts.Infer = &infer
+			}
+
+			memories, err := c.Add(cmd.Context(), []client.Message{{Role: role, Content: text}}, opts)
+			if err != nil {
+				return err
+			}
+			return a.printMemoryEvents(memories)
+		},
+	}
+	entity.register(cmd)
+	cmd.Flags().StringVar(&role, "role", "user", "message role: user or assistant")
+	cmd.Flags().BoolVar(&infer, "infer", true, "let the platform extract facts from the message")
+	return cmd
+}
+
+func (a *app) newSearchCmd() *cobra.Command {
+	var entity entityFlags
+	var limit int
+	var threshold float64
+	var v2 bool
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search memories",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.newClient()
+			if err != nil {
+				return err
+			}
+
+			opts := client.SearchOptions{MemoryOptions: entity.memoryOptions()}
+			if cmd.Flags().Changed("limit") {
+				opts.Limit = &limit
+			}
+			if cmd.Flags().Changed("threshold") {
+				opts.Threshold = &threshold
+			}
+			if v2 {
+				apiVersion := client.APIVersionV2
+				opts.APIVersion = &apiVersion
+			}
+
+			memories, err := c.Search(cmd.Context(), strings.Join(args, " "), opts)
+			if err != nil {
+				return err
+			}
+			return a.printMemories(memories)
+		},
+	}
+	entity.register(cmd)
+	cmd.Flags().IntVar(&limit, "limit", 0, "maximum number of results")
+	cmd.Flags().Float64Var(&threshold, "threshold", 0, "minimum similarity score")
+	cmd.Flags().BoolVar(&v2, "v2", false, "use the v2 search endpoint")
+	return cmd
+}
+
+func (a *app) newGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <memory-id>",
+		Short: "Show a memory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.newClient()
+			if err != nil {
+				return err
+			}
+			memory, err := c.Get(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if a.output == "json" {
+				return a.printJSON(memory)
+			}
+			return a.printMemories([]client.Memory{*memory})
+		},
+	}
+}
+
+func (a *app) newListCmd() *cobra.Command {
+	var entity entityFlags
+	var page, pageSize int
+	var v2 bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List memories",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.newClient()
+			if err != nil {
+				return err
+			}
+
+			opts := client.SearchOptions{MemoryOptions: entity.memoryOptions()}
+			if page > 0 && pageSize > 0 {
+				opts.Page = &page
+				opts.PageSize = &pageSize
+			}
+			if v2 {
+				apiVersion := client.APIVersionV2
+				opts.APIVersion = &apiVersion
+			}
+
+			memories, err := c.GetAll(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			return a.printMemories(memories)
+		},
+	}
+	entity.register(cmd)
+	cmd.Flags().IntVar(&page, "page", 0, "page number (requires --page-size)")
+	cmd.Flags().IntVar(&pageSize, "page-size", 0, "page size (requires --page)")
+	cmd.Flags().BoolVar(&v2, "v2", false, "use the v2 list endpoint")
+	return cmd
+}
+
+func (a *app) newDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <memory-id>",
+		Short: "Delete a memory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.newClient()
+			if err != nil {
+				return err
+			}
+			result, err := c.Delete(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return a.printMessage(result.Message)
+		},
+	}
+}
+
+func (a *app) newHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history <memory-id>",
+		Short: "Show the change history of a memory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.newClient()
+			if err != nil {
+				return err
+			}
+			history, err := c.History(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return a.printHistory(history)
+		},
+	}
+}
+
+func (a *app) newUsersCmd() *cobra.Command {
+	var entityType string
+
+	cmd := &cobra.Command{
+		Use:   "users",
+		Short: "List users, agents, apps and runs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.newClient()
+			if err != nil {
+				return err
+			}
+
+			opts := client.UsersOptions{}
+			if entityType != "" {
+				opts.Type = &entityType
+			}
+			users := &client.AllUsers{Results: []client.User{}}
+			err = client.ForEachUser(cmd.Context(), c, opts, func(user client.User) error {
+				users.Results = append(users.Results, user)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			users.Count = len(users.Results)
+			return a.printUsers(users)
+		},
+	}
+	cmd.Flags().StringVar(&entityType, "type", "", "only list entities of this type: user, agent, app or run")
+	return cmd
+}
+
+func (a *app) newExportCmd() *cobra.Command {
+	var entity entityFlags
+	var outPath string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export memories as JSON Lines",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.newClient()
+			if err != nil {
+				return err
+			}
+
+			w := a.out
+			if outPath != "" && outPath != "-" {
+				f, err := os.Create(outPath)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			count, err := client.ExportMemories(cmd.Context(), c, w, client.SearchOptions{MemoryOptions: entity.memoryOptions()})
+			if err != nil {
+				return err
+			}
+			if w != a.out {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d memories to %s\n", count, outPath)
+			}
+			return nil
+		},
+	}
+	entity.register(cmd)
+	cmd.Flags().StringVar(&outPath, "out", "", "output file (default stdout)")
+	return cmd
+}
+
+func (a *app) newImportCmd() *cobra.Command {
+	var entity entityFlags
+
+	cmd := &cobra.Command{
+		Use:   "import <file.jsonl>",
+		Short: "Import memories from JSON Lines (use - for stdin)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.newClient()
+			if err != nil {
+				return err
+			}
+
+			r := cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("failed to open input file: %w", err)
+				}
+				defer f.Close()
+				r = f
+			}
+
+			count, err := client.ImportMemories(cmd.Context(), c, r, entity.memoryOptions())
+			if err != nil {
+				return fmt.Errorf("imported %d memories before failing: %w", count, err)
+			}
+			return a.printMessage(fmt.Sprintf("Imported %d memories", count))
+		},
+	}
+	entity.register(cmd)
+	return cmd
+}
+
+func (a *app) newTailCmd() *cobra.Command {
+	var entity entityFlags
+	var interval time.Duration
+	var includeExisting bool
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Stream memory additions, updates and deletions as they happen",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.newClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			options := client.WatchOptions{
+				SearchOptions:   client.SearchOptions{MemoryOptions: entity.memoryOptions()},
+				Interval:        interval,
+				IncludeExisting: includeExisting,
+			}
+			err = client.WatchMemories(ctx, c, options, func(event client.MemoryEvent) {
+				a.printEvent(event)
+			})
+			if ctx.Err() != nil {
+				// Interrupted or deadline reached
+				return nil
+			}
+			return err
+		},
+	}
+	entity.register(cmd)
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "poll interval")
+	cmd.Flags().BoolVar(&includeExisting, "all", false, "print existing memories as additions first")
+	return cmd
+}
@@ -0,0 +1,21 @@
+// Command mem0 inspects and edits memories stored in the Mem0 API.
+//
+// The API key is read from --api-key, the MEM0_API_KEY environment variable,
+// or a .env file in the working directory:
+//
+//	mem0 add --user-id alex "I am a vegetarian"
+//	mem0 search --user-id alex "what do I eat?"
+//	mem0 list --user-id alex -o table
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if err := newRootCmd(os.Stdout).Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+// run executes the CLI against the fake server and returns its output
+func run(t *testing.T, srv *mem0test.Server, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := newRootCmd(&out)
+	cmd.SetArgs(append([]string{"--api-key", srv.APIKey, "--host", srv.URL}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestCLIMemoryCommands(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+
+	out, err := run(t, srv, "add", "--user-id", "alex", "I", "love", "hiking")
+	if err != nil {
+		t.Fatalf("add error = %v", err)
+	}
+	var added []client.Memory
+	if err := json.Unmarshal([]byte(out), &added); err != nil || len(added) != 1 {
+		t.Fatalf("add output = %s, want one memory as JSON", out)
+	}
+	id := added[0].ID
+
+	out, err = run(t, srv, "search", "--user-id", "alex", "-o", "table", "hiking")
+	if err != nil {
+		t.Fatalf("search error = %v", err)
+	}
+	if !strings.Contains(out, "ID") || !strings.Contains(out, "I love hiking") || !strings.Contains(out, "user:alex") {
+		t.Errorf("search table output = %s", out)
+	}
+
+	out, err = run(t, srv, "get", id)
+	if err != nil {
+		t.Fatalf("get error = %v", err)
+	}
+	var memory client.Memory
+	if err := json.Unmarshal([]byte(out), &memory); err != nil || memory.ID != id {
+		t.Errorf("get output = %s, want memory %s", out, id)
+	}
+
+	out, err = run(t, srv, "list", "--user-id", "alex")
+	if err != nil {
+		t.Fatalf("list error = %v", err)
+	}
+	var listed []client.Memory
+	if err := json.Unmarshal([]byte(out), &listed); err != nil || len(listed) != 1 {
+		t.Errorf("list output = %s, want one memory", out)
+	}
+
+	out, err = run(t, srv, "users", "-o", "table")
+	if err != nil {
+		t.Fatalf("users error = %v", err)
+	}
+	if !strings.Contains(out, "alex") {
+		t.Errorf("users output = %s, want alex", out)
+	}
+
+	if _, err := run(t, srv, "delete", id); err != nil {
+		t.Fatalf("delete error = %v", err)
+	}
+
+	out, err = run(t, srv, "history", "-o", "table", id)
+	if err != nil {
+		t.Fatalf("history error = %v", err)
+	}
+	if !strings.Contains(out, "ADD") || !strings.Contains(out, "DELETE") {
+		t.Errorf("history output = %s, want ADD and DELETE rows", out)
+	}
+}
+
+func TestCLIRejectsUnknownOutput(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+
+	if _, err := run(t, srv, "users", "-o", "yaml"); err == nil {
+		t.Error("expected an error for unsupported output format")
+	}
+}
+
+func TestCLIExportImport(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+
+	if _, err := run(t, srv, "add", "--user-id", "alex", "I like tea"); err != nil {
+		t.Fatalf("add error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "memories.jsonl")
+	if _, err := run(t, srv, "export", "--user-id", "alex", "--out", path); err != nil {
+		t.Fatalf("export error = %v", err)
+	}
+
+	out, err := run(t, srv, "import", "--user-id", "sam", "-o", "table", path)
+	if err != nil {
+		t.Fatalf("import error = %v", err)
+	}
+	if !strings.Contains(out, "Imported 1 memories") {
+		t.Errorf("import output = %s", out)
+	}
+
+	memories := srv.Memories()
+	if len(memories) != 2 || *memories[1].UserID != "sam" {
+		t.Errorf("memories after import = %+v, want copy owned by sam", memories)
+	}
+}
+
+func TestCLITail(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+
+	if _, err := run(t, srv, "add", "--user-id", "alex", "I like tea"); err != nil {
+		t.Fatalf("add error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var out bytes.Buffer
+	cmd := newRootCmd(&out)
+	cmd.SetArgs([]string{"--api-key", srv.APIKey, "--host", srv.URL, "-o", "table",
+		"tail", "--user-id", "alex", "--all", "--interval", "10ms"})
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		t.Fatalf("tail error = %v", err)
+	}
+
+	if !strings.Contains(out.String(), "memory_add") || !strings.Contains(out.String(), "I like tea") {
+		t.Errorf("tail output = %s, want the existing memory as an addition", out.String())
+	}
+}
+
+func TestCLIProfile(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+
+	config := filepath.Join(t.TempDir(), "config.toml")
+	os.WriteFile(config, []byte("[staging]\napi_key = \""+srv.APIKey+"\"\nhost = \""+srv.URL+"\"\n"), 0o600)
+	t.Setenv(client.EnvConfigFile, config)
+	t.Setenv(client.EnvAPIKey, "wrong-key")
+
+	var out bytes.Buffer
+	cmd := newRootCmd(&out)
+	cmd.SetArgs([]string{"--profile", "staging", "list", "--user-id", "alex"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("list --profile staging error = %v", err)
+	}
+
+	cmd = newRootCmd(&out)
+	cmd.SetArgs([]string{"--profile", "prod", "list", "--user-id", "alex"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "prod") {
+		t.Errorf("list --profile prod error = %v, want missing profile", err)
+	}
+}
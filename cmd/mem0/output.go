@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// printJSON writes v as indented JSON
+func (a *app) printJSON(v interface{}) error {
+	encoder := json.NewEncoder(a.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// printTable writes tab-separated rows as an aligned table
+func (a *app) printTable(header string, rows [][]interface{}) error {
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, header)
+	for _, row := range rows {
+		for i, cell := range row {
+			if i > 0 {
+				fmt.Fprint(w, "\t")
+			}
+			fmt.Fprint(w, cell)
+		}
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
+
+// printMemories writes memories returned by get, list and search
+func (a *app) printMemories(memories []client.Memory) error {
+	if a.output == "json" {
+		return a.printJSON(memories)
+	}
+
+	rows := make([][]interface{}, len(memories))
+	for i, m := range memories {
+		score := ""
+		if m.Score != nil {
+			score = fmt.Sprintf("%.3f", *m.Score)
+		}
+		rows[i] = []interface{}{m.ID, owner(m), value(m.Memory), score, formatTime(m.CreatedAt)}
+	}
+	return a.printTable("ID\tOWNER\tMEMORY\tSCORE\tCREATED", rows)
+}
+
+// printMemoryEvents writes the result of add
+func (a *app) printMemoryEvents(memories []client.Memory) error {
+	if a.output == "json" {
+		return a.printJSON(memories)
+	}
+
+	rows := make([][]interface{}, len(memories))
+	for i, m := range memories {
+		event, text := "", ""
+		if m.Event != nil {
+			event = string(*m.Event)
+		}
+		if m.Data != nil {
+			text = m.Data.Memory
+		}
+		rows[i] = []interface{}{m.ID, event, text}
+	}
+	return a.printTable("ID\tEVENT\tMEMORY", rows)
+}
+
+// printHistory writes memory history entries
+func (a *app) printHistory(history []client.MemoryHistory) error {
+	if a.output == "json" {
+		return a.printJSON(history)
+	}
+
+	rows := make([][]interface{}, len(history))
+	for i, h := range history {
+		rows[i] = []interface{}{h.CreatedAt.Format(time.RFC3339), h.Event, value(h.OldMemory), value(h.NewMemory)}
+	}
+	return a.printTable("CREATED\tEVENT\tOLD\tNEW", rows)
+}
+
+// printUsers writes the entity list
+func (a *app) printUsers(users *client.AllUsers) error {
+	if a.output == "json" {
+		return a.printJSON(users)
+	}
+
+	rows := make([][]interface{}, len(users.Results))
+	for i, u := range users.Results {
+		rows[i] = []interface{}{u.Type, u.Name, u.TotalMemories, u.UpdatedAt.Format(time.RFC3339)}
+	}
+	return a.printTable("TYPE\tNAME\tMEMORIES\tUPDATED", rows)
+}
+
+// printEvent writes one memory event as a JSON line or a single text line
+func (a *app) printEvent(event client.MemoryEvent) {
+	if a.output == "json" {
+		json.NewEncoder(a.out).Encode(event)
+		return
+	}
+	fmt.Fprintf(a.out, "%s  %-13s  %s  %s  %s\n",
+		event.Timestamp.Format(time.RFC3339), event.Event, event.Memory.ID, owner(event.Memory), value(event.Memory.Memory))
+}
+
+// printMessage writes a status message
+func (a *app) printMessage(message string) error {
+	if a.output == "json" {
+		return a.printJSON(client.MessageResponse{Message: message})
+	}
+	_, err := fmt.Fprintln(a.out, message)
+	return err
+}
+
+// owner describes the entity a memory belongs to
+func owner(m client.Memory) string {
+	switch {
+	case m.UserID != nil:
+		return "user:" + *m.UserID
+	case m.AgentID != nil:
+		return "agent:" + *m.AgentID
+	case m.AppID != nil:
+		return "app:" + *m.AppID
+	case m.RunID != nil:
+		return "run:" + *m.RunID
+	}
+	return ""
+}
+
+// value dereferences an optional string
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// formatTime formats an optional timestamp
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/joho/godotenv"
+	"github.com/murilopl/go-mem0/client"
+	"github.com/spf13/cobra"
+)
+
+// app holds the global flags shared by all subcommands
+type app struct {
+	out       io.Writer
+	apiKey    string
+	host      string
+	orgID     string
+	projectID string
+	profile   string
+	output    string
+}
+
+// entityFlags holds the entity scoping flags used by several subcommands
+type entityFlags struct {
+	userID  string
+	agentID string
+	appID   string
+	runID   string
+}
+
+// register adds the entity flags to a command
+func (f *entityFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.userID, "user-id", "", "scope to a user")
+	cmd.Flags().StringVar(&f.agentID, "agent-id", "", "scope to an agent")
+	cmd.Flags().StringVar(&f.appID, "app-id", "", "scope to an app")
+	cmd.Flags().StringVar(&f.runID, "run-id", "", "scope to a run")
+}
+
+// memoryOptions converts the flags into MemoryOptions
+func (f *entityFlags) memoryOptions() client.MemoryOptions {
+	var opts client.MemoryOptions
+	if f.userID != "" {
+		opts.UserID = &f.userID
+	}
+	if f.agentID != "" {
+		opts.AgentID = &f.agentID
+	}
+	if f.appID != "" {
+		opts.AppID = &f.appID
+	}
+	if f.runID != "" {
+		opts.RunID = &f.runID
+	}
+	return opts
+}
+
+// newRootCmd builds the command tree writing results to out
+func newRootCmd(out io.Writer) *cobra.Command {
+	a := &app{out: out}
+
+	root := &cobra.Command{
+		Use:           "mem0",
+		Short:         "Inspect and edit memories stored in Mem0",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if a.output != "json" && a.output != "table" {
+				return fmt.Errorf("unsupported output format %q (use json or table)", a.output)
+			}
+			return nil
+		},
+	}
+	root.SetOut(out)
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&a.apiKey, "api-key", "", "Mem0 API key (default $MEM0_API_KEY)")
+	flags.StringVar(&a.host, "host", "", "API host (default $MEM0_HOST or https://api.mem0.ai)")
+	flags.StringVar(&a.orgID, "org-id", "", "organization ID (default $MEM0_ORG_ID)")
+	flags.StringVar(&a.projectID, "project-id", "", "project ID (default $MEM0_PROJECT_ID)")
+	flags.StringVar(&a.profile, "profile", "", "profile in ~/.mem0/config.toml (default $MEM0_PROFILE)")
+	flags.StringVarP(&a.output, "output", "o", "json", "output format: json or table")
+
+	root.AddCommand(
+		a.newAddCmd(),
+		a.newSearchCmd(),
+		a.newGetCmd(),
+		a.newListCmd(),
+		a.newDeleteCmd(),
+		a.newHistoryCmd(),
+		a.newUsersCmd(),
+		a.newExportCmd(),
+		a.newImportCmd(),
+		a.newTailCmd(),
+	)
+
+	return root
+}
+
+// newClient creates a MemoryClient from flags, environment and .env
+func (a *app) newClient() (*client.MemoryClient, error) {
+	// A missing .env file is not an error
+	_ = godotenv.Load()
+
+	// --profile replaces the environment; flags override either
+	var options client.ClientOptions
+	var err error
+	if a.profile != "" {
+		options, err = client.ClientOptions{}.WithProfile(a.profile)
+	} else {
+		options, err = client.OptionsFromEnv()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if a.apiKey != "" {
+		options.APIKey = a.apiKey
+	}
+	if a.host != "" {
+		options.Host = &a.host
+	}
+	if a.orgID != "" {
+		options.OrganizationID = a.orgID
+	}
+	if a.projectID != "" {
+		options.ProjectID = a.projectID
+	}
+
+	return client.NewMemoryClient(options)
+}
@@ -0,0 +1,116 @@
+// Package contextpack assembles memories into a context block for an LLM
+// prompt: it searches, removes duplicates, ranks by relevance and trims the
+// result to a token budget.
+package contextpack
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// DefaultHeader introduces the memory block in the formatted text
+const DefaultHeader = "Relevant memories about the user:"
+
+// Options configures Build
+type Options struct {
+	client.SearchOptions
+	MaxTokens   int                        // Token budget for Text, unlimited when zero
+	CountTokens client.TokenCounter        // Optional: client.EstimateTokens when nil
+	Header      *string                    // Optional: DefaultHeader when nil; empty for none
+	Format      func(client.Memory) string // Optional: formats one memory, "- text" when nil
+}
+
+// Pack is an assembled context block
+type Pack struct {
+	Text     string          // Formatted block, empty when no memory fits
+	Memories []client.Memory // Memories included in Text, in order
+	Tokens   int             // Tokens in Text
+}
+
+// Build searches for memories relevant to query and packs as many as fit in
+// the budget, most relevant first
+func Build(ctx context.Context, c client.Client, query string, options Options) (*Pack, error) {
+	memories, err := c.Search(ctx, query, options.SearchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	countTokens := options.CountTokens
+	if countTokens == nil {
+		countTokens = client.EstimateTokens
+	}
+	format := options.Format
+	if format == nil {
+		format = func(memory client.Memory) string {
+			return "- " + strings.TrimSpace(memory.Text())
+		}
+	}
+	header := DefaultHeader
+	if options.Header != nil {
+		header = *options.Header
+	}
+
+	pack := &Pack{}
+	var lines []string
+	if header != "" {
+		lines = append(lines, header)
+		pack.Tokens = countTokens(header + "\n")
+	}
+
+	for _, memory := range rank(dedupe(memories)) {
+		line := format(memory)
+		tokens := countTokens(line + "\n")
+		if options.MaxTokens > 0 && pack.Tokens+tokens > options.MaxTokens {
+			break
+		}
+		lines = append(lines, line)
+		pack.Tokens += tokens
+		pack.Memories = append(pack.Memories, memory)
+	}
+
+	if len(pack.Memories) == 0 {
+		return &Pack{}, nil
+	}
+	pack.Text = strings.Join(lines, "\n")
+	return pack, nil
+}
+
+// dedupe drops memories with a repeated ID or text, keeping the first
+func dedupe(memories []client.Memory) []client.Memory {
+	seenIDs := make(map[string]bool)
+	seenTexts := make(map[string]bool)
+	result := make([]client.Memory, 0, len(memories))
+	for _, memory := range memories {
+		text := strings.ToLower(strings.Join(strings.Fields(memory.Text()), " "))
+		if text == "" || seenTexts[text] || (memory.ID != "" && seenIDs[memory.ID]) {
+			continue
+		}
+		seenTexts[text] = true
+		seenIDs[memory.ID] = true
+		result = append(result, memory)
+	}
+	return result
+}
+
+// rank orders memories by score, then by most recent update
+func rank(memories []client.Memory) []client.Memory {
+	sort.SliceStable(memories, func(i, j int) bool {
+		a, b := memories[i], memories[j]
+		if score(a) != score(b) {
+			return score(a) > score(b)
+		}
+		return a.UpdatedAt != nil && (b.UpdatedAt == nil || a.UpdatedAt.After(*b.UpdatedAt))
+	})
+	return memories
+}
+
+// score returns the search score, zero when absent
+func score(memory client.Memory) float64 {
+	if memory.Score == nil {
+		return 0
+	}
+	return *memory.Score
+}
@@ -0,0 +1,96 @@
+package contextpack_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+	"github.com/murilopl/go-mem0/contextpack"
+)
+
+func memory(id, text string, score float64) client.Memory {
+	return client.Memory{ID: id, Memory: &text, Score: &score}
+}
+
+func searchReturning(memories ...client.Memory) *clienttest.MockClient {
+	return &clienttest.MockClient{
+		SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+			return memories, nil
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	mock := searchReturning(
+		memory("mem-1", "Lives in Lisbon", 0.4),
+		memory("mem-2", "Is a vegetarian", 0.9),
+		memory("mem-3", "is a  Vegetarian", 0.8),
+		memory("mem-2", "Is a vegetarian (dup id)", 0.7),
+	)
+
+	pack, err := contextpack.Build(context.Background(), mock, "diet", contextpack.Options{})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := contextpack.DefaultHeader + "\n- Is a vegetarian\n- Lives in Lisbon"
+	if pack.Text != want {
+		t.Errorf("Text = %q, want %q", pack.Text, want)
+	}
+	if len(pack.Memories) != 2 || pack.Memories[0].ID != "mem-2" || pack.Memories[1].ID != "mem-1" {
+		t.Errorf("Memories = %+v", pack.Memories)
+	}
+	if pack.Tokens != client.EstimateTokens(contextpack.DefaultHeader+"\n")+client.EstimateTokens("- Is a vegetarian\n")+client.EstimateTokens("- Lives in Lisbon\n") {
+		t.Errorf("Tokens = %d", pack.Tokens)
+	}
+
+	calls := mock.CallsTo("Search")
+	if len(calls) != 1 || calls[0].Args[0] != "diet" {
+		t.Errorf("Search calls = %+v", calls)
+	}
+}
+
+func TestBuildBudget(t *testing.T) {
+	mock := searchReturning(
+		memory("mem-1", "first", 0.9),
+		memory("mem-2", "second", 0.8),
+		memory("mem-3", "third", 0.7),
+	)
+	noHeader := ""
+	countLines := func(string) int { return 1 }
+
+	pack, err := contextpack.Build(context.Background(), mock, "q", contextpack.Options{
+		MaxTokens:   2,
+		CountTokens: countLines,
+		Header:      &noHeader,
+		Format:      func(m client.Memory) string { return m.ID },
+	})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if pack.Text != "mem-1\nmem-2" || pack.Tokens != 2 {
+		t.Errorf("Build() = %+v", pack)
+	}
+
+	empty, err := contextpack.Build(context.Background(), mock, "q", contextpack.Options{MaxTokens: 1, CountTokens: countLines})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if empty.Text != "" || len(empty.Memories) != 0 || empty.Tokens != 0 {
+		t.Errorf("Build() with header-only budget = %+v, want empty pack", empty)
+	}
+}
+
+func TestBuildSearchError(t *testing.T) {
+	wantErr := errors.New("boom")
+	mock := &clienttest.MockClient{
+		SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+			return nil, wantErr
+		},
+	}
+	if _, err := contextpack.Build(context.Background(), mock, "q", contextpack.Options{}); !errors.Is(err, wantErr) {
+		t.Errorf("Build() error = %v, want %v", err, wantErr)
+	}
+}
@@ -0,0 +1,81 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+)
+
+// SecretsManagerAPI is the part of an AWS Secrets Manager client that
+// AWSSecretsManager needs. Adapt the AWS SDK with a few lines:
+//
+//	type sdkSecrets struct{ *secretsmanager.Client }
+//
+//	func (s sdkSecrets) GetSecretString(ctx context.Context, secretID string) (string, error) {
+//		out, err := s.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: &secretID})
+//		if err != nil {
+//			return "", err
+//		}
+//		return aws.ToString(out.SecretString), nil
+//	}
+type SecretsManagerAPI interface {
+	GetSecretString(ctx context.Context, secretID string) (string, error)
+}
+
+// AWSSecretsManager reads the API key from an AWS Secrets Manager secret. A
+// secret stored as JSON is looked up by Field; a plain-text secret is the key.
+type AWSSecretsManager struct {
+	Client   SecretsManagerAPI
+	SecretID string // Name or ARN of the secret
+	Field    string // JSON field holding the key, "api_key" when empty
+}
+
+// APIKey implements client.CredentialsProvider
+func (a AWSSecretsManager) APIKey(ctx context.Context) (string, error) {
+	secret, err := a.Client.GetSecretString(ctx, a.SecretID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read AWS secret %s: %w", a.SecretID, err)
+	}
+	key, err := secretField(secret, a.Field)
+	if err != nil {
+		return "", fmt.Errorf("AWS secret %s: %w", a.SecretID, err)
+	}
+	return key, nil
+}
+
+// SecretAccessor is the part of a GCP Secret Manager client that
+// GCPSecretManager needs. Adapt the Google Cloud SDK with a few lines:
+//
+//	type sdkSecrets struct{ *secretmanager.Client }
+//
+//	func (s sdkSecrets) AccessSecret(ctx context.Context, name string) ([]byte, error) {
+//		out, err := s.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: name})
+//		if err != nil {
+//			return nil, err
+//		}
+//		return out.Payload.Data, nil
+//	}
+type SecretAccessor interface {
+	AccessSecret(ctx context.Context, name string) ([]byte, error)
+}
+
+// GCPSecretManager reads the API key from a GCP Secret Manager secret
+// version. A secret stored as JSON is looked up by Field; a plain-text secret
+// is the key.
+type GCPSecretManager struct {
+	Client SecretAccessor
+	Name   string // e.g. "projects/my-project/secrets/mem0/versions/latest"
+	Field  string // JSON field holding the key, "api_key" when empty
+}
+
+// APIKey implements client.CredentialsProvider
+func (g GCPSecretManager) APIKey(ctx context.Context) (string, error) {
+	secret, err := g.Client.AccessSecret(ctx, g.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read GCP secret %s: %w", g.Name, err)
+	}
+	key, err := secretField(string(secret), g.Field)
+	if err != nil {
+		return "", fmt.Errorf("GCP secret %s: %w", g.Name, err)
+	}
+	return key, nil
+}
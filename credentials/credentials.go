@@ -0,0 +1,155 @@
+// Package credentials provides client.CredentialsProvider implementations
+// that load the Mem0 API key from the environment, a file, HashiCorp Vault or
+// a cloud secret manager, so the key never lives in plain configuration.
+//
+// Pass a provider as ClientOptions.Credentials to load the key once, or wrap
+// it with Cached and pass it as ClientOptions.TokenSource to pick up rotated
+// keys while the client runs.
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// DefaultEnvVar is the environment variable Env reads when given no name
+const DefaultEnvVar = "MEM0_API_KEY"
+
+// ErrNotFound is returned when a provider has no API key to offer
+var ErrNotFound = errors.New("credentials not found")
+
+// Env reads the API key from an environment variable
+type Env struct {
+	Name string // DefaultEnvVar when empty
+}
+
+// APIKey implements client.CredentialsProvider
+func (e Env) APIKey(ctx context.Context) (string, error) {
+	name := e.Name
+	if name == "" {
+		name = DefaultEnvVar
+	}
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return "", fmt.Errorf("environment variable %s: %w", name, ErrNotFound)
+	}
+	return value, nil
+}
+
+// File reads the API key from a file, such as a mounted Kubernetes or Docker
+// secret. Surrounding whitespace is trimmed.
+type File struct {
+	Path string
+}
+
+// APIKey implements client.CredentialsProvider
+func (f File) APIKey(ctx context.Context) (string, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("file %s: %w", f.Path, ErrNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return "", fmt.Errorf("file %s is empty: %w", f.Path, ErrNotFound)
+	}
+	return value, nil
+}
+
+// Chain tries each provider in order and returns the first key found.
+// Errors other than ErrNotFound stop the search.
+type Chain []client.CredentialsProvider
+
+// APIKey implements client.CredentialsProvider
+func (c Chain) APIKey(ctx context.Context) (string, error) {
+	for _, provider := range c {
+		key, err := provider.APIKey(ctx)
+		if err == nil {
+			return key, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return "", err
+		}
+	}
+	return "", ErrNotFound
+}
+
+// CachedOptions configures Cached
+type CachedOptions struct {
+	OnError func(error) // Optional: called when a refresh fails and the previous key is served instead
+}
+
+// Cached adapts a provider to a client.TokenSource that asks the provider
+// again once ttl has passed. If a refresh fails, the previous key is served,
+// the error is passed to OnError and the next call tries to refresh again.
+func Cached(provider client.CredentialsProvider, ttl time.Duration, options ...CachedOptions) client.TokenSource {
+	c := &cached{provider: provider, ttl: ttl}
+	if len(options) > 0 {
+		c.onError = options[0].OnError
+	}
+	return c
+}
+
+type cached struct {
+	provider client.CredentialsProvider
+	ttl      time.Duration
+	onError  func(error)
+
+	mu      sync.Mutex
+	key     string
+	fetched time.Time
+}
+
+// Token implements client.TokenSource
+func (c *cached) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.key != "" && time.Since(c.fetched) < c.ttl {
+		return c.key, nil
+	}
+	key, err := c.provider.APIKey(ctx)
+	if err != nil {
+		if c.key == "" {
+			return "", err
+		}
+		// Keep fetched so that the next call retries the refresh
+		if c.onError != nil {
+			c.onError(fmt.Errorf("failed to refresh API key, serving the previous one: %w", err))
+		}
+		return c.key, nil
+	}
+	c.key, c.fetched = key, time.Now()
+	return key, nil
+}
+
+// secretField returns the API key held in a secret. A secret that is a JSON
+// object is looked up by field, and a plain string is returned as is.
+func secretField(secret, field string) (string, error) {
+	if field == "" {
+		field = "api_key"
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(secret), &object); err != nil {
+		value := strings.TrimSpace(secret)
+		if value == "" {
+			return "", ErrNotFound
+		}
+		return value, nil
+	}
+	value, ok := object[field].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("field %q: %w", field, ErrNotFound)
+	}
+	return value, nil
+}
@@ -0,0 +1,176 @@
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/credentials"
+)
+
+type fakeSecrets map[string]string
+
+func (f fakeSecrets) GetSecretString(ctx context.Context, secretID string) (string, error) {
+	if secret, ok := f[secretID]; ok {
+		return secret, nil
+	}
+	return "", errors.New("ResourceNotFoundException")
+}
+
+func (f fakeSecrets) AccessSecret(ctx context.Context, name string) ([]byte, error) {
+	secret, err := f.GetSecretString(ctx, name)
+	return []byte(secret), err
+}
+
+func TestProviders(t *testing.T) {
+	t.Setenv("MEM0_API_KEY", " m0-env \n")
+	t.Setenv("EMPTY_KEY", "")
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "api-key")
+	os.WriteFile(keyFile, []byte("m0-file\n"), 0o600)
+
+	secrets := fakeSecrets{
+		"mem0/json":  `{"api_key": "m0-json", "other": "x"}`,
+		"mem0/plain": "m0-plain",
+	}
+
+	tests := []struct {
+		name     string
+		provider client.CredentialsProvider
+		want     string
+		notFound bool
+	}{
+		{name: "env default", provider: credentials.Env{}, want: "m0-env"},
+		{name: "env empty", provider: credentials.Env{Name: "EMPTY_KEY"}, notFound: true},
+		{name: "file", provider: credentials.File{Path: keyFile}, want: "m0-file"},
+		{name: "missing file", provider: credentials.File{Path: filepath.Join(dir, "missing")}, notFound: true},
+		{name: "chain skips missing", provider: credentials.Chain{credentials.Env{Name: "EMPTY_KEY"}, credentials.File{Path: keyFile}}, want: "m0-file"},
+		{name: "aws json", provider: credentials.AWSSecretsManager{Client: secrets, SecretID: "mem0/json"}, want: "m0-json"},
+		{name: "aws json missing field", provider: credentials.AWSSecretsManager{Client: secrets, SecretID: "mem0/json", Field: "key"}, notFound: true},
+		{name: "gcp plain", provider: credentials.GCPSecretManager{Client: secrets, Name: "mem0/plain"}, want: "m0-plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.APIKey(context.Background())
+			if tt.notFound {
+				if !errors.Is(err, credentials.ErrNotFound) {
+					t.Errorf("APIKey() error = %v, want ErrNotFound", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("APIKey() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVault(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": ["permission denied"]}`))
+			return
+		}
+		if r.URL.Path != "/v1/kv/data/mem0/prod" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"data": {"data": {"api_key": "m0-vault"}, "metadata": {"version": 3}}}`))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	key, err := credentials.Vault{Address: srv.URL, Token: "s.token", Mount: "kv", Path: "mem0/prod"}.APIKey(ctx)
+	if err != nil || key != "m0-vault" {
+		t.Errorf("APIKey() = %q, %v; want m0-vault", key, err)
+	}
+	if _, err := (credentials.Vault{Address: srv.URL, Token: "s.token", Mount: "kv", Path: "mem0/dev"}).APIKey(ctx); !errors.Is(err, credentials.ErrNotFound) {
+		t.Errorf("APIKey(missing path) error = %v, want ErrNotFound", err)
+	}
+	if _, err := (credentials.Vault{Address: srv.URL, Token: "wrong", Mount: "kv", Path: "mem0/prod"}).APIKey(ctx); err == nil || errors.Is(err, credentials.ErrNotFound) {
+		t.Errorf("APIKey(bad token) error = %v, want permission error", err)
+	}
+}
+
+type sequence struct {
+	keys  []string
+	err   error
+	calls int
+}
+
+func (s *sequence) APIKey(ctx context.Context) (string, error) {
+	s.calls++
+	if s.err != nil {
+		return "", s.err
+	}
+	key := s.keys[0]
+	s.keys = s.keys[1:]
+	return key, nil
+}
+
+func TestCached(t *testing.T) {
+	ctx := context.Background()
+	provider := &sequence{keys: []string{"m0-first", "m0-second", "m0-third"}}
+	var reported []error
+	source := credentials.Cached(provider, 20*time.Millisecond, credentials.CachedOptions{
+		OnError: func(err error) { reported = append(reported, err) },
+	})
+
+	for i := 0; i < 3; i++ {
+		if key, _ := source.Token(ctx); key != "m0-first" {
+			t.Fatalf("Token() = %q, want m0-first", key)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("provider calls = %d, want 1", provider.calls)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if key, _ := source.Token(ctx); key != "m0-second" {
+		t.Errorf("Token() after ttl = %q, want m0-second", key)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	provider.err = errors.New("secrets manager unavailable")
+	if key, err := source.Token(ctx); err != nil || key != "m0-second" {
+		t.Errorf("Token() on failed refresh = %q, %v; want previous key", key, err)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], provider.err) {
+		t.Errorf("OnError got %v, want the refresh error", reported)
+	}
+
+	// The failed refresh is retried on the next call rather than after ttl
+	provider.err = nil
+	if key, _ := source.Token(ctx); key != "m0-third" {
+		t.Errorf("Token() after a failed refresh = %q, want m0-third", key)
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	var authorization string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Write([]byte(`{"status":"ok","org_id":"org-1","project_id":"proj-1","user_email":"test@example.com"}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("MEM0_TEST_KEY", "m0-from-env")
+	if _, err := client.NewMemoryClient(client.ClientOptions{Host: &srv.URL, Credentials: credentials.Env{Name: "MEM0_TEST_KEY"}}); err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	if authorization != "Token m0-from-env" {
+		t.Errorf("Authorization = %q, want Token m0-from-env", authorization)
+	}
+
+	if _, err := client.NewMemoryClient(client.ClientOptions{Host: &srv.URL, Credentials: credentials.Env{Name: "MEM0_UNSET_KEY"}}); !errors.Is(err, credentials.ErrNotFound) {
+		t.Errorf("NewMemoryClient() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,88 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Vault reads the API key from a HashiCorp Vault KV version 2 secrets engine
+// over Vault's HTTP API
+type Vault struct {
+	Address    string       // VAULT_ADDR when empty
+	Token      string       // VAULT_TOKEN when empty
+	Mount      string       // Secrets engine mount, "secret" when empty
+	Path       string       // Secret path within the mount, e.g. "mem0/prod"
+	Field      string       // Field holding the key, "api_key" when empty
+	HTTPClient *http.Client // http.DefaultClient when nil
+}
+
+// APIKey implements client.CredentialsProvider
+func (v Vault) APIKey(ctx context.Context) (string, error) {
+	address := v.Address
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	token := v.Token
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if address == "" || v.Path == "" {
+		return "", fmt.Errorf("vault address and path are required")
+	}
+	mount := v.Mount
+	if mount == "" {
+		mount = "secret"
+	}
+	field := v.Field
+	if field == "" {
+		field = "api_key"
+	}
+	httpClient := v.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	endpoint, err := url.JoinPath(address, "v1", mount, "data", strings.TrimPrefix(v.Path, "/"))
+	if err != nil {
+		return "", fmt.Errorf("invalid vault address: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("vault secret %s/%s: %w", mount, v.Path, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("vault request failed (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("failed to parse vault response: %w", err)
+	}
+	value, ok := secret.Data.Data[field].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("vault secret %s/%s field %q: %w", mount, v.Path, field, ErrNotFound)
+	}
+	return value, nil
+}
@@ -0,0 +1,242 @@
+package ingest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseOpenAI reads OpenAI chat messages, either a JSON array or an object
+// with a messages field. Content may be a string or a list of parts, of which
+// the text parts are kept. System, developer and tool messages are skipped.
+func ParseOpenAI(r io.Reader) ([]Turn, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+
+	type openAIMessage struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+	}
+	var messages []openAIMessage
+	if err := json.Unmarshal(data, &messages); err != nil {
+		var wrapper struct {
+			Messages []openAIMessage `json:"messages"`
+		}
+		if err := json.Unmarshal(data, &wrapper); err != nil {
+			return nil, fmt.Errorf("failed to parse OpenAI transcript: %w", err)
+		}
+		messages = wrapper.Messages
+	}
+
+	var turns []Turn
+	for i, message := range messages {
+		if message.Role != "user" && message.Role != "assistant" {
+			continue
+		}
+		text, err := openAIText(message.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse message %d: %w", i, err)
+		}
+		if text != "" {
+			turns = append(turns, Turn{Role: message.Role, Text: text})
+		}
+	}
+	return turns, nil
+}
+
+// openAIText extracts the text of a string or list-of-parts content
+func openAIText(content json.RawMessage) (string, error) {
+	if len(content) == 0 || string(content) == "null" {
+		return "", nil
+	}
+	var text string
+	if err := json.Unmarshal(content, &text); err == nil {
+		return strings.TrimSpace(text), nil
+	}
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(content, &parts); err != nil {
+		return "", err
+	}
+	var texts []string
+	for _, part := range parts {
+		if part.Type == "text" && strings.TrimSpace(part.Text) != "" {
+			texts = append(texts, strings.TrimSpace(part.Text))
+		}
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// ParseChatML reads a ChatML transcript of <|im_start|>role ... <|im_end|>
+// blocks. System and tool blocks are skipped.
+func ParseChatML(r io.Reader) ([]Turn, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+
+	var turns []Turn
+	blocks := strings.Split(string(data), "<|im_start|>")
+	for _, block := range blocks[1:] {
+		block, _, _ = strings.Cut(block, "<|im_end|>")
+		header, text, _ := strings.Cut(block, "\n")
+		role := strings.TrimSpace(header)
+		// A header may carry a name, as in "user name=alice"
+		role, _, _ = strings.Cut(role, " ")
+		if role != "user" && role != "assistant" {
+			continue
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			turns = append(turns, Turn{Role: role, Text: text})
+		}
+	}
+	if len(turns) == 0 && len(blocks) == 1 && strings.TrimSpace(string(data)) != "" {
+		return nil, fmt.Errorf("failed to parse ChatML transcript: no <|im_start|> blocks")
+	}
+	return turns, nil
+}
+
+// ParseSlack reads the JSON array of one channel file from a Slack export.
+// Speakers are user IDs, or the bot or user name when the export includes
+// it; bot messages are assistant turns. Join, leave and other system
+// subtypes are skipped.
+func ParseSlack(r io.Reader, options SpeakerOptions) ([]Turn, error) {
+	var messages []struct {
+		Type        string `json:"type"`
+		Subtype     string `json:"subtype"`
+		User        string `json:"user"`
+		Username    string `json:"username"`
+		BotID       string `json:"bot_id"`
+		Text        string `json:"text"`
+		Ts          string `json:"ts"`
+		UserProfile struct {
+			RealName string `json:"real_name"`
+		} `json:"user_profile"`
+	}
+	if err := json.NewDecoder(r).Decode(&messages); err != nil {
+		return nil, fmt.Errorf("failed to parse Slack export: %w", err)
+	}
+
+	var turns []Turn
+	for _, message := range messages {
+		if message.Type != "message" || strings.TrimSpace(message.Text) == "" {
+			continue
+		}
+		bot := message.BotID != "" || message.Subtype == "bot_message"
+		if message.Subtype != "" && message.Subtype != "bot_message" && message.Subtype != "thread_broadcast" {
+			continue
+		}
+
+		speaker := message.User
+		switch {
+		case message.UserProfile.RealName != "":
+			speaker = message.UserProfile.RealName
+		case message.Username != "":
+			speaker = message.Username
+		}
+		role := "user"
+		if bot || options.role(speaker) == "assistant" || options.role(message.User) == "assistant" {
+			role = "assistant"
+		}
+		turns = append(turns, Turn{Role: role, Speaker: speaker, Text: strings.TrimSpace(message.Text), Time: slackTime(message.Ts)})
+	}
+	return turns, nil
+}
+
+// slackTime parses a Slack "seconds.micros" timestamp
+func slackTime(ts string) time.Time {
+	seconds, fraction, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	micros, _ := strconv.ParseInt((fraction + "000000")[:6], 10, 64)
+	return time.Unix(sec, micros*1000).UTC()
+}
+
+// whatsAppLine matches the first line of a WhatsApp message in both the
+// Android ("12/31/23, 9:41 PM - Alice: hi") and iOS
+// ("[31/12/2023, 21:41:05] Alice: hi") export formats
+var whatsAppLine = regexp.MustCompile(`^\[?(\d{1,2}/\d{1,2}/\d{2,4}),? (\d{1,2}:\d{2}(?::\d{2})?(?:\s?[AaPp]\.?[Mm]\.?)?)\]?(?: -)? ([^:]+): (.*)$`)
+
+// ParseWhatsApp reads a WhatsApp "export chat" text file. Lines without a
+// date start continue the previous message; system notices without an
+// author are skipped. Dates are read day first unless the first field is
+// larger than 12. Timestamps are interpreted in loc, or UTC when nil.
+func ParseWhatsApp(r io.Reader, options SpeakerOptions, loc *time.Location) ([]Turn, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var turns []Turn
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "\u200e")
+		match := whatsAppLine.FindStringSubmatch(line)
+		if match == nil {
+			if len(turns) > 0 && !startsWithDate(line) {
+				turns[len(turns)-1].Text += "\n" + line
+			}
+			continue
+		}
+		speaker := strings.TrimSpace(match[3])
+		text := strings.TrimSpace(match[4])
+		if text == "" || text == "<Media omitted>" {
+			continue
+		}
+		turns = append(turns, Turn{
+			Role:    options.role(speaker),
+			Speaker: speaker,
+			Text:    text,
+			Time:    whatsAppTime(match[1], match[2], loc),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read WhatsApp export: %w", err)
+	}
+	for i := range turns {
+		turns[i].Text = strings.TrimSpace(turns[i].Text)
+	}
+	return turns, nil
+}
+
+var whatsAppDate = regexp.MustCompile(`^\[?\d{1,2}/\d{1,2}/\d{2,4}`)
+
+// startsWithDate reports whether line is a dated system notice
+func startsWithDate(line string) bool {
+	return whatsAppDate.MatchString(line)
+}
+
+// whatsAppTime parses the date and time fields of a message line, returning
+// the zero time when they can't be read
+func whatsAppTime(date, clock string, loc *time.Location) time.Time {
+	fields := strings.Split(date, "/")
+	first, _ := strconv.Atoi(fields[0])
+	second, _ := strconv.Atoi(fields[1])
+	year, _ := strconv.Atoi(fields[2])
+	if year < 100 {
+		year += 2000
+	}
+	day, month := first, second
+	if second > 12 {
+		day, month = second, first
+	}
+
+	clock = strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(clock, ".", ""), " ", ""))
+	for _, layout := range []string{"15:04:05", "15:04", "3:04:05PM", "3:04PM"} {
+		if t, err := time.Parse(layout, clock); err == nil {
+			return time.Date(year, time.Month(month), day, t.Hour(), t.Minute(), t.Second(), 0, loc)
+		}
+	}
+	return time.Time{}
+}
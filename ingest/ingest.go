@@ -0,0 +1,113 @@
+// Package ingest converts conversation transcripts into Add calls so
+// historical conversations can be backfilled. Parsers read OpenAI chat JSON,
+// ChatML, Slack exports and WhatsApp exports into turns, and Chunk groups the
+// turns into batches small enough for one Add call each.
+package ingest
+
+import (
+	"strings"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// Chunk limits used when ChunkOptions leaves them at zero
+const (
+	DefaultMaxMessages = 50
+	DefaultMaxChars    = 16000
+)
+
+// Turn is one message of a transcript
+type Turn struct {
+	Role    string    // "user" or "assistant"
+	Speaker string    // Author name or ID, when the format records one
+	Text    string    // Message text
+	Time    time.Time // Zero when the format has no timestamps
+}
+
+// Message converts the turn to a client.Message, prefixing the text with the
+// speaker so multi-party conversations keep their authors
+func (t Turn) Message() client.Message {
+	content := t.Text
+	if t.Speaker != "" {
+		content = t.Speaker + ": " + t.Text
+	}
+	return client.Message{Role: t.Role, Content: content}
+}
+
+// SpeakerOptions maps the authors of Slack and WhatsApp messages to roles
+type SpeakerOptions struct {
+	Assistants []string // Names or Slack user IDs whose messages get the assistant role; everyone else is a user
+}
+
+// role returns the role of speaker
+func (o SpeakerOptions) role(speaker string) string {
+	for _, assistant := range o.Assistants {
+		if strings.EqualFold(assistant, speaker) {
+			return "assistant"
+		}
+	}
+	return "user"
+}
+
+// ChunkOptions limits the size of each batch
+type ChunkOptions struct {
+	MaxMessages int // Messages per batch, DefaultMaxMessages when zero
+	MaxChars    int // Characters of content per batch, DefaultMaxChars when zero
+}
+
+// Batch is a group of turns sent in one Add call
+type Batch struct {
+	Messages []client.Message
+	Time     time.Time // Time of the last timestamped turn, zero when none
+}
+
+// Chunk splits turns into batches in order. A turn longer than MaxChars gets
+// a batch of its own rather than being cut.
+func Chunk(turns []Turn, options ChunkOptions) []Batch {
+	maxMessages := options.MaxMessages
+	if maxMessages <= 0 {
+		maxMessages = DefaultMaxMessages
+	}
+	maxChars := options.MaxChars
+	if maxChars <= 0 {
+		maxChars = DefaultMaxChars
+	}
+
+	var batches []Batch
+	var current Batch
+	chars := 0
+	for _, turn := range turns {
+		message := turn.Message()
+		size := len(message.Content.(string))
+		if len(current.Messages) > 0 && (len(current.Messages) >= maxMessages || chars+size > maxChars) {
+			batches = append(batches, current)
+			current, chars = Batch{}, 0
+		}
+		current.Messages = append(current.Messages, message)
+		chars += size
+		if !turn.Time.IsZero() {
+			current.Time = turn.Time
+		}
+	}
+	if len(current.Messages) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// Requests turns batches into client.AddBatch requests with options. Unless
+// options sets a Timestamp, each request is stamped with its batch's time so
+// memories keep the date of the original conversation.
+func Requests(batches []Batch, options client.MemoryOptions) []client.AddRequest {
+	requests := make([]client.AddRequest, len(batches))
+	for i, batch := range batches {
+		requestOptions := options
+		if options.Timestamp == nil && !batch.Time.IsZero() {
+			timestamp := batch.Time.Unix()
+			requestOptions.Timestamp = &timestamp
+		}
+		requests[i] = client.AddRequest{Messages: batch.Messages, Options: requestOptions}
+	}
+	return requests
+}
@@ -0,0 +1,135 @@
+package ingest_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/ingest"
+)
+
+func contents(turns []ingest.Turn) []string {
+	var out []string
+	for _, turn := range turns {
+		out = append(out, turn.Role+"|"+turn.Message().Content.(string))
+	}
+	return out
+}
+
+func assertTurns(t *testing.T, turns []ingest.Turn, want ...string) {
+	t.Helper()
+	got := contents(turns)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("turns =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParseOpenAI(t *testing.T) {
+	transcript := `{"messages": [
+		{"role": "system", "content": "You are helpful."},
+		{"role": "user", "content": "I'm vegetarian."},
+		{"role": "assistant", "content": [{"type": "text", "text": "Noted!"}, {"type": "image_url", "image_url": {"url": "x"}}]},
+		{"role": "tool", "content": "{}"},
+		{"role": "assistant", "content": null}
+	]}`
+	turns, err := ingest.ParseOpenAI(strings.NewReader(transcript))
+	if err != nil {
+		t.Fatalf("ParseOpenAI() error = %v", err)
+	}
+	assertTurns(t, turns, "user|I'm vegetarian.", "assistant|Noted!")
+
+	turns, err = ingest.ParseOpenAI(strings.NewReader(`[{"role": "user", "content": "hi"}]`))
+	if err != nil || len(turns) != 1 {
+		t.Errorf("ParseOpenAI(array) = %v, %v", turns, err)
+	}
+	if _, err := ingest.ParseOpenAI(strings.NewReader(`not json`)); err == nil {
+		t.Error("ParseOpenAI(invalid) expected error")
+	}
+}
+
+func TestParseChatML(t *testing.T) {
+	transcript := "<|im_start|>system\nBe brief.<|im_end|>\n" +
+		"<|im_start|>user\nMy dog is called Rex.\nHe is 3.<|im_end|>\n" +
+		"<|im_start|>assistant\nCute!<|im_end|>\n"
+	turns, err := ingest.ParseChatML(strings.NewReader(transcript))
+	if err != nil {
+		t.Fatalf("ParseChatML() error = %v", err)
+	}
+	assertTurns(t, turns, "user|My dog is called Rex.\nHe is 3.", "assistant|Cute!")
+
+	if _, err := ingest.ParseChatML(strings.NewReader("plain text")); err == nil {
+		t.Error("ParseChatML(plain text) expected error")
+	}
+}
+
+func TestParseSlack(t *testing.T) {
+	export := `[
+		{"type": "message", "subtype": "channel_join", "user": "U1", "text": "<@U1> has joined", "ts": "1700000000.000100"},
+		{"type": "message", "user": "U1", "text": "Deploys happen on Tuesdays", "ts": "1700000100.000200", "user_profile": {"real_name": "Alice"}},
+		{"type": "message", "subtype": "bot_message", "bot_id": "B1", "username": "helper", "text": "Got it", "ts": "1700000200.000000"},
+		{"type": "message", "user": "U2", "text": "I answer questions", "ts": "1700000300.000000"}
+	]`
+	turns, err := ingest.ParseSlack(strings.NewReader(export), ingest.SpeakerOptions{Assistants: []string{"U2"}})
+	if err != nil {
+		t.Fatalf("ParseSlack() error = %v", err)
+	}
+	assertTurns(t, turns, "user|Alice: Deploys happen on Tuesdays", "assistant|helper: Got it", "assistant|U2: I answer questions")
+	if want := time.Unix(1700000100, 200000).UTC(); !turns[0].Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", turns[0].Time, want)
+	}
+}
+
+func TestParseWhatsApp(t *testing.T) {
+	export := "12/31/23, 9:41 PM - Messages and calls are end-to-end encrypted.\n" +
+		"12/31/23, 9:41 PM - Alice: I'm moving to Lisbon\n" +
+		"in March\n" +
+		"12/31/23, 9:42 PM - Bot: Exciting!\n" +
+		"12/31/23, 9:43 PM - Alice: <Media omitted>\n" +
+		"[05/01/2024, 08:15:30] Alice: New job starts Monday\n"
+	turns, err := ingest.ParseWhatsApp(strings.NewReader(export), ingest.SpeakerOptions{Assistants: []string{"bot"}}, nil)
+	if err != nil {
+		t.Fatalf("ParseWhatsApp() error = %v", err)
+	}
+	assertTurns(t, turns, "user|Alice: I'm moving to Lisbon\nin March", "assistant|Bot: Exciting!", "user|Alice: New job starts Monday")
+
+	if want := time.Date(2023, 12, 31, 21, 41, 0, 0, time.UTC); !turns[0].Time.Equal(want) {
+		t.Errorf("Android time = %v, want %v", turns[0].Time, want)
+	}
+	if want := time.Date(2024, 1, 5, 8, 15, 30, 0, time.UTC); !turns[2].Time.Equal(want) {
+		t.Errorf("iOS time = %v, want %v", turns[2].Time, want)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var turns []ingest.Turn
+	for i := 0; i < 5; i++ {
+		turns = append(turns, ingest.Turn{Role: "user", Text: strings.Repeat("x", 10), Time: base.Add(time.Duration(i) * time.Hour)})
+	}
+	turns = append(turns, ingest.Turn{Role: "user", Text: strings.Repeat("y", 100)})
+
+	batches := ingest.Chunk(turns, ingest.ChunkOptions{MaxMessages: 2, MaxChars: 50})
+	var sizes []int
+	for _, batch := range batches {
+		sizes = append(sizes, len(batch.Messages))
+	}
+	if len(sizes) != 4 || sizes[0] != 2 || sizes[1] != 2 || sizes[2] != 1 || sizes[3] != 1 {
+		t.Fatalf("batch sizes = %v, want [2 2 1 1]", sizes)
+	}
+	if !batches[1].Time.Equal(base.Add(3*time.Hour)) || !batches[3].Time.IsZero() {
+		t.Errorf("batch times = %v, %v", batches[1].Time, batches[3].Time)
+	}
+
+	userID := "alice"
+	requests := ingest.Requests(batches, client.MemoryOptions{UserID: &userID})
+	if len(requests) != 4 || *requests[0].Options.UserID != "alice" {
+		t.Fatalf("Requests() = %+v", requests)
+	}
+	if requests[1].Options.Timestamp == nil || *requests[1].Options.Timestamp != base.Add(3*time.Hour).Unix() {
+		t.Errorf("Timestamp = %v, want batch time", requests[1].Options.Timestamp)
+	}
+	if requests[3].Options.Timestamp != nil {
+		t.Errorf("Timestamp = %v, want nil for untimed batch", *requests[3].Options.Timestamp)
+	}
+}
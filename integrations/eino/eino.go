@@ -0,0 +1,113 @@
+// Package eino adapts the Mem0 client to CloudWeGo Eino. Retriever
+// implements retriever.Retriever over memory search and Indexer implements
+// indexer.Indexer by adding documents as memories, so Mem0 can serve as the
+// memory store of an Eino graph.
+//
+// The package is a separate module so the core client does not depend on
+// Eino.
+package eino
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/murilopl/go-mem0/client"
+)
+
+var (
+	_ retriever.Retriever = (*Retriever)(nil)
+	_ indexer.Indexer     = (*Indexer)(nil)
+)
+
+// Retriever is a retriever.Retriever backed by Mem0 search. The common TopK
+// and ScoreThreshold options map to limit and threshold.
+type Retriever struct {
+	Client  client.Client
+	Options client.SearchOptions
+}
+
+// NewRetriever creates a Retriever that searches with options
+func NewRetriever(c client.Client, options client.SearchOptions) *Retriever {
+	return &Retriever{Client: c, Options: options}
+}
+
+// Retrieve implements retriever.Retriever
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	common := retriever.GetCommonOptions(&retriever.Options{}, opts...)
+	search := r.Options
+	if common.TopK != nil {
+		search.Limit = common.TopK
+	}
+	if common.ScoreThreshold != nil {
+		search.Threshold = common.ScoreThreshold
+	}
+
+	memories, err := r.Client.Search(ctx, query, search)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]*schema.Document, 0, len(memories))
+	for _, memory := range memories {
+		metadata := map[string]any{}
+		if memory.Metadata != nil {
+			metadata["metadata"] = memory.Metadata
+		}
+		if len(memory.Categories) > 0 {
+			metadata["categories"] = memory.Categories
+		}
+		document := &schema.Document{ID: memory.ID, Content: memory.Text(), MetaData: metadata}
+		if memory.Score != nil {
+			document.WithScore(*memory.Score)
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// GetType returns the component type name shown in Eino callbacks
+func (r *Retriever) GetType() string {
+	return "Mem0"
+}
+
+// Indexer is an indexer.Indexer that stores documents as Mem0 memories
+type Indexer struct {
+	Client  client.Client
+	Options client.MemoryOptions // Entity IDs and settings for every Add
+}
+
+// NewIndexer creates an Indexer that adds memories with options
+func NewIndexer(c client.Client, options client.MemoryOptions) *Indexer {
+	return &Indexer{Client: c, Options: options}
+}
+
+// Store implements indexer.Indexer. Each document is added as a user
+// message; the returned IDs are those of the memories created, which may be
+// more or fewer than the documents since Mem0 extracts facts.
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	var ids []string
+	for _, doc := range docs {
+		if doc == nil || doc.Content == "" {
+			continue
+		}
+		options := i.Options
+		if len(doc.MetaData) > 0 && options.Metadata == nil {
+			options.Metadata = doc.MetaData
+		}
+		memories, err := i.Client.Add(ctx, []client.Message{{Role: "user", Content: doc.Content}}, options)
+		if err != nil {
+			return ids, err
+		}
+		for _, memory := range memories {
+			ids = append(ids, memory.ID)
+		}
+	}
+	return ids, nil
+}
+
+// GetType returns the component type name shown in Eino callbacks
+func (i *Indexer) GetType() string {
+	return "Mem0"
+}
@@ -0,0 +1,68 @@
+package eino_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+	"github.com/murilopl/go-mem0/integrations/eino"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+func TestIndexerAndRetriever(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	memoryClient, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+	userID := "alex"
+
+	indexer := eino.NewIndexer(memoryClient, client.MemoryOptions{UserID: &userID})
+	ids, err := indexer.Store(ctx, []*schema.Document{
+		{Content: "I am a vegetarian", MetaData: map[string]any{"source": "chat"}},
+		{Content: ""},
+		{Content: "I live in Lisbon"},
+	})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("Store() ids = %v, want 2", ids)
+	}
+	memories := srv.Memories()
+	if len(memories) != 2 || *memories[0].UserID != userID {
+		t.Errorf("stored memories = %+v", memories)
+	}
+
+	r := eino.NewRetriever(memoryClient, client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID}})
+	docs, err := r.Retrieve(ctx, "vegetarian food", retriever.WithTopK(1))
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if len(docs) != 1 || docs[0].ID != ids[0] || docs[0].Content != "I am a vegetarian" || docs[0].Score() <= 0 {
+		t.Errorf("Retrieve() = %+v", docs)
+	}
+}
+
+func TestRetrieverOptions(t *testing.T) {
+	var got client.SearchOptions
+	mock := &clienttest.MockClient{
+		SearchFunc: func(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error) {
+			got = options[0]
+			return nil, nil
+		},
+	}
+
+	r := eino.NewRetriever(mock, client.SearchOptions{})
+	if _, err := r.Retrieve(context.Background(), "q", retriever.WithTopK(3), retriever.WithScoreThreshold(0.5)); err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if got.Limit == nil || *got.Limit != 3 || got.Threshold == nil || *got.Threshold != 0.5 {
+		t.Errorf("search options = %+v, want limit 3 and threshold 0.5", got)
+	}
+}
@@ -0,0 +1,178 @@
+// Package genkit adapts the Mem0 client to Firebase Genkit. Plugin registers
+// a retriever over memory search and tools that let models add and search
+// memories.
+//
+// The package is a separate module so the core client does not depend on
+// Genkit.
+package genkit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/core/api"
+	"github.com/murilopl/go-mem0/client"
+)
+
+// Provider is the plugin name and the prefix of the actions it registers
+const Provider = "mem0"
+
+// Action names registered by Plugin
+const (
+	RetrieverName    = Provider + "/memories"
+	AddMemoryTool    = Provider + "/add_memory"
+	SearchMemoryTool = Provider + "/search_memory"
+)
+
+var _ api.Plugin = (*Plugin)(nil)
+
+// Plugin registers the Mem0 retriever and memory tools with Genkit
+type Plugin struct {
+	Client  client.Client
+	Options client.MemoryOptions // Entity IDs the retriever and tools default to
+}
+
+// Name implements api.Plugin
+func (p *Plugin) Name() string {
+	return Provider
+}
+
+// Init implements api.Plugin
+func (p *Plugin) Init(ctx context.Context) []api.Action {
+	return []api.Action{
+		NewRetriever(p.Client, p.Options),
+		NewAddMemoryTool(p.Client, p.Options),
+		NewSearchMemoryTool(p.Client, p.Options),
+	}
+}
+
+// RetrieverConfig scopes a single retrieval. Set fields override the
+// retriever's default options.
+type RetrieverConfig struct {
+	UserID  string `json:"userId,omitempty"`
+	AgentID string `json:"agentId,omitempty"`
+	RunID   string `json:"runId,omitempty"`
+	Limit   int    `json:"limit,omitempty"`
+}
+
+// NewRetriever creates a retriever that searches memories with the text of
+// the query document
+func NewRetriever(c client.Client, options client.MemoryOptions) *ai.RetrieverAction {
+	return ai.NewRetrieverAction(RetrieverName, &ai.RetrieverOptions{Label: "Mem0 memories"},
+		func(ctx context.Context, req *ai.RetrieverRequest, config RetrieverConfig) (*ai.RetrieverResponse, error) {
+			search := client.SearchOptions{MemoryOptions: scope(options, config.UserID, config.AgentID, config.RunID)}
+			if config.Limit > 0 {
+				limit := config.Limit
+				search.Limit = &limit
+			}
+
+			memories, err := c.Search(ctx, documentText(req.Query), search)
+			if err != nil {
+				return nil, err
+			}
+
+			documents := make([]*ai.Document, 0, len(memories))
+			for _, memory := range memories {
+				metadata := map[string]any{"id": memory.ID}
+				if memory.Score != nil {
+					metadata["score"] = *memory.Score
+				}
+				if memory.Metadata != nil {
+					metadata["metadata"] = memory.Metadata
+				}
+				documents = append(documents, ai.DocumentFromText(memory.Text(), metadata))
+			}
+			return &ai.RetrieverResponse{Documents: documents}, nil
+		})
+}
+
+// AddMemoryInput is the input of the add_memory tool
+type AddMemoryInput struct {
+	Content string `json:"content" jsonschema_description:"The fact to remember, in a short sentence"`
+}
+
+// SearchMemoryInput is the input of the search_memory tool
+type SearchMemoryInput struct {
+	Query string `json:"query" jsonschema_description:"What to look for"`
+	Limit int    `json:"limit,omitempty" jsonschema_description:"Maximum number of memories to return"`
+}
+
+// MemoryOutput is a memory returned by the tools
+type MemoryOutput struct {
+	ID     string `json:"id"`
+	Memory string `json:"memory"`
+}
+
+// NewAddMemoryTool creates a tool that stores a fact as a memory of the
+// entity in options
+func NewAddMemoryTool(c client.Client, options client.MemoryOptions) *ai.ToolAction[AddMemoryInput, []MemoryOutput] {
+	return ai.NewTool(AddMemoryTool, "Store a fact about the user worth remembering in future conversations.",
+		func(ctx *ai.ToolContext, input AddMemoryInput) ([]MemoryOutput, error) {
+			if input.Content == "" {
+				return nil, client.NewValidationError("content", "content is required")
+			}
+			memories, err := c.Add(ctx, []client.Message{{Role: "user", Content: input.Content}}, options)
+			if err != nil {
+				return nil, err
+			}
+			return toOutputs(memories), nil
+		})
+}
+
+// NewSearchMemoryTool creates a tool that searches the memories of the
+// entity in options
+func NewSearchMemoryTool(c client.Client, options client.MemoryOptions) *ai.ToolAction[SearchMemoryInput, []MemoryOutput] {
+	return ai.NewTool(SearchMemoryTool, "Search stored memories about the user relevant to a query.",
+		func(ctx *ai.ToolContext, input SearchMemoryInput) ([]MemoryOutput, error) {
+			if input.Query == "" {
+				return nil, client.NewValidationError("query", "query is required")
+			}
+			search := client.SearchOptions{MemoryOptions: options}
+			if input.Limit > 0 {
+				search.Limit = &input.Limit
+			}
+			memories, err := c.Search(ctx, input.Query, search)
+			if err != nil {
+				return nil, err
+			}
+			return toOutputs(memories), nil
+		})
+}
+
+// scope returns options with the non-empty entity IDs replaced
+func scope(options client.MemoryOptions, userID, agentID, runID string) client.MemoryOptions {
+	if userID != "" {
+		options.UserID = &userID
+	}
+	if agentID != "" {
+		options.AgentID = &agentID
+	}
+	if runID != "" {
+		options.RunID = &runID
+	}
+	return options
+}
+
+// documentText joins the text parts of a document
+func documentText(doc *ai.Document) string {
+	if doc == nil {
+		return ""
+	}
+	var parts []string
+	for _, part := range doc.Content {
+		if part.IsText() {
+			parts = append(parts, part.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// toOutputs converts memories to tool output
+func toOutputs(memories []client.Memory) []MemoryOutput {
+	outputs := make([]MemoryOutput, 0, len(memories))
+	for _, memory := range memories {
+		outputs = append(outputs, MemoryOutput{ID: memory.ID, Memory: memory.Text()})
+	}
+	return outputs
+}
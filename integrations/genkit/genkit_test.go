@@ -0,0 +1,102 @@
+package genkit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/integrations/genkit"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+func newClient(t *testing.T) (*mem0test.Server, *client.MemoryClient) {
+	t.Helper()
+	srv := mem0test.NewServer()
+	t.Cleanup(srv.Close)
+	memoryClient, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return srv, memoryClient
+}
+
+func TestPluginActions(t *testing.T) {
+	_, memoryClient := newClient(t)
+	plugin := &genkit.Plugin{Client: memoryClient}
+
+	if plugin.Name() != genkit.Provider {
+		t.Errorf("Name() = %q, want %q", plugin.Name(), genkit.Provider)
+	}
+	var names []string
+	for _, action := range plugin.Init(context.Background()) {
+		names = append(names, action.Name())
+	}
+	want := []string{genkit.RetrieverName, genkit.AddMemoryTool, genkit.SearchMemoryTool}
+	if len(names) != len(want) {
+		t.Fatalf("Init() actions = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("action %d = %q, want %q", i, names[i], want[i])
+		}
+	}
+}
+
+func TestTools(t *testing.T) {
+	srv, memoryClient := newClient(t)
+	userID := "alex"
+	options := client.MemoryOptions{UserID: &userID}
+	ctx := context.Background()
+
+	added, err := genkit.NewAddMemoryTool(memoryClient, options).RunRaw(ctx, map[string]any{"content": "I am a vegetarian"})
+	if err != nil {
+		t.Fatalf("add_memory error = %v", err)
+	}
+	if outputs, ok := added.([]any); !ok || len(outputs) != 1 {
+		t.Errorf("add_memory output = %#v", added)
+	}
+	if memories := srv.Memories(); len(memories) != 1 || *memories[0].UserID != userID {
+		t.Errorf("stored memories = %+v", memories)
+	}
+
+	found, err := genkit.NewSearchMemoryTool(memoryClient, options).RunRaw(ctx, map[string]any{"query": "vegetarian"})
+	if err != nil {
+		t.Fatalf("search_memory error = %v", err)
+	}
+	if outputs, ok := found.([]any); !ok || len(outputs) != 1 || outputs[0].(map[string]any)["memory"] != "I am a vegetarian" {
+		t.Errorf("search_memory output = %#v", found)
+	}
+
+	if _, err := genkit.NewSearchMemoryTool(memoryClient, options).RunRaw(ctx, map[string]any{}); err == nil {
+		t.Error("search_memory without query expected error")
+	}
+}
+
+func TestRetriever(t *testing.T) {
+	_, memoryClient := newClient(t)
+	ctx := context.Background()
+	for _, user := range []string{"alex", "sam"} {
+		userID := user
+		_, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: user + " lives in Lisbon"}}, client.MemoryOptions{UserID: &userID})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	retriever := genkit.NewRetriever(memoryClient, client.MemoryOptions{})
+	resp, err := retriever.Retrieve(ctx, &ai.RetrieverRequest{
+		Query:   ai.DocumentFromText("lives in Lisbon", nil),
+		Options: genkit.RetrieverConfig{UserID: "sam"},
+	})
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if len(resp.Documents) != 1 {
+		t.Fatalf("Retrieve() returned %d documents, want 1", len(resp.Documents))
+	}
+	doc := resp.Documents[0]
+	if doc.Content[0].Text != "sam lives in Lisbon" || doc.Metadata["id"] == "" {
+		t.Errorf("document = %+v", doc)
+	}
+}
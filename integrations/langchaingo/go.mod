@@ -0,0 +1,14 @@
+module github.com/murilopl/go-mem0/integrations/langchaingo
+
+go 1.24.4
+
+require (
+	github.com/murilopl/go-mem0 v0.0.0-20261015163528-ee162f825aed
+	github.com/tmc/langchaingo v0.1.14
+)
+
+require (
+	github.com/dlclark/regexp2 v1.10.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pkoukk/tiktoken-go v0.1.6 // indirect
+)
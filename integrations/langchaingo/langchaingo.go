@@ -0,0 +1,182 @@
+// Package langchaingo adapts the Mem0 client to LangChainGo. Memory
+// implements schema.Memory so chains keep persistent user memory across
+// sessions, and Retriever implements schema.Retriever for retrieval chains.
+//
+// The package is a separate module so the core client does not depend on
+// LangChainGo.
+package langchaingo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/tmc/langchaingo/schema"
+)
+
+var (
+	_ schema.Memory    = (*Memory)(nil)
+	_ schema.Retriever = (*Retriever)(nil)
+)
+
+// DefaultMemoryKey is the chain variable Memory fills when MemoryKey is empty
+const DefaultMemoryKey = "history"
+
+// Memory is a schema.Memory backed by Mem0. Loading searches for memories
+// relevant to the chain input; saving adds the exchange as new memories.
+type Memory struct {
+	Client    client.Client
+	Options   client.MemoryOptions // Entity IDs (typically UserID) the memories belong to
+	MemoryKey string               // Chain variable to fill, DefaultMemoryKey when empty
+	InputKey  string               // Input variable holding the user message; the only input when empty
+	OutputKey string               // Output variable holding the reply; the only output when empty
+	Limit     int                  // Maximum memories to load, API default when zero
+}
+
+// NewMemory creates a Memory for the entity described by options
+func NewMemory(c client.Client, options client.MemoryOptions) *Memory {
+	return &Memory{Client: c, Options: options}
+}
+
+// GetMemoryKey implements schema.Memory
+func (m *Memory) GetMemoryKey(ctx context.Context) string {
+	if m.MemoryKey == "" {
+		return DefaultMemoryKey
+	}
+	return m.MemoryKey
+}
+
+// MemoryVariables implements schema.Memory
+func (m *Memory) MemoryVariables(ctx context.Context) []string {
+	return []string{m.GetMemoryKey(ctx)}
+}
+
+// LoadMemoryVariables implements schema.Memory. It searches with the chain
+// input, or lists all memories when there is no input.
+func (m *Memory) LoadMemoryVariables(ctx context.Context, inputs map[string]any) (map[string]any, error) {
+	query, err := lookupText(inputs, m.InputKey, "input")
+	if err != nil {
+		return nil, err
+	}
+
+	options := client.SearchOptions{MemoryOptions: m.Options}
+	if m.Limit > 0 {
+		limit := m.Limit
+		options.Limit = &limit
+	}
+
+	var memories []client.Memory
+	if query == "" {
+		memories, err = m.Client.GetAll(ctx, options)
+	} else {
+		memories, err = m.Client.Search(ctx, query, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]string, 0, len(memories))
+	for _, memory := range memories {
+		if text := memory.Text(); text != "" {
+			lines = append(lines, "- "+text)
+		}
+	}
+	return map[string]any{m.GetMemoryKey(ctx): strings.Join(lines, "\n")}, nil
+}
+
+// SaveContext implements schema.Memory by adding the user input and the
+// chain output as a conversation
+func (m *Memory) SaveContext(ctx context.Context, inputs map[string]any, outputs map[string]any) error {
+	input, err := lookupText(inputs, m.InputKey, "input")
+	if err != nil {
+		return err
+	}
+	output, err := lookupText(outputs, m.OutputKey, "output")
+	if err != nil {
+		return err
+	}
+
+	var messages []client.Message
+	if input != "" {
+		messages = append(messages, client.Message{Role: "user", Content: input})
+	}
+	if output != "" {
+		messages = append(messages, client.Message{Role: "assistant", Content: output})
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	_, err = m.Client.Add(ctx, messages, m.Options)
+	return err
+}
+
+// Clear implements schema.Memory by deleting all memories of the entity
+func (m *Memory) Clear(ctx context.Context) error {
+	_, err := m.Client.DeleteAll(ctx, m.Options)
+	return err
+}
+
+// Retriever is a schema.Retriever backed by Mem0 search
+type Retriever struct {
+	Client  client.Client
+	Options client.SearchOptions
+}
+
+// NewRetriever creates a Retriever that searches with options
+func NewRetriever(c client.Client, options client.SearchOptions) *Retriever {
+	return &Retriever{Client: c, Options: options}
+}
+
+// GetRelevantDocuments implements schema.Retriever. Each memory becomes a
+// document whose metadata holds the memory ID, categories and metadata.
+func (r *Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	memories, err := r.Client.Search(ctx, query, r.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]schema.Document, 0, len(memories))
+	for _, memory := range memories {
+		metadata := map[string]any{"id": memory.ID}
+		if len(memory.Categories) > 0 {
+			metadata["categories"] = memory.Categories
+		}
+		if memory.Metadata != nil {
+			metadata["metadata"] = memory.Metadata
+		}
+		document := schema.Document{PageContent: memory.Text(), Metadata: metadata}
+		if memory.Score != nil {
+			document.Score = float32(*memory.Score)
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// lookupText returns values[key] as a string. With an empty key it uses the
+// only value, or returns "" when values is empty.
+func lookupText(values map[string]any, key, kind string) (string, error) {
+	if key == "" {
+		switch len(values) {
+		case 0:
+			return "", nil
+		case 1:
+			for k := range values {
+				key = k
+			}
+		default:
+			return "", fmt.Errorf("multiple %s keys, set the %s key explicitly", kind, kind)
+		}
+	}
+
+	value, ok := values[key]
+	if !ok {
+		return "", fmt.Errorf("%s key %q not found", kind, key)
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%s key %q is %T, want string", kind, key, value)
+	}
+	return text, nil
+}
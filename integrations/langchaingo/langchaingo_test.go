@@ -0,0 +1,100 @@
+package langchaingo_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/integrations/langchaingo"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+func newClient(t *testing.T) (*mem0test.Server, *client.MemoryClient) {
+	t.Helper()
+	srv := mem0test.NewServer()
+	t.Cleanup(srv.Close)
+	memoryClient, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return srv, memoryClient
+}
+
+func TestMemory(t *testing.T) {
+	srv, memoryClient := newClient(t)
+	ctx := context.Background()
+	userID := "alex"
+	memory := langchaingo.NewMemory(memoryClient, client.MemoryOptions{UserID: &userID})
+
+	if got := memory.MemoryVariables(ctx); len(got) != 1 || got[0] != langchaingo.DefaultMemoryKey {
+		t.Errorf("MemoryVariables() = %v", got)
+	}
+
+	err := memory.SaveContext(ctx, map[string]any{"input": "I am a vegetarian"}, map[string]any{"text": "Noted!"})
+	if err != nil {
+		t.Fatalf("SaveContext() error = %v", err)
+	}
+	if got := len(srv.Memories()); got != 1 {
+		t.Fatalf("stored %d memories, want 1 (assistant messages are not memorized by the fake)", got)
+	}
+
+	vars, err := memory.LoadMemoryVariables(ctx, map[string]any{"input": "what do I eat? vegetarian"})
+	if err != nil {
+		t.Fatalf("LoadMemoryVariables() error = %v", err)
+	}
+	if vars["history"] != "- I am a vegetarian" {
+		t.Errorf("history = %q", vars["history"])
+	}
+
+	all, err := memory.LoadMemoryVariables(ctx, nil)
+	if err != nil {
+		t.Fatalf("LoadMemoryVariables(nil) error = %v", err)
+	}
+	if all["history"] != "- I am a vegetarian" {
+		t.Errorf("history without input = %q", all["history"])
+	}
+
+	if err := memory.Clear(ctx); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if got := len(srv.Memories()); got != 0 {
+		t.Errorf("stored %d memories after Clear, want 0", got)
+	}
+}
+
+func TestMemoryInputKeys(t *testing.T) {
+	_, memoryClient := newClient(t)
+	ctx := context.Background()
+	memory := langchaingo.NewMemory(memoryClient, client.MemoryOptions{})
+
+	_, err := memory.LoadMemoryVariables(ctx, map[string]any{"a": "x", "b": "y"})
+	if err == nil || !strings.Contains(err.Error(), "multiple input keys") {
+		t.Errorf("LoadMemoryVariables() error = %v, want multiple input keys", err)
+	}
+
+	memory.InputKey = "question"
+	_, err = memory.LoadMemoryVariables(ctx, map[string]any{"question": 42})
+	if err == nil || !strings.Contains(err.Error(), "want string") {
+		t.Errorf("LoadMemoryVariables() error = %v, want type error", err)
+	}
+}
+
+func TestRetriever(t *testing.T) {
+	_, memoryClient := newClient(t)
+	ctx := context.Background()
+	userID := "alex"
+	_, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "I live in Lisbon"}}, client.MemoryOptions{UserID: &userID})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	retriever := langchaingo.NewRetriever(memoryClient, client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID}})
+	docs, err := retriever.GetRelevantDocuments(ctx, "where do I live in Lisbon")
+	if err != nil {
+		t.Fatalf("GetRelevantDocuments() error = %v", err)
+	}
+	if len(docs) != 1 || docs[0].PageContent != "I live in Lisbon" || docs[0].Metadata["id"] == "" || docs[0].Score <= 0 {
+		t.Errorf("GetRelevantDocuments() = %+v", docs)
+	}
+}
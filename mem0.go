@@ -0,0 +1,28 @@
+// Package mem0 holds the backend-agnostic surface of the SDK.
+//
+// Applications that only need the core memory operations should depend on
+// MemoryStore rather than on a concrete client, so the backend can be chosen
+// at startup (for example from a configuration flag) without touching call
+// sites.
+package mem0
+
+import (
+	"context"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// MemoryStore is the set of memory operations shared by every backend
+type MemoryStore interface {
+	Add(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error)
+	Search(ctx context.Context, query string, options ...client.SearchOptions) ([]client.Memory, error)
+	Get(ctx context.Context, memoryID string) (*client.Memory, error)
+	GetAll(ctx context.Context, options ...client.SearchOptions) ([]client.Memory, error)
+	Update(ctx context.Context, memoryID, message string) ([]client.Memory, error)
+	Delete(ctx context.Context, memoryID string) (*client.MessageResponse, error)
+	History(ctx context.Context, memoryID string) ([]client.MemoryHistory, error)
+	DeleteAll(ctx context.Context, options ...client.MemoryOptions) (*client.DeleteAllResult, error)
+}
+
+// The hosted API client is a MemoryStore
+var _ MemoryStore = (*client.MemoryClient)(nil)
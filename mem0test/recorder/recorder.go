@@ -0,0 +1,257 @@
+// Package recorder provides an http.RoundTripper that records Mem0 API
+// interactions to a cassette file and replays them deterministically.
+//
+// Record once against the real API with a valid key, commit the cassette,
+// and CI replays it without credentials:
+//
+//	rec, err := recorder.New("testdata/search.json", recorder.ModeReplay, nil)
+//	...
+//	memoryClient, err := client.NewMemoryClient(client.ClientOptions{
+//		APIKey:     apiKey,
+//		HTTPClient: rec.Client(),
+//	})
+//	...
+//	err = rec.Stop() // writes the cassette in ModeRecord
+//
+// Request headers are never stored, and the API key taken from the
+// Authorization header is replaced with a placeholder anywhere it appears
+// in recorded URLs or bodies.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder talks to the network
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and never touches the network
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the real transport and records them
+	ModeRecord
+)
+
+// Placeholder replaces the API key in recorded interactions
+const Placeholder = "REDACTED"
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction
+// matches a request
+var ErrNoInteraction = errors.New("recorder: no matching interaction in cassette")
+
+// Request is the recorded part of an HTTP request
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"` // path and query, without scheme and host
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is the recorded part of an HTTP response
+type Response struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body"`
+}
+
+// Interaction is one recorded request/response pair
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// cassette is the on-disk format
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	secrets      map[string]bool
+}
+
+// New creates a Recorder for the cassette at path. In ModeReplay the cassette
+// must exist. transport is used in ModeRecord; nil means http.DefaultTransport.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: transport,
+		secrets:   make(map[string]bool),
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		var c cassette
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette: %w", err)
+		}
+		r.interactions = c.Interactions
+		r.used = make([]bool, len(c.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the recorder's mode
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an http.Client that uses the recorder as its transport
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+// record forwards the request and stores the scrubbed interaction
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	r.mu.Lock()
+	if token := apiKey(req); token != "" {
+		r.secrets[token] = true
+	}
+	r.interactions = append(r.interactions, Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    r.scrub(req.URL.RequestURI()),
+			Body:   r.scrub(string(body)),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header.Clone(),
+			Body:       r.scrub(string(respBody)),
+		},
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// replay serves the first unused interaction matching the request
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if token := apiKey(req); token != "" {
+		r.secrets[token] = true
+	}
+	uri := r.scrub(req.URL.RequestURI())
+	reqBody := r.scrub(string(body))
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request.Method != req.Method ||
+			interaction.Request.URL != uri || interaction.Request.Body != reqBody {
+			continue
+		}
+		r.used[i] = true
+
+		header := http.Header{}
+		for key, values := range interaction.Response.Headers {
+			header[key] = values
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, uri)
+}
+
+// Stop writes the cassette in ModeRecord. It is a no-op in ModeReplay.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// scrub replaces known secrets with the placeholder. Callers must hold r.mu.
+func (r *Recorder) scrub(s string) string {
+	for secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Placeholder)
+	}
+	return s
+}
+
+// apiKey extracts the credential from the Authorization header
+func apiKey(req *http.Request) string {
+	auth := req.Header.Get("Authorization")
+	if i := strings.IndexByte(auth, ' '); i >= 0 {
+		return strings.TrimSpace(auth[i+1:])
+	}
+	return auth
+}
@@ -0,0 +1,86 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/mem0test"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	userID := "vcr-user"
+	messages := []client.Message{{Role: "user", Content: "I enjoy hiking"}}
+	options := client.MemoryOptions{UserID: &userID}
+
+	// Record against the fake server
+	srv := mem0test.NewServer()
+	rec, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("New(ModeRecord) error = %v", err)
+	}
+	host := srv.URL
+	recordingClient, err := client.NewMemoryClient(client.ClientOptions{
+		APIKey:     srv.APIKey,
+		Host:       &host,
+		HTTPClient: rec.Client(),
+	})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	recorded, err := recordingClient.Add(context.Background(), messages, options)
+	if err != nil {
+		t.Fatalf("Add() while recording error = %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %v", err)
+	}
+	if strings.Contains(string(data), srv.APIKey) {
+		t.Error("cassette should not contain the API key")
+	}
+
+	// Replay with the server gone and a different key
+	replayer, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("New(ModeReplay) error = %v", err)
+	}
+	replayClient, err := client.NewMemoryClient(client.ClientOptions{
+		APIKey:     "another-key",
+		Host:       &host,
+		HTTPClient: replayer.Client(),
+	})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+	replayed, err := replayClient.Add(context.Background(), messages, options)
+	if err != nil {
+		t.Fatalf("Add() while replaying error = %v", err)
+	}
+	if len(replayed) != len(recorded) || replayed[0].ID != recorded[0].ID {
+		t.Errorf("replayed Add() = %+v, want %+v", replayed, recorded)
+	}
+
+	// Each interaction is served once
+	_, err = replayClient.Add(context.Background(), messages, options)
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("second Add() error = %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestReplayRequiresCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil)
+	if err == nil {
+		t.Error("New(ModeReplay) should fail when the cassette does not exist")
+	}
+}
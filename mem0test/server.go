@@ -0,0 +1,1070 @@
+// Package mem0test provides an in-process fake of the Mem0 API for tests.
+//
+// The fake keeps memories in memory and emulates the /v1 and /v2 endpoints
+// used by the client package closely enough to exercise application code
+// without an API key or network access:
+//
+//	srv := mem0test.NewServer()
+//	defer srv.Close()
+//
+//	memoryClient, err := srv.NewClient()
+//
+// Fact extraction is not emulated: every user message passed to Add becomes
+// one memory verbatim, and search scores memories by word overlap with the
+// query. Embeddings, when requested, are normalized letter counts.
+package mem0test
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// DefaultAPIKey is the API key accepted by a Server unless APIKey is changed
+const DefaultAPIKey = "mem0test-api-key"
+
+// entityTypes lists the entity kinds in the order the API reports them
+var entityTypes = []string{"user", "agent", "app", "run"}
+
+// record is the server-side state of one memory
+type record struct {
+	id         string
+	text       string
+	entities   map[string]string // entity type -> entity name
+	metadata   map[string]interface{}
+	categories []string
+	createdAt  time.Time
+	updatedAt  time.Time
+	expiration string // YYYY-MM-DD, empty when the memory never expires
+	immutable  bool
+}
+
+// expired reports whether the memory's expiration date has passed. A memory
+// stays live through its expiration date.
+func (r *record) expired(now time.Time) bool {
+	return r.expiration != "" && r.expiration < now.UTC().Format(time.DateOnly)
+}
+
+// expiryFilter selects live, expired or all memories
+type expiryFilter int
+
+const (
+	liveOnly expiryFilter = iota
+	includeExpired
+	expiredOnly
+)
+
+// parseExpiryFilter reads include_expired and expired_only, which arrive as
+// JSON booleans in bodies and as strings in query parameters
+func parseExpiryFilter(includeValue, onlyValue interface{}) expiryFilter {
+	truthy := func(value interface{}) bool {
+		return value == true || value == "true"
+	}
+	switch {
+	case truthy(onlyValue):
+		return expiredOnly
+	case truthy(includeValue):
+		return includeExpired
+	default:
+		return liveOnly
+	}
+}
+
+// match reports whether rec passes the filter at now
+func (f expiryFilter) match(rec *record, now time.Time) bool {
+	switch f {
+	case includeExpired:
+		return true
+	case expiredOnly:
+		return rec.expired(now)
+	default:
+		return !rec.expired(now)
+	}
+}
+
+// memory converts the record to its API representation
+func (r *record) memory() client.Memory {
+	text := r.text
+	hash := hashText(r.text)
+	createdAt := r.createdAt
+	updatedAt := r.updatedAt
+	mem := client.Memory{
+		ID:         r.id,
+		Memory:     &text,
+		Hash:       &hash,
+		Categories: r.categories,
+		CreatedAt:  &createdAt,
+		UpdatedAt:  &updatedAt,
+	}
+	if r.metadata != nil {
+		mem.Metadata = r.metadata
+	}
+	if r.expiration != "" {
+		expiration := r.expiration
+		mem.ExpirationDate = &expiration
+	}
+	if r.immutable {
+		immutable := true
+		mem.Immutable = &immutable
+	}
+	if v, ok := r.entities["user"]; ok {
+		mem.UserID = &v
+	}
+	if v, ok := r.entities["agent"]; ok {
+		mem.AgentID = &v
+	}
+	if v, ok := r.entities["app"]; ok {
+		mem.AppID = &v
+	}
+	if v, ok := r.entities["run"]; ok {
+		mem.RunID = &v
+	}
+	return mem
+}
+
+// Server is a fake Mem0 API backed by in-memory state
+type Server struct {
+	*httptest.Server
+
+	// APIKey is the only key the server accepts. It may be changed before
+	// the first request.
+	APIKey string
+
+	mu       sync.Mutex
+	seq      int
+	order    []string
+	memories map[string]*record
+	history  map[string][]client.MemoryHistory
+
+	categories   []map[string]string // custom categories, name -> description
+	instructions string              // custom instructions
+}
+
+// NewServer starts a fake Mem0 API server. Callers must Close it.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:   DefaultAPIKey,
+		memories: make(map[string]*record),
+		history:  make(map[string][]client.MemoryHistory),
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// NewClient returns a MemoryClient configured to talk to the server
+func (s *Server) NewClient() (*client.MemoryClient, error) {
+	host := s.URL
+	return client.NewMemoryClient(client.ClientOptions{
+		APIKey: s.APIKey,
+		Host:   &host,
+	})
+}
+
+// Memories returns a snapshot of all stored memories in insertion order
+func (s *Server) Memories() []client.Memory {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	memories := make([]client.Memory, 0, len(s.order))
+	for _, id := range s.order {
+		memories = append(memories, s.memories[id].memory())
+	}
+	return memories
+}
+
+// Reset removes all memories, history and project settings
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.categories = nil
+	s.instructions = ""
+	s.order = nil
+	s.memories = make(map[string]*record)
+	s.history = make(map[string][]client.MemoryHistory)
+}
+
+// routes builds the request multiplexer
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/ping/", s.handlePing)
+	mux.HandleFunc("POST /v1/memories/{$}", s.handleAdd)
+	mux.HandleFunc("GET /v1/memories/{$}", s.handleList)
+	mux.HandleFunc("POST /v2/memories/{$}", s.handleList)
+	mux.HandleFunc("DELETE /v1/memories/{$}", s.handleDeleteAll)
+	mux.HandleFunc("DELETE /v2/memories/{$}", s.handleDeleteAll)
+	mux.HandleFunc("POST /v1/memories/search/", s.handleSearch)
+	mux.HandleFunc("POST /v2/memories/search/", s.handleSearch)
+	mux.HandleFunc("GET /v1/memories/{id}/", s.handleGet)
+	mux.HandleFunc("PUT /v1/memories/{id}/", s.handleUpdate)
+	mux.HandleFunc("DELETE /v1/memories/{id}/", s.handleDelete)
+	mux.HandleFunc("GET /v1/memories/{id}/history/", s.handleHistory)
+	mux.HandleFunc("PUT /v1/batch/", s.handleBatchUpdate)
+	mux.HandleFunc("DELETE /v1/batch/", s.handleBatchDelete)
+	mux.HandleFunc("GET /v1/entities/", s.handleEntities)
+	mux.HandleFunc("DELETE /v1/entities/{type}/{id}/", s.handleDeleteEntityByID)
+	mux.HandleFunc("DELETE /v2/entities/{type}/{name}/", s.handleDeleteEntity)
+	mux.HandleFunc("GET /api/v1/orgs/organizations/{org}/projects/{project}/", s.handleGetProject)
+	mux.HandleFunc("PATCH /api/v1/orgs/organizations/{org}/projects/{project}/", s.handleUpdateProject)
+	return s.authenticate(mux)
+}
+
+// authenticate rejects requests that do not carry the server's API key
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token "+s.APIKey {
+			writeError(w, http.StatusUnauthorized, "Invalid API key")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handlePing(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":     "ok",
+		"org_id":     "mem0test-org",
+		"project_id": "mem0test-project",
+		"user_email": "mem0test@example.com",
+	})
+}
+
+func (s *Server) handleAdd(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Messages []client.Message       `json:"messages"`
+		UserID   string                 `json:"user_id"`
+		AgentID  string                 `json:"agent_id"`
+		AppID    string                 `json:"app_id"`
+		RunID    string                 `json:"run_id"`
+		Metadata map[string]interface{} `json:"metadata"`
+
+		ExpirationDate string `json:"expiration_date"`
+		Immutable      bool   `json:"immutable"`
+		OutputFormat   string `json:"output_format"`
+		Timestamp      *int64 `json:"timestamp"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	if body.ExpirationDate != "" {
+		if _, err := time.Parse(time.DateOnly, body.ExpirationDate); err != nil {
+			writeError(w, http.StatusBadRequest, "expiration_date must be YYYY-MM-DD")
+			return
+		}
+	}
+	if body.UserID == "" && body.AgentID == "" && body.AppID == "" && body.RunID == "" {
+		writeError(w, http.StatusBadRequest, "One of user_id, agent_id, app_id or run_id is required")
+		return
+	}
+
+	entities := map[string]string{}
+	for entityType, name := range map[string]string{"user": body.UserID, "agent": body.AgentID, "app": body.AppID, "run": body.RunID} {
+		if name != "" {
+			entities[entityType] = name
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := []map[string]interface{}{}
+	for _, message := range body.Messages {
+		text, ok := message.Content.(string)
+		if message.Role != "user" || !ok || strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		now := time.Now().UTC()
+		if body.Timestamp != nil {
+			now = time.Unix(*body.Timestamp, 0).UTC()
+		}
+		s.seq++
+		rec := &record{
+			id:         fmt.Sprintf("mem-%d", s.seq),
+			text:       text,
+			entities:   entities,
+			metadata:   body.Metadata,
+			createdAt:  now,
+			updatedAt:  now,
+			expiration: body.ExpirationDate,
+			immutable:  body.Immutable,
+		}
+		s.memories[rec.id] = rec
+		s.order = append(s.order, rec.id)
+		s.appendHistory(rec, body.Messages, nil, &text, client.EventAdd)
+
+		results = append(results, map[string]interface{}{
+			"id":    rec.id,
+			"data":  map[string]string{"memory": rec.text},
+			"event": client.EventAdd,
+		})
+	}
+
+	writeMemories(w, body.OutputFormat, results)
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	filters := map[string]interface{}{}
+	query := r.URL.Query()
+	expiry := parseExpiryFilter(query.Get("include_expired"), query.Get("expired_only"))
+	fields := splitFields(query.Get("fields"))
+	format := query.Get("output_format")
+	embeddings := query.Get("include_embeddings") == "true"
+	if r.Method == http.MethodPost {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+		if f, ok := body["filters"].(map[string]interface{}); ok {
+			filters = f
+		}
+		expiry = parseExpiryFilter(body["include_expired"], body["expired_only"])
+		format, _ = body["output_format"].(string)
+		embeddings, _ = body["include_embeddings"].(bool)
+		fields = nil
+		if list, ok := body["fields"].([]interface{}); ok {
+			for _, field := range list {
+				if name, ok := field.(string); ok {
+					fields = append(fields, name)
+				}
+			}
+		}
+	}
+	for _, key := range []string{"user_id", "agent_id", "app_id", "run_id"} {
+		if value := query.Get(key); value != "" {
+			filters[key] = value
+		}
+	}
+	if window := dateWindow(query.Get("start_date"), query.Get("end_date")); window != nil {
+		filters = map[string]interface{}{"AND": []interface{}{filters, window}}
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	memories := []client.Memory{}
+	for _, id := range s.order {
+		if rec := s.memories[id]; matchFilters(rec, filters) && expiry.match(rec, now) {
+			memories = append(memories, rec.memory())
+		}
+	}
+	s.mu.Unlock()
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+	if page > 0 && pageSize > 0 {
+		start := min((page-1)*pageSize, len(memories))
+		end := min(start+pageSize, len(memories))
+		memories = memories[start:end]
+	}
+
+	projected := make([]interface{}, len(memories))
+	for i, mem := range memories {
+		if embeddings {
+			mem.Embedding = embed(mem.Text())
+		}
+		projected[i] = project(mem, fields)
+	}
+	writeMemories(w, format, projected)
+}
+
+// writeMemories writes a list of memories as a plain list, or wrapped in a
+// {"results": [...]} envelope for output format v1.1
+func writeMemories(w http.ResponseWriter, format string, memories interface{}) {
+	if format == string(client.OutputFormatV1_1) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"results": memories})
+		return
+	}
+	writeJSON(w, http.StatusOK, memories)
+}
+
+// splitFields parses a comma-separated fields parameter
+func splitFields(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// project keeps only the named JSON fields of mem. No fields keeps them all.
+func project(mem client.Memory, fields []string) interface{} {
+	if len(fields) == 0 {
+		return mem
+	}
+	data, _ := json.Marshal(mem)
+	var all map[string]interface{}
+	json.Unmarshal(data, &all)
+
+	projected := map[string]interface{}{}
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			projected[field] = value
+		}
+	}
+	return projected
+}
+
+func (s *Server) handleDeleteAll(w http.ResponseWriter, r *http.Request) {
+	filters := map[string]interface{}{}
+	query := r.URL.Query()
+	if r.URL.Path == "/v2/memories/" {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+		if f, ok := body["filters"].(map[string]interface{}); ok {
+			filters = f
+		}
+	}
+	for _, key := range []string{"user_id", "agent_id", "app_id", "run_id"} {
+		if value := query.Get(key); value != "" {
+			filters[key] = value
+		}
+	}
+	if window := dateWindow(query.Get("start_date"), query.Get("end_date")); window != nil {
+		filters = map[string]interface{}{"AND": []interface{}{filters, window}}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleted := 0
+	for _, id := range append([]string(nil), s.order...) {
+		if rec := s.memories[id]; matchFilters(rec, filters) {
+			s.remove(rec)
+			deleted++
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Memories deleted successfully!",
+		"deleted": deleted,
+	})
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	query, _ := body["query"].(string)
+	filters, _ := body["filters"].(map[string]interface{})
+	if filters == nil {
+		filters = map[string]interface{}{}
+	}
+	for _, key := range []string{"user_id", "agent_id", "app_id", "run_id"} {
+		if value, ok := body[key]; ok {
+			filters[key] = value
+		}
+	}
+	startDate, _ := body["start_date"].(string)
+	endDate, _ := body["end_date"].(string)
+	if window := dateWindow(startDate, endDate); window != nil {
+		filters = map[string]interface{}{"AND": []interface{}{filters, window}}
+	}
+	expiry := parseExpiryFilter(body["include_expired"], body["expired_only"])
+	threshold, _ := body["threshold"].(float64)
+	categories, _ := body["categories"].([]interface{})
+	embeddings, _ := body["include_embeddings"].(bool)
+	limit := 0
+	for _, key := range []string{"limit", "top_k"} {
+		if value, ok := body[key].(float64); ok {
+			limit = int(value)
+		}
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	memories := []client.Memory{}
+	for _, id := range s.order {
+		rec := s.memories[id]
+		if !matchFilters(rec, filters) || !expiry.match(rec, now) || !matchCategories(rec, categories) {
+			continue
+		}
+		score := similarity(query, rec.text)
+		if score == 0 || score < threshold {
+			continue
+		}
+		mem := rec.memory()
+		mem.Score = &score
+		if embeddings {
+			mem.Embedding = embed(rec.text)
+		}
+		memories = append(memories, mem)
+	}
+	s.mu.Unlock()
+
+	sort.SliceStable(memories, func(i, j int) bool {
+		return *memories[i].Score > *memories[j].Score
+	})
+	if limit > 0 && len(memories) > limit {
+		memories = memories[:limit]
+	}
+
+	format, _ := body["output_format"].(string)
+	writeMemories(w, format, memories)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	rec, ok := s.memories[r.PathValue("id")]
+	var mem client.Memory
+	if ok {
+		mem = rec.memory()
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Memory not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, project(mem, splitFields(r.URL.Query().Get("fields"))))
+}
+
+func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Text     *string                `json:"text"`
+		Metadata map[string]interface{} `json:"metadata"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.memories[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Memory not found")
+		return
+	}
+	if rec.immutable {
+		writeError(w, http.StatusBadRequest, "Immutable memories cannot be updated")
+		return
+	}
+	if body.Metadata != nil {
+		rec.metadata = body.Metadata
+	}
+	if body.Text != nil {
+		s.update(rec, *body.Text)
+	} else {
+		rec.updatedAt = time.Now().UTC()
+	}
+
+	writeJSON(w, http.StatusOK, []client.Memory{rec.memory()})
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.memories[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Memory not found")
+		return
+	}
+	if rec.immutable {
+		writeError(w, http.StatusBadRequest, "Immutable memories cannot be deleted")
+		return
+	}
+	s.remove(rec)
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Memory deleted successfully!"})
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	history, ok := s.history[r.PathValue("id")]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Memory not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, history)
+}
+
+func (s *Server) handleBatchUpdate(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Memories []struct {
+			MemoryID string `json:"memory_id"`
+			Text     string `json:"text"`
+		} `json:"memories"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+
+	if len(body.Memories) > client.MaxBatchSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("At most %d memories can be processed in one batch", client.MaxBatchSize))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range body.Memories {
+		rec, ok := s.memories[item.MemoryID]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Memory %s not found", item.MemoryID))
+			return
+		}
+		if rec.immutable {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Immutable memory %s cannot be updated", item.MemoryID))
+			return
+		}
+	}
+	results := make([]map[string]string, len(body.Memories))
+	for i, item := range body.Memories {
+		s.update(s.memories[item.MemoryID], item.Text)
+		results[i] = map[string]string{"memory_id": item.MemoryID, "status": client.BatchItemSucceeded}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": fmt.Sprintf("Successfully updated %d memories", len(body.Memories)),
+		"results": results,
+	})
+}
+
+func (s *Server) handleBatchDelete(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Memories []struct {
+			MemoryID string `json:"memory_id"`
+		} `json:"memories"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+
+	if len(body.Memories) > client.MaxBatchSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("At most %d memories can be processed in one batch", client.MaxBatchSize))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range body.Memories {
+		rec, ok := s.memories[item.MemoryID]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Memory %s not found", item.MemoryID))
+			return
+		}
+		if rec.immutable {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Immutable memory %s cannot be deleted", item.MemoryID))
+			return
+		}
+	}
+	results := make([]map[string]string, len(body.Memories))
+	for i, item := range body.Memories {
+		s.remove(s.memories[item.MemoryID])
+		results[i] = map[string]string{"memory_id": item.MemoryID, "status": client.BatchItemSucceeded}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": fmt.Sprintf("Successfully deleted %d memories", len(body.Memories)),
+		"results": results,
+	})
+}
+
+func (s *Server) handleEntities(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	users := s.entities()
+	s.mu.Unlock()
+
+	query := r.URL.Query()
+	if entityType := query.Get("type"); entityType != "" {
+		filtered := []client.User{}
+		for _, user := range users {
+			if user.Type == entityType {
+				filtered = append(filtered, user)
+			}
+		}
+		users = filtered
+	}
+
+	count := len(users)
+	var next, previous interface{}
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+	if page > 0 && pageSize > 0 {
+		start := min((page-1)*pageSize, count)
+		end := min(start+pageSize, count)
+		users = users[start:end]
+		if end < count {
+			next = pageURL(r, page+1)
+		}
+		if page > 1 {
+			previous = pageURL(r, page-1)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":    count,
+		"results":  users,
+		"next":     next,
+		"previous": previous,
+	})
+}
+
+// pageURL returns the URL of r with its page parameter set to page
+func pageURL(r *http.Request, page int) string {
+	u := *r.URL
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func (s *Server) handleDeleteEntityByID(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entity := range s.entities() {
+		if entity.Type == r.PathValue("type") && entity.ID == r.PathValue("id") {
+			s.removeEntity(entity.Type, entity.Name)
+			writeJSON(w, http.StatusOK, map[string]string{"message": "Entity deleted successfully!"})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Entity not found")
+}
+
+func (s *Server) handleDeleteEntity(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.removeEntity(r.PathValue("type"), r.PathValue("name")) {
+		writeError(w, http.StatusNotFound, "Entity not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Entity deleted successfully!"})
+}
+
+// entities derives the entity list from stored memories. Callers must hold s.mu.
+func (s *Server) handleGetProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"custom_categories":   s.categories,
+		"custom_instructions": s.instructions,
+	})
+}
+
+func (s *Server) handleUpdateProject(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		CustomCategories   []map[string]string `json:"custom_categories"`
+		CustomInstructions *string             `json:"custom_instructions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if body.CustomCategories != nil {
+		s.categories = body.CustomCategories
+	}
+	if body.CustomInstructions != nil {
+		s.instructions = *body.CustomInstructions
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Updated project"})
+}
+
+func (s *Server) entities() []client.User {
+	byKey := map[string]*client.User{}
+	var keys []string
+	for _, id := range s.order {
+		rec := s.memories[id]
+		for _, entityType := range entityTypes {
+			name, ok := rec.entities[entityType]
+			if !ok {
+				continue
+			}
+			key := entityType + "/" + name
+			user, exists := byKey[key]
+			if !exists {
+				user = &client.User{
+					ID:        strconv.Itoa(len(keys) + 1),
+					Name:      name,
+					Type:      entityType,
+					Owner:     "mem0test",
+					CreatedAt: rec.createdAt,
+				}
+				byKey[key] = user
+				keys = append(keys, key)
+			}
+			user.TotalMemories++
+			if rec.updatedAt.After(user.UpdatedAt) {
+				user.UpdatedAt = rec.updatedAt
+			}
+		}
+	}
+
+	users := make([]client.User, 0, len(keys))
+	for _, key := range keys {
+		users = append(users, *byKey[key])
+	}
+	return users
+}
+
+// removeEntity deletes every memory owned by the entity. Callers must hold s.mu.
+func (s *Server) removeEntity(entityType, name string) bool {
+	removed := false
+	for _, id := range append([]string(nil), s.order...) {
+		if rec := s.memories[id]; rec.entities[entityType] == name {
+			s.remove(rec)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// update changes a memory's text and records the event. Callers must hold s.mu.
+func (s *Server) update(rec *record, text string) {
+	oldText := rec.text
+	rec.text = text
+	rec.updatedAt = time.Now().UTC()
+	s.appendHistory(rec, nil, &oldText, &text, client.EventUpdate)
+}
+
+// remove deletes a memory and records the event. Callers must hold s.mu.
+func (s *Server) remove(rec *record) {
+	oldText := rec.text
+	s.appendHistory(rec, nil, &oldText, nil, client.EventDelete)
+	delete(s.memories, rec.id)
+	for i, id := range s.order {
+		if id == rec.id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// appendHistory records a change to a memory. Callers must hold s.mu.
+func (s *Server) appendHistory(rec *record, input []client.Message, oldMemory, newMemory *string, event client.Event) {
+	now := time.Now().UTC()
+	if input == nil {
+		input = []client.Message{}
+	}
+	s.history[rec.id] = append(s.history[rec.id], client.MemoryHistory{
+		ID:         fmt.Sprintf("%s-history-%d", rec.id, len(s.history[rec.id])+1),
+		MemoryID:   rec.id,
+		Input:      input,
+		OldMemory:  oldMemory,
+		NewMemory:  newMemory,
+		UserID:     rec.entities["user"],
+		Categories: rec.categories,
+		Event:      event,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+}
+
+// matchFilters evaluates a v2-style filter expression against a memory.
+// Supported are AND/OR groups, equality on entity IDs, the "*" wildcard and
+// {"in": [...]} lists.
+// matchCategories reports whether the memory is in any of the categories,
+// or whether no categories were requested
+func matchCategories(rec *record, categories []interface{}) bool {
+	if len(categories) == 0 {
+		return true
+	}
+	for _, category := range categories {
+		for _, have := range rec.categories {
+			if category == have {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchFilters(rec *record, filters map[string]interface{}) bool {
+	for key, value := range filters {
+		switch key {
+		case "AND", "OR":
+			clauses, _ := value.([]interface{})
+			matched := key == "AND"
+			for _, clause := range clauses {
+				sub, _ := clause.(map[string]interface{})
+				if key == "AND" && !matchFilters(rec, sub) {
+					matched = false
+					break
+				}
+				if key == "OR" && matchFilters(rec, sub) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		case "user_id", "agent_id", "app_id", "run_id":
+			actual, ok := rec.entities[strings.TrimSuffix(key, "_id")]
+			if !matchValue(actual, ok, value) {
+				return false
+			}
+		case "created_at":
+			window, _ := value.(map[string]interface{})
+			if !matchDate(rec.createdAt, window) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// dateWindow returns the created_at filter for v1 start_date and end_date
+// parameters, or nil when both are empty
+func dateWindow(start, end string) map[string]interface{} {
+	if start == "" && end == "" {
+		return nil
+	}
+	window := map[string]interface{}{}
+	if start != "" {
+		window["gte"] = start
+	}
+	if end != "" {
+		window["lte"] = end
+	}
+	return map[string]interface{}{"created_at": window}
+}
+
+// matchDate checks a creation time against gte/gt/lte/lt bounds given as
+// RFC 3339 timestamps or dates. A date upper bound includes the whole day.
+func matchDate(createdAt time.Time, window map[string]interface{}) bool {
+	for op, value := range window {
+		text, _ := value.(string)
+		bound, err := time.Parse(time.RFC3339, text)
+		dateOnly := false
+		if err != nil {
+			if bound, err = time.Parse(time.DateOnly, text); err != nil {
+				return false
+			}
+			dateOnly = true
+		}
+		if dateOnly && (op == "lte" || op == "gt") {
+			bound = bound.Add(24*time.Hour - time.Nanosecond)
+		}
+		switch op {
+		case "gte":
+			if createdAt.Before(bound) {
+				return false
+			}
+		case "gt":
+			if !createdAt.After(bound) {
+				return false
+			}
+		case "lte":
+			if createdAt.After(bound) {
+				return false
+			}
+		case "lt":
+			if !createdAt.Before(bound) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchValue compares an entity ID against a filter value
+func matchValue(actual string, present bool, expected interface{}) bool {
+	switch v := expected.(type) {
+	case string:
+		if v == "*" {
+			return present
+		}
+		return present && actual == v
+	case map[string]interface{}:
+		if in, ok := v["in"].([]interface{}); ok {
+			for _, candidate := range in {
+				if s, ok := candidate.(string); ok && present && actual == s {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return false
+}
+
+// similarity scores text against a query by the fraction of query words it contains
+func similarity(query, text string) float64 {
+	queryWords := strings.Fields(strings.ToLower(query))
+	if len(queryWords) == 0 {
+		return 0
+	}
+	textWords := map[string]bool{}
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		textWords[strings.Trim(word, ".,!?;:'\"")] = true
+	}
+	hits := 0
+	for _, word := range queryWords {
+		if textWords[strings.Trim(word, ".,!?;:'\"")] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(queryWords))
+}
+
+// EmbeddingDimensions is the length of the embeddings the server returns
+const EmbeddingDimensions = 26
+
+// embed returns a stand-in embedding of text: its normalized letter counts,
+// so texts sharing words end up close together
+func embed(text string) []float64 {
+	vector := make([]float64, EmbeddingDimensions)
+	var norm float64
+	for _, r := range strings.ToLower(text) {
+		if r >= 'a' && r <= 'z' {
+			vector[r-'a']++
+		}
+	}
+	for _, v := range vector {
+		norm += v * v
+	}
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+		for i := range vector {
+			vector[i] /= norm
+		}
+	}
+	return vector
+}
+
+// hashText returns the memory hash reported by the API
+func hashText(text string) string {
+	sum := md5.Sum([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an API error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
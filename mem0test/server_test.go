@@ -0,0 +1,727 @@
+package mem0test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func newTestClient(t *testing.T) (*Server, *client.MemoryClient) {
+	t.Helper()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	memoryClient, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return srv, memoryClient
+}
+
+func TestServerMemoryLifecycle(t *testing.T) {
+	srv, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	added, err := memoryClient.Add(ctx, []client.Message{
+		{Role: "user", Content: "I love programming in Go"},
+		{Role: "assistant", Content: "Go is great"},
+		{Role: "user", Content: "I am a vegetarian"},
+	}, client.MemoryOptions{UserID: stringPtr("alex")})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if len(added) != 2 {
+		t.Fatalf("Add() returned %d memories, want 2", len(added))
+	}
+	if added[0].Event == nil || *added[0].Event != client.EventAdd {
+		t.Errorf("Add() event = %v, want ADD", added[0].Event)
+	}
+
+	memory, err := memoryClient.Get(ctx, added[0].ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if *memory.Memory != "I love programming in Go" || *memory.UserID != "alex" {
+		t.Errorf("Get() = %+v, want Go memory for alex", memory)
+	}
+
+	results, err := memoryClient.Search(ctx, "programming Go", client.SearchOptions{
+		MemoryOptions: client.MemoryOptions{UserID: stringPtr("alex")},
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ID != added[0].ID || results[0].Score == nil {
+		t.Errorf("Search() = %+v, want the Go memory with a score", results)
+	}
+
+	if _, err := memoryClient.Update(ctx, added[1].ID, "I am vegan"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	history, err := memoryClient.History(ctx, added[1].ID)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 || history[1].Event != client.EventUpdate || *history[1].NewMemory != "I am vegan" {
+		t.Errorf("History() = %+v, want ADD then UPDATE to 'I am vegan'", history)
+	}
+
+	if _, err := memoryClient.Delete(ctx, added[0].ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got := len(srv.Memories()); got != 1 {
+		t.Errorf("Memories() length = %d after delete, want 1", got)
+	}
+
+	_, err = memoryClient.Get(ctx, added[0].ID)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("Get() of deleted memory error = %v, want 404", err)
+	}
+}
+
+func TestServerFiltersAndEntities(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	for _, userID := range []string{"alex", "sam"} {
+		_, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "I like tea"}},
+			client.MemoryOptions{UserID: stringPtr(userID)})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	_, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Recommend tea shops"}},
+		client.MemoryOptions{AgentID: stringPtr("shopping-assistant")})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	all, err := memoryClient.GetAll(ctx, client.SearchOptions{
+		MemoryOptions: client.MemoryOptions{UserID: stringPtr("alex")},
+	})
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != 1 {
+		t.Errorf("GetAll(user_id=alex) returned %d memories, want 1", len(all))
+	}
+
+	apiVersion := client.APIVersionV2
+	results, err := memoryClient.Search(ctx, "tea", client.SearchOptions{
+		MemoryOptions: client.MemoryOptions{
+			APIVersion: &apiVersion,
+			Filters: map[string]interface{}{
+				"OR": []map[string]interface{}{
+					{"user_id": "alex"},
+					{"agent_id": "shopping-assistant"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Search(v2 OR filter) returned %d memories, want 2", len(results))
+	}
+
+	users, err := memoryClient.Users(ctx)
+	if err != nil {
+		t.Fatalf("Users() error = %v", err)
+	}
+	if users.Count != 3 {
+		t.Errorf("Users() count = %d, want 3", users.Count)
+	}
+
+	deleted, err := memoryClient.DeleteUsers(ctx, client.DeleteUsersParams{UserID: stringPtr("sam")})
+	if err != nil {
+		t.Fatalf("DeleteUsers() error = %v", err)
+	}
+	if deleted.Message != "Entity deleted successfully." {
+		t.Errorf("DeleteUsers() message = %q", deleted.Message)
+	}
+
+	users, err = memoryClient.Users(ctx)
+	if err != nil {
+		t.Fatalf("Users() error = %v", err)
+	}
+	if users.Count != 2 {
+		t.Errorf("Users() count after delete = %d, want 2", users.Count)
+	}
+}
+
+func TestServerRejectsInvalidAPIKey(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	host := srv.URL
+	memoryClient, err := client.NewMemoryClient(client.ClientOptions{APIKey: "wrong", Host: &host})
+	if err != nil {
+		t.Fatalf("NewMemoryClient() error = %v", err)
+	}
+
+	_, err = memoryClient.Get(context.Background(), "mem-1")
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 401 {
+		t.Errorf("Get() with wrong key error = %v, want 401", err)
+	}
+}
+
+func TestServerExpiration(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	expired := client.MemoryOptions{UserID: stringPtr("alex")}
+	expired.WithExpiration(time.Now().AddDate(0, 0, -2))
+	if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Visiting Paris this week"}}, expired); err != nil {
+		t.Fatalf("Add(expired) error = %v", err)
+	}
+	live := client.MemoryOptions{UserID: stringPtr("alex")}
+	live.WithTTL(24 * time.Hour)
+	if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Visiting Rome next week"}}, live); err != nil {
+		t.Fatalf("Add(live) error = %v", err)
+	}
+
+	scope := client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: stringPtr("alex")}}
+	tests := []struct {
+		name    string
+		options func(o client.SearchOptions) client.SearchOptions
+		want    int
+	}{
+		{name: "default hides expired", options: func(o client.SearchOptions) client.SearchOptions { return o }, want: 1},
+		{name: "include expired", options: func(o client.SearchOptions) client.SearchOptions {
+			include := true
+			o.IncludeExpired = &include
+			return o
+		}, want: 2},
+		{name: "expired only", options: func(o client.SearchOptions) client.SearchOptions {
+			only := true
+			o.ExpiredOnly = &only
+			return o
+		}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			all, err := memoryClient.GetAll(ctx, tt.options(scope))
+			if err != nil || len(all) != tt.want {
+				t.Errorf("GetAll() = %d memories, %v; want %d", len(all), err, tt.want)
+			}
+			found, err := memoryClient.Search(ctx, "visiting", tt.options(scope))
+			if err != nil || len(found) != tt.want {
+				t.Errorf("Search() = %d memories, %v; want %d", len(found), err, tt.want)
+			}
+		})
+	}
+
+	results, _ := memoryClient.GetAll(ctx, scope)
+	if len(results) != 1 || results[0].ExpirationDate == nil {
+		t.Errorf("GetAll() = %+v, want live memory with expiration_date", results)
+	}
+}
+
+func TestServerImmutableMemories(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	immutable := true
+	added, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Account 42 is under legal hold"}}, client.MemoryOptions{UserID: stringPtr("alex"), Immutable: &immutable})
+	if err != nil || len(added) != 1 {
+		t.Fatalf("Add() = %v, %v", added, err)
+	}
+	id := added[0].ID
+
+	if _, err := memoryClient.Update(ctx, id, "Account 42 is closed"); !errors.Is(err, client.ErrImmutableMemory) {
+		t.Errorf("Update() error = %v, want ErrImmutableMemory", err)
+	}
+	if _, err := memoryClient.Delete(ctx, id); !errors.Is(err, client.ErrImmutableMemory) {
+		t.Errorf("Delete() error = %v, want ErrImmutableMemory", err)
+	}
+
+	mem, err := memoryClient.Get(ctx, id)
+	if err != nil || mem.Text() != "Account 42 is under legal hold" || mem.Immutable == nil || !*mem.Immutable {
+		t.Errorf("Get() = %+v, %v; want unchanged immutable memory", mem, err)
+	}
+}
+
+func TestServerFieldsProjection(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	added, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Prefers window seats"}}, client.MemoryOptions{
+		UserID:   stringPtr("alex"),
+		Metadata: map[string]interface{}{"source": "chat"},
+	})
+	if err != nil || len(added) != 1 {
+		t.Fatalf("Add() = %v, %v", added, err)
+	}
+
+	mem, err := memoryClient.GetFields(ctx, added[0].ID, "id", "memory")
+	if err != nil || mem.Text() != "Prefers window seats" || mem.Metadata != nil || mem.UserID != nil {
+		t.Errorf("GetFields() = %+v, %v; want only id and memory", mem, err)
+	}
+
+	all, err := memoryClient.GetAll(ctx, client.SearchOptions{
+		MemoryOptions: client.MemoryOptions{UserID: stringPtr("alex")},
+		Fields:        []string{"id", "memory", "created_at"},
+	})
+	if err != nil || len(all) != 1 || all[0].CreatedAt == nil || all[0].Metadata != nil {
+		t.Errorf("GetAll() = %+v, %v; want id, memory and created_at only", all, err)
+	}
+}
+
+func TestServerOutputFormats(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Prefers aisle seats"}}, client.MemoryOptions{UserID: stringPtr("alex")}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	for _, format := range []client.OutputFormat{client.OutputFormatV1, client.OutputFormatV1_1} {
+		t.Run(string(format), func(t *testing.T) {
+			options := client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: stringPtr("alex"), OutputFormat: &format}}
+			if all, err := memoryClient.GetAll(ctx, options); err != nil || len(all) != 1 {
+				t.Errorf("GetAll() = %v, %v; want one memory", all, err)
+			}
+			if found, err := memoryClient.Search(ctx, "aisle", options); err != nil || len(found) != 1 {
+				t.Errorf("Search() = %v, %v; want one memory", found, err)
+			}
+		})
+	}
+}
+
+func TestServerUsersPagination(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	for _, opts := range []client.MemoryOptions{
+		{UserID: stringPtr("alex")},
+		{UserID: stringPtr("sam")},
+		{UserID: stringPtr("kim")},
+		{AgentID: stringPtr("planner")},
+		{RunID: stringPtr("run-1")},
+	} {
+		if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "I like tea"}}, opts); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	page, pageSize := 2, 2
+	users, err := memoryClient.Users(ctx, client.UsersOptions{Page: &page, PageSize: &pageSize})
+	if err != nil {
+		t.Fatalf("Users() error = %v", err)
+	}
+	if users.Count != 5 || len(users.Results) != 2 || users.Next == nil || users.Previous == nil {
+		t.Errorf("Users(page 2) = count %d, %d results, next %v, previous %v; want 5, 2 and both links",
+			users.Count, len(users.Results), users.Next, users.Previous)
+	}
+
+	var names []string
+	err = client.ForEachUser(ctx, memoryClient, client.UsersOptions{PageSize: &pageSize}, func(user client.User) error {
+		names = append(names, user.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachUser() error = %v", err)
+	}
+	if len(names) != 5 {
+		t.Errorf("ForEachUser() visited %v, want 5 entities", names)
+	}
+
+	entityType := client.EntityUser
+	names = nil
+	err = client.ForEachUser(ctx, memoryClient, client.UsersOptions{PageSize: &pageSize, Type: &entityType}, func(user client.User) error {
+		if user.Type != client.EntityUser {
+			t.Errorf("ForEachUser(type=user) visited %s %s", user.Type, user.Name)
+		}
+		names = append(names, user.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachUser(type=user) error = %v", err)
+	}
+	if len(names) != 3 {
+		t.Errorf("ForEachUser(type=user) visited %v, want 3 users", names)
+	}
+
+	stop := errors.New("stop")
+	err = client.ForEachUser(ctx, memoryClient, client.UsersOptions{}, func(user client.User) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("ForEachUser() error = %v, want the callback error", err)
+	}
+}
+
+func TestServerEntityDetail(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	for _, opts := range []client.MemoryOptions{
+		{UserID: stringPtr("alex")},
+		{UserID: stringPtr("alex")},
+		{UserID: stringPtr("sam")},
+		{AgentID: stringPtr("alex")},
+	} {
+		if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "I like tea"}}, opts); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	user, err := memoryClient.GetUser(ctx, "alex")
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if user.Type != client.EntityUser || user.Name != "alex" || user.TotalMemories != 2 {
+		t.Errorf("GetUser() = %+v, want user alex with 2 memories", user)
+	}
+
+	agent, err := memoryClient.GetEntity(ctx, client.EntityAgent, "alex")
+	if err != nil {
+		t.Fatalf("GetEntity() error = %v", err)
+	}
+	if agent.Type != client.EntityAgent || agent.TotalMemories != 1 {
+		t.Errorf("GetEntity(agent) = %+v, want agent alex with 1 memory", agent)
+	}
+
+	if _, err := memoryClient.GetUser(ctx, "kim"); !errors.Is(err, client.ErrEntityNotFound) {
+		t.Errorf("GetUser(kim) error = %v, want ErrEntityNotFound", err)
+	}
+	var validationErr *client.ValidationError
+	if _, err := memoryClient.GetEntity(ctx, "group", "alex"); !errors.As(err, &validationErr) {
+		t.Errorf("GetEntity(group) error = %v, want *ValidationError", err)
+	}
+
+	memories, err := memoryClient.MemoriesForEntity(ctx, client.EntityUser, "alex")
+	if err != nil {
+		t.Fatalf("MemoriesForEntity() error = %v", err)
+	}
+	if len(memories) != 2 {
+		t.Errorf("MemoriesForEntity(user alex) returned %d memories, want 2", len(memories))
+	}
+	for _, memory := range memories {
+		if memory.UserID == nil || *memory.UserID != "alex" {
+			t.Errorf("MemoriesForEntity(user alex) returned %+v", memory)
+		}
+	}
+}
+
+func TestServerRevertMemory(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	added, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Likes tea"}},
+		client.MemoryOptions{UserID: stringPtr("alex"), Metadata: map[string]interface{}{"source": "chat"}})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	id := added[0].ID
+	if _, err := memoryClient.Update(ctx, id, "Likes coffee"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	history, err := memoryClient.History(ctx, id)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	var updateID, addID string
+	for _, entry := range history {
+		switch entry.Event {
+		case client.EventUpdate:
+			updateID = entry.ID
+		case client.EventAdd:
+			addID = entry.ID
+		}
+	}
+
+	if _, err := memoryClient.RevertMemory(ctx, id, updateID); err != nil {
+		t.Fatalf("RevertMemory() error = %v", err)
+	}
+	memory, err := memoryClient.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if memory.Memory == nil || *memory.Memory != "Likes tea" {
+		t.Errorf("memory after revert = %v, want Likes tea", memory.Memory)
+	}
+	metadata, _ := memory.Metadata.(map[string]interface{})
+	if metadata["source"] != "chat" || metadata[client.MetadataRevertedFrom] != updateID || metadata[client.MetadataRevertedAt] == nil {
+		t.Errorf("metadata after revert = %v, want source kept and revert tags", metadata)
+	}
+
+	var validationErr *client.ValidationError
+	if _, err := memoryClient.RevertMemory(ctx, id, addID); !errors.As(err, &validationErr) {
+		t.Errorf("RevertMemory(add event) error = %v, want *ValidationError", err)
+	}
+	if _, err := memoryClient.RevertMemory(ctx, id, "missing"); !errors.As(err, &validationErr) {
+		t.Errorf("RevertMemory(missing) error = %v, want *ValidationError", err)
+	}
+}
+
+func TestServerCustomCategories(t *testing.T) {
+	srv, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	categories, err := memoryClient.GetCategories(ctx)
+	if err != nil || len(categories) != 0 {
+		t.Fatalf("GetCategories() = %v, %v; want none", categories, err)
+	}
+
+	want := []client.Category{
+		{Name: "billing", Description: "Invoices, plans and payment issues"},
+		{Name: "account", Description: "Login and profile settings"},
+	}
+	if err := memoryClient.SetCustomCategories(ctx, want); err != nil {
+		t.Fatalf("SetCustomCategories() error = %v", err)
+	}
+	categories, err = memoryClient.GetCategories(ctx)
+	if err != nil {
+		t.Fatalf("GetCategories() error = %v", err)
+	}
+	if len(categories) != 2 || categories[0] != want[1] || categories[1] != want[0] {
+		t.Errorf("GetCategories() = %v, want %v sorted by name", categories, want)
+	}
+
+	var validationErr *client.ValidationError
+	invalid := [][]client.Category{
+		{{Name: "billing"}},
+		{{Name: "bad name", Description: "Spaces"}},
+		{{Name: "billing", Description: "One"}, {Name: "billing", Description: "Two"}},
+	}
+	for _, categories := range invalid {
+		if err := memoryClient.SetCustomCategories(ctx, categories); !errors.As(err, &validationErr) {
+			t.Errorf("SetCustomCategories(%v) error = %v, want *ValidationError", categories, err)
+		}
+	}
+
+	for _, text := range []string{"Refund for the March invoice", "Reset my invoice email"} {
+		if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: text}}, client.MemoryOptions{UserID: stringPtr("alex")}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	srv.mu.Lock()
+	srv.memories["mem-1"].categories = []string{"billing"}
+	srv.memories["mem-2"].categories = []string{"account"}
+	srv.mu.Unlock()
+
+	options := client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: stringPtr("alex")}}
+	results, err := memoryClient.SearchCategories(ctx, "invoice", []string{"billing"}, options)
+	if err != nil {
+		t.Fatalf("SearchCategories() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "mem-1" {
+		t.Errorf("SearchCategories(billing) = %v, want mem-1", results)
+	}
+	if _, err := memoryClient.SearchCategories(ctx, "invoice", []string{"food"}, options); !errors.As(err, &validationErr) {
+		t.Errorf("SearchCategories(food) error = %v, want *ValidationError", err)
+	}
+}
+
+func TestServerUpdateProjectInstructions(t *testing.T) {
+	srv, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	instructions, err := client.InstructionsPreferences.Merge(client.InstructionsNoSecrets).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if _, err := memoryClient.UpdateProject(ctx, client.PromptUpdatePayload{CustomInstructions: &instructions}); err != nil {
+		t.Fatalf("UpdateProject() error = %v", err)
+	}
+	srv.mu.Lock()
+	got := srv.instructions
+	srv.mu.Unlock()
+	if got != instructions {
+		t.Errorf("project instructions = %q, want %q", got, instructions)
+	}
+
+	long := strings.Repeat("x", client.MaxCustomInstructionsLength+1)
+	var validationErr *client.ValidationError
+	if _, err := memoryClient.UpdateProject(ctx, client.PromptUpdatePayload{CustomInstructions: &long}); !errors.As(err, &validationErr) {
+		t.Errorf("UpdateProject(too long) error = %v, want *ValidationError", err)
+	}
+}
+
+func TestServerBackdatedAdd(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	then := time.Date(2023, 11, 5, 9, 30, 0, 0, time.UTC)
+	options := client.MemoryOptions{UserID: stringPtr("alex")}
+	options.WithTimestamp(then)
+	added, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Moved to Lisbon"}}, options)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	memory, err := memoryClient.Get(ctx, added[0].ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if memory.CreatedAt == nil || !memory.CreatedAt.Equal(then) {
+		t.Errorf("CreatedAt = %v, want %v", memory.CreatedAt, then)
+	}
+}
+
+func TestServerDateWindow(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	for i, text := range []string{"Tea in January", "Tea in March", "Tea in May"} {
+		options := client.MemoryOptions{UserID: stringPtr("alex")}
+		options.WithTimestamp(time.Date(2024, time.Month(1+2*i), 10, 12, 0, 0, 0, time.UTC))
+		if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: text}}, options); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	for _, version := range []client.APIVersion{client.APIVersionV1, client.APIVersionV2} {
+		options := client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: stringPtr("alex")}}
+		options.WithAPIVersion(version)
+		options.StartDate = stringPtr("2024-02-01")
+		options.EndDate = stringPtr("2024-03-10")
+
+		all, err := memoryClient.GetAll(ctx, options)
+		if err != nil {
+			t.Fatalf("GetAll(%s) error = %v", version, err)
+		}
+		if len(all) != 1 || all[0].Text() != "Tea in March" {
+			t.Errorf("GetAll(%s) = %v, want only the March memory", version, all)
+		}
+
+		results, err := memoryClient.Search(ctx, "tea", options)
+		if err != nil {
+			t.Fatalf("Search(%s) error = %v", version, err)
+		}
+		if len(results) != 1 || results[0].Text() != "Tea in March" {
+			t.Errorf("Search(%s) = %v, want only the March memory", version, results)
+		}
+	}
+}
+
+func TestServerDeleteAll(t *testing.T) {
+	srv, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	for _, user := range []string{"alex", "alex", "sam"} {
+		if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Likes tea"}}, client.MemoryOptions{UserID: stringPtr(user)}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	var validationErr *client.ValidationError
+	if _, err := memoryClient.DeleteAll(ctx); !errors.As(err, &validationErr) || validationErr.Field != "confirm_delete_all" {
+		t.Fatalf("DeleteAll() error = %v, want confirm_delete_all *ValidationError", err)
+	}
+
+	result, err := memoryClient.DeleteAll(ctx, client.MemoryOptions{UserID: stringPtr("alex")})
+	if err != nil {
+		t.Fatalf("DeleteAll(alex) error = %v", err)
+	}
+	if result.Deleted != 2 || len(srv.Memories()) != 1 {
+		t.Errorf("DeleteAll(alex) deleted %d, %d left; want 2 and 1", result.Deleted, len(srv.Memories()))
+	}
+
+	v2 := client.MemoryOptions{Filters: map[string]interface{}{"user_id": "sam"}}
+	v2.WithAPIVersion(client.APIVersionV2)
+	if result, err = memoryClient.DeleteAll(ctx, v2); err != nil || result.Deleted != 1 {
+		t.Fatalf("DeleteAll(v2 filters) = %+v, %v; want 1 deleted", result, err)
+	}
+
+	if _, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Likes tea"}}, client.MemoryOptions{UserID: stringPtr("kim")}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if result, err = memoryClient.DeleteAll(ctx, client.MemoryOptions{ConfirmDeleteAll: true}); err != nil || result.Deleted != 1 {
+		t.Errorf("DeleteAll(confirmed) = %+v, %v; want 1 deleted", result, err)
+	}
+}
+
+func TestServerUpdateMemory(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	added, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Likes tea"}}, client.MemoryOptions{UserID: stringPtr("alex")})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	id := added[0].ID
+
+	metadata := map[string]interface{}{"source": "import"}
+	if _, err := memoryClient.UpdateMemory(ctx, id, client.UpdateRequest{Metadata: metadata}); err != nil {
+		t.Fatalf("UpdateMemory(metadata) error = %v", err)
+	}
+	memory, err := memoryClient.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if memory.Text() != "Likes tea" || memory.Metadata.(map[string]interface{})["source"] != "import" {
+		t.Errorf("after metadata update = %q, %v; want text kept and metadata set", memory.Text(), memory.Metadata)
+	}
+
+	if _, err := memoryClient.UpdateMemory(ctx, id, client.UpdateRequest{Text: stringPtr("Likes green tea")}); err != nil {
+		t.Fatalf("UpdateMemory(text) error = %v", err)
+	}
+	if memory, _ = memoryClient.Get(ctx, id); memory.Text() != "Likes green tea" || memory.Metadata.(map[string]interface{})["source"] != "import" {
+		t.Errorf("after text update = %q, %v; want new text and metadata kept", memory.Text(), memory.Metadata)
+	}
+
+	var validationErr *client.ValidationError
+	for _, request := range []client.UpdateRequest{{}, {Text: stringPtr(" ")}} {
+		if _, err := memoryClient.UpdateMemory(ctx, id, request); !errors.As(err, &validationErr) {
+			t.Errorf("UpdateMemory(%+v) error = %v, want *ValidationError", request, err)
+		}
+	}
+}
+
+func TestServerEmbeddings(t *testing.T) {
+	_, memoryClient := newTestClient(t)
+	ctx := context.Background()
+
+	_, err := memoryClient.Add(ctx, []client.Message{{Role: "user", Content: "Drinks green tea"}}, client.MemoryOptions{UserID: stringPtr("alex")})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	for _, version := range []client.APIVersion{client.APIVersionV1, client.APIVersionV2} {
+		options := client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: stringPtr("alex")}}
+		options.WithAPIVersion(version)
+
+		all, err := memoryClient.GetAll(ctx, options)
+		if err != nil {
+			t.Fatalf("GetAll(%s) error = %v", version, err)
+		}
+		if len(all) != 1 || all[0].Embedding != nil {
+			t.Errorf("GetAll(%s) returned an embedding that was not requested", version)
+		}
+
+		options.IncludeEmbeddings = boolPtr(true)
+		all, err = memoryClient.GetAll(ctx, options)
+		if err != nil {
+			t.Fatalf("GetAll(%s) error = %v", version, err)
+		}
+		if len(all) != 1 || len(all[0].Embedding) != EmbeddingDimensions {
+			t.Errorf("GetAll(%s) = %v, want a %d-dimension embedding", version, all, EmbeddingDimensions)
+		}
+
+		results, err := memoryClient.Search(ctx, "tea", options)
+		if err != nil {
+			t.Fatalf("Search(%s) error = %v", version, err)
+		}
+		if len(results) != 1 || len(results[0].Embedding) != EmbeddingDimensions {
+			t.Errorf("Search(%s) = %v, want a %d-dimension embedding", version, results, EmbeddingDimensions)
+		}
+	}
+}
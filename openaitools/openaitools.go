@@ -0,0 +1,184 @@
+// Package openaitools exposes memory operations as OpenAI function-calling
+// tools. Tools returns the definitions to send with a chat completion request
+// and Executor runs the tool calls the model makes against a client.Client.
+//
+// The types mirror the OpenAI wire format, so they can be marshalled into a
+// request or converted to the types of any OpenAI SDK.
+package openaitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// Tool names
+const (
+	AddMemoryTool    = "add_memory"
+	SearchMemoryTool = "search_memory"
+)
+
+// Tool is an OpenAI tool definition
+type Tool struct {
+	Type     string             `json:"type"`
+	Function FunctionDefinition `json:"function"`
+}
+
+// FunctionDefinition describes a function the model may call
+type FunctionDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// ToolCall is a tool call from an assistant message
+type ToolCall struct {
+	ID       string       `json:"id"`
+	Type     string       `json:"type"`
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall holds the function name and JSON-encoded arguments of a call
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// ToolMessage is the message that returns a tool call's result to the model
+type ToolMessage struct {
+	Role       string `json:"role"`
+	ToolCallID string `json:"tool_call_id"`
+	Content    string `json:"content"`
+}
+
+// Tools returns the definitions of add_memory and search_memory
+func Tools() []Tool {
+	return []Tool{
+		{
+			Type: "function",
+			Function: FunctionDefinition{
+				Name:        AddMemoryTool,
+				Description: "Store a fact about the user worth remembering in future conversations.",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"content": {"type": "string", "description": "The fact to remember, in a short sentence."}
+					},
+					"required": ["content"],
+					"additionalProperties": false
+				}`),
+			},
+		},
+		{
+			Type: "function",
+			Function: FunctionDefinition{
+				Name:        SearchMemoryTool,
+				Description: "Search stored memories about the user relevant to a query.",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"query": {"type": "string", "description": "What to look for."},
+						"limit": {"type": "integer", "minimum": 1, "description": "Maximum number of memories to return."}
+					},
+					"required": ["query"],
+					"additionalProperties": false
+				}`),
+			},
+		},
+	}
+}
+
+// addMemoryArgs are the arguments of add_memory
+type addMemoryArgs struct {
+	Content string `json:"content"`
+}
+
+// searchMemoryArgs are the arguments of search_memory
+type searchMemoryArgs struct {
+	Query string `json:"query"`
+	Limit *int   `json:"limit"`
+}
+
+// memoryResult is the JSON returned to the model for a memory
+type memoryResult struct {
+	ID     string   `json:"id"`
+	Memory string   `json:"memory"`
+	Score  *float64 `json:"score,omitempty"`
+}
+
+// Executor runs tool calls against a client. Entity IDs come from Options,
+// never from the model, so a conversation can only reach its own memories.
+type Executor struct {
+	Client  client.Client
+	Options client.MemoryOptions
+}
+
+// NewExecutor creates an Executor scoped by options (typically a UserID)
+func NewExecutor(c client.Client, options client.MemoryOptions) *Executor {
+	return &Executor{Client: c, Options: options}
+}
+
+// Execute runs the named tool with JSON-encoded arguments and returns the
+// JSON-encoded result
+func (e *Executor) Execute(ctx context.Context, name, arguments string) (string, error) {
+	var result interface{}
+	switch name {
+	case AddMemoryTool:
+		var args addMemoryArgs
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("invalid %s arguments: %w", name, err)
+		}
+		if args.Content == "" {
+			return "", client.NewValidationError("content", "content is required")
+		}
+		memories, err := e.Client.Add(ctx, []client.Message{{Role: "user", Content: args.Content}}, e.Options)
+		if err != nil {
+			return "", err
+		}
+		result = toResults(memories)
+	case SearchMemoryTool:
+		var args searchMemoryArgs
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("invalid %s arguments: %w", name, err)
+		}
+		if args.Query == "" {
+			return "", client.NewValidationError("query", "query is required")
+		}
+		memories, err := e.Client.Search(ctx, args.Query, client.SearchOptions{MemoryOptions: e.Options, Limit: args.Limit})
+		if err != nil {
+			return "", err
+		}
+		result = toResults(memories)
+	default:
+		return "", fmt.Errorf("unknown tool %q", name)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s result: %w", name, err)
+	}
+	return string(data), nil
+}
+
+// ExecuteToolCall runs call and wraps the outcome in a tool message. Errors
+// are reported to the model in the message content rather than returned, so
+// the conversation can continue.
+func (e *Executor) ExecuteToolCall(ctx context.Context, call ToolCall) ToolMessage {
+	content, err := e.Execute(ctx, call.Function.Name, call.Function.Arguments)
+	if err != nil {
+		data, _ := json.Marshal(map[string]string{"error": err.Error()})
+		content = string(data)
+	}
+	return ToolMessage{Role: "tool", ToolCallID: call.ID, Content: content}
+}
+
+// toResults converts memories to the compact form returned to the model
+func toResults(memories []client.Memory) []memoryResult {
+	results := make([]memoryResult, 0, len(memories))
+	for _, memory := range memories {
+		results = append(results, memoryResult{ID: memory.ID, Memory: memory.Text(), Score: memory.Score})
+	}
+	return results
+}
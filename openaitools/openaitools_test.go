@@ -0,0 +1,111 @@
+package openaitools_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/mem0test"
+	"github.com/murilopl/go-mem0/openaitools"
+)
+
+func TestToolsAreValidJSON(t *testing.T) {
+	data, err := json.Marshal(openaitools.Tools())
+	if err != nil {
+		t.Fatalf("Marshal(Tools()) error = %v", err)
+	}
+
+	var tools []struct {
+		Type     string `json:"type"`
+		Function struct {
+			Name       string `json:"name"`
+			Parameters struct {
+				Type     string   `json:"type"`
+				Required []string `json:"required"`
+			} `json:"parameters"`
+		} `json:"function"`
+	}
+	if err := json.Unmarshal(data, &tools); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(tools) != 2 {
+		t.Fatalf("len(Tools()) = %d, want 2", len(tools))
+	}
+	for _, tool := range tools {
+		if tool.Type != "function" || tool.Function.Parameters.Type != "object" || len(tool.Function.Parameters.Required) != 1 {
+			t.Errorf("tool %s = %+v", tool.Function.Name, tool)
+		}
+	}
+}
+
+func TestExecutor(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	memoryClient, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	userID := "alex"
+	executor := openaitools.NewExecutor(memoryClient, client.MemoryOptions{UserID: &userID})
+	ctx := context.Background()
+
+	msg := executor.ExecuteToolCall(ctx, openaitools.ToolCall{
+		ID:       "call-1",
+		Type:     "function",
+		Function: openaitools.FunctionCall{Name: openaitools.AddMemoryTool, Arguments: `{"content":"I am a vegetarian"}`},
+	})
+	if msg.Role != "tool" || msg.ToolCallID != "call-1" || !strings.Contains(msg.Content, "I am a vegetarian") {
+		t.Errorf("add_memory message = %+v", msg)
+	}
+	if memories := srv.Memories(); len(memories) != 1 || memories[0].UserID == nil || *memories[0].UserID != userID {
+		t.Errorf("stored memories = %+v, want one for %s", memories, userID)
+	}
+
+	out, err := executor.Execute(ctx, openaitools.SearchMemoryTool, `{"query":"vegetarian","limit":3}`)
+	if err != nil {
+		t.Fatalf("search_memory error = %v", err)
+	}
+	var results []struct {
+		ID     string `json:"id"`
+		Memory string `json:"memory"`
+	}
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("search_memory result %q is not JSON: %v", out, err)
+	}
+	if len(results) != 1 || results[0].Memory != "I am a vegetarian" {
+		t.Errorf("search_memory results = %+v", results)
+	}
+}
+
+func TestExecutorErrors(t *testing.T) {
+	executor := openaitools.NewExecutor(nil, client.MemoryOptions{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		tool      string
+		arguments string
+		wantErr   string
+	}{
+		{name: "unknown tool", tool: "delete_everything", arguments: `{}`, wantErr: "unknown tool"},
+		{name: "bad json", tool: openaitools.AddMemoryTool, arguments: `{`, wantErr: "invalid add_memory arguments"},
+		{name: "missing content", tool: openaitools.AddMemoryTool, arguments: `{}`, wantErr: "content is required"},
+		{name: "missing query", tool: openaitools.SearchMemoryTool, arguments: `{"limit":2}`, wantErr: "query is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executor.Execute(ctx, tt.tool, tt.arguments)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	msg := executor.ExecuteToolCall(ctx, openaitools.ToolCall{ID: "call-2", Function: openaitools.FunctionCall{Name: "nope"}})
+	if !strings.Contains(msg.Content, `"error"`) {
+		t.Errorf("ExecuteToolCall() content = %q, want error object", msg.Content)
+	}
+}
@@ -0,0 +1,239 @@
+// Package outbox queues Add, Update and Delete operations in a durable store
+// and sends them to Mem0 in the background, retrying until they succeed. It
+// lets agents keep writing memories while the API is unreachable.
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// DefaultInterval is how often Run retries pending operations
+const DefaultInterval = 10 * time.Second
+
+// Kind is the type of a queued operation
+type Kind string
+
+// Operation kinds
+const (
+	KindAdd    Kind = "add"
+	KindUpdate Kind = "update"
+	KindDelete Kind = "delete"
+)
+
+// Operation is a queued write
+type Operation struct {
+	ID         string                `json:"id"` // Sorts in enqueue order within one process
+	Kind       Kind                  `json:"kind"`
+	Messages   []client.Message      `json:"messages,omitempty"`  // Add
+	Options    *client.MemoryOptions `json:"options,omitempty"`   // Add
+	MemoryID   string                `json:"memory_id,omitempty"` // Update and Delete
+	Text       string                `json:"text,omitempty"`      // Update
+	Attempts   int                   `json:"attempts"`
+	LastError  string                `json:"last_error,omitempty"`
+	EnqueuedAt time.Time             `json:"enqueued_at"`
+}
+
+// Options configures an Outbox
+type Options struct {
+	Interval    time.Duration                 // Retry interval of Run, DefaultInterval when zero
+	MaxAttempts int                           // Drop an operation after this many failures, unlimited when zero
+	OnDrop      func(op Operation, err error) // Optional: called when an operation is dropped
+}
+
+// Outbox queues writes in a Store and flushes them to a client in order
+type Outbox struct {
+	client  client.Client
+	store   Store
+	options Options
+	notify  chan struct{}
+
+	flushMu sync.Mutex // Serializes flushes so operations are sent once, in order
+	idMu    sync.Mutex
+	lastID  int64
+}
+
+// New creates an Outbox sending the operations in store through c
+func New(c client.Client, store Store, options Options) *Outbox {
+	if options.Interval <= 0 {
+		options.Interval = DefaultInterval
+	}
+	return &Outbox{
+		client:  c,
+		store:   store,
+		options: options,
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// Add queues an Add and returns the operation ID
+func (o *Outbox) Add(messages []client.Message, options ...client.MemoryOptions) (string, error) {
+	if len(messages) == 0 {
+		return "", client.NewValidationError("messages", "at least one message is required")
+	}
+	op := Operation{Kind: KindAdd, Messages: messages}
+	if len(options) > 0 {
+		op.Options = &options[0]
+	}
+	return o.enqueue(op)
+}
+
+// Update queues an Update and returns the operation ID
+func (o *Outbox) Update(memoryID, text string) (string, error) {
+	if memoryID == "" {
+		return "", client.NewValidationError("memoryID", "memory ID is required")
+	}
+	return o.enqueue(Operation{Kind: KindUpdate, MemoryID: memoryID, Text: text})
+}
+
+// Delete queues a Delete and returns the operation ID
+func (o *Outbox) Delete(memoryID string) (string, error) {
+	if memoryID == "" {
+		return "", client.NewValidationError("memoryID", "memory ID is required")
+	}
+	return o.enqueue(Operation{Kind: KindDelete, MemoryID: memoryID})
+}
+
+// Pending returns the operations not yet sent, oldest first
+func (o *Outbox) Pending() ([]Operation, error) {
+	return o.store.List()
+}
+
+// Flush sends pending operations in order. It stops at the first operation
+// that fails with a retryable error, leaving it and the rest queued, and
+// returns that error. Operations rejected by the API are dropped.
+func (o *Outbox) Flush(ctx context.Context) error {
+	o.flushMu.Lock()
+	defer o.flushMu.Unlock()
+
+	ops, err := o.store.List()
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		sendErr := o.send(ctx, op)
+		if sendErr == nil {
+			if err := o.store.Remove(op.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		op.Attempts++
+		op.LastError = sendErr.Error()
+		if !retryable(sendErr) || (o.options.MaxAttempts > 0 && op.Attempts >= o.options.MaxAttempts) {
+			if err := o.store.Remove(op.ID); err != nil {
+				return err
+			}
+			if o.options.OnDrop != nil {
+				o.options.OnDrop(op, sendErr)
+			}
+			continue
+		}
+		if err := o.store.Put(op); err != nil {
+			return err
+		}
+		return fmt.Errorf("operation %s: %w", op.ID, sendErr)
+	}
+	return nil
+}
+
+// Run flushes the outbox right away, whenever an operation is queued and
+// every Interval, until ctx is cancelled. It returns ctx.Err().
+func (o *Outbox) Run(ctx context.Context) error {
+	ticker := time.NewTicker(o.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		// Failures stay queued and are retried on the next tick
+		_ = o.Flush(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-o.notify:
+		}
+	}
+}
+
+// send performs op against the client, keyed by the operation ID so a retry
+// after a timeout isn't applied twice
+func (o *Outbox) send(ctx context.Context, op Operation) error {
+	ctx = client.WithIdempotencyKey(ctx, op.ID)
+	switch op.Kind {
+	case KindAdd:
+		if op.Options != nil {
+			_, err := o.client.Add(ctx, op.Messages, *op.Options)
+			return err
+		}
+		_, err := o.client.Add(ctx, op.Messages)
+		return err
+	case KindUpdate:
+		_, err := o.client.Update(ctx, op.MemoryID, op.Text)
+		return err
+	case KindDelete:
+		_, err := o.client.Delete(ctx, op.MemoryID)
+		return err
+	default:
+		return client.NewValidationError("kind", fmt.Sprintf("unknown operation kind %q", op.Kind))
+	}
+}
+
+// enqueue stores op with a new ID and wakes Run
+func (o *Outbox) enqueue(op Operation) (string, error) {
+	op.ID = o.nextID()
+	op.EnqueuedAt = time.Now()
+	if err := o.store.Put(op); err != nil {
+		return "", err
+	}
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+	return op.ID, nil
+}
+
+// nextID returns an ID that sorts after every ID this Outbox issued before
+// it, followed by a random suffix. IDs start with the wall-clock time, so
+// they are not ordered against IDs issued by another process sharing the
+// store, nor against earlier runs if the clock has gone back.
+func (o *Outbox) nextID() string {
+	o.idMu.Lock()
+	now := time.Now().UnixNano()
+	if now <= o.lastID {
+		now = o.lastID + 1
+	}
+	o.lastID = now
+	o.idMu.Unlock()
+
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return fmt.Sprintf("%019d-%s", now, hex.EncodeToString(suffix))
+}
+
+// retryable reports whether a failed operation may succeed later. Client-side
+// validation errors and 4xx responses other than 408 and 429 are final.
+func retryable(err error) bool {
+	var validationErr *client.ValidationError
+	if errors.As(err, &validationErr) {
+		return false
+	}
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 {
+		return apiErr.StatusCode == http.StatusRequestTimeout || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
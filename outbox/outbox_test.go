@@ -0,0 +1,202 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/client/clienttest"
+	"github.com/murilopl/go-mem0/outbox"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func newStore(t *testing.T) *outbox.FileStore {
+	t.Helper()
+	store, err := outbox.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	return store
+}
+
+func TestQueueSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	store, err := outbox.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	box := outbox.New(&clienttest.MockClient{}, store, outbox.Options{})
+
+	messages := []client.Message{{Role: "user", Content: "I like tea"}}
+	if _, err := box.Add(messages, client.MemoryOptions{UserID: strPtr("alice")}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := box.Update("mem-1", "likes green tea"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err := box.Delete("mem-2"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	reopened, err := outbox.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	pending, err := outbox.New(&clienttest.MockClient{}, reopened, outbox.Options{}).Pending()
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("Pending() = %d operations, want 3", len(pending))
+	}
+	kinds := []outbox.Kind{pending[0].Kind, pending[1].Kind, pending[2].Kind}
+	if kinds[0] != outbox.KindAdd || kinds[1] != outbox.KindUpdate || kinds[2] != outbox.KindDelete {
+		t.Errorf("Pending() kinds = %v, want enqueue order", kinds)
+	}
+	if pending[0].Options == nil || *pending[0].Options.UserID != "alice" || pending[0].Messages[0].Content != "I like tea" {
+		t.Errorf("Pending()[0] = %+v", pending[0])
+	}
+}
+
+func TestEnqueueValidation(t *testing.T) {
+	box := outbox.New(&clienttest.MockClient{}, newStore(t), outbox.Options{})
+	if _, err := box.Add(nil); err == nil {
+		t.Error("Add(nil) expected error")
+	}
+	if _, err := box.Update("", "text"); err == nil {
+		t.Error("Update(\"\") expected error")
+	}
+	if _, err := box.Delete(""); err == nil {
+		t.Error("Delete(\"\") expected error")
+	}
+}
+
+func TestFlushSendsInOrder(t *testing.T) {
+	var addKey string
+	mock := &clienttest.MockClient{
+		AddFunc: func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+			addKey, _ = client.IdempotencyKey(ctx)
+			return nil, nil
+		},
+	}
+	box := outbox.New(mock, newStore(t), outbox.Options{})
+
+	addID, _ := box.Add([]client.Message{{Role: "user", Content: "hi"}}, client.MemoryOptions{UserID: strPtr("alice")})
+	box.Update("mem-1", "updated")
+	box.Delete("mem-1")
+
+	if err := box.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	var methods []string
+	for _, call := range mock.Calls() {
+		methods = append(methods, call.Method)
+	}
+	if got := strings.Join(methods, ","); got != "Add,Update,Delete" {
+		t.Errorf("calls = %s, want Add,Update,Delete", got)
+	}
+	if addKey != addID {
+		t.Errorf("Add idempotency key = %q, want operation ID %q", addKey, addID)
+	}
+	if pending, _ := box.Pending(); len(pending) != 0 {
+		t.Errorf("Pending() after flush = %d, want 0", len(pending))
+	}
+}
+
+func TestFlushStopsAtRetryableError(t *testing.T) {
+	online := false
+	mock := &clienttest.MockClient{
+		AddFunc: func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+			if !online {
+				return nil, errors.New("dial tcp: connection refused")
+			}
+			return nil, nil
+		},
+	}
+	box := outbox.New(mock, newStore(t), outbox.Options{})
+	box.Add([]client.Message{{Role: "user", Content: "first"}})
+	box.Delete("mem-1")
+
+	if err := box.Flush(context.Background()); err == nil {
+		t.Fatal("Flush() expected error while offline")
+	}
+	if len(mock.CallsTo("Delete")) != 0 {
+		t.Error("Delete sent before the failed Add")
+	}
+	pending, _ := box.Pending()
+	if len(pending) != 2 || pending[0].Attempts != 1 || pending[0].LastError == "" {
+		t.Fatalf("Pending() = %+v, want both operations with one failed attempt", pending)
+	}
+
+	online = true
+	if err := box.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if pending, _ := box.Pending(); len(pending) != 0 {
+		t.Errorf("Pending() after reconnect = %d, want 0", len(pending))
+	}
+}
+
+func TestFlushDropsRejectedOperations(t *testing.T) {
+	mock := &clienttest.MockClient{
+		UpdateFunc: func(ctx context.Context, memoryID, message string) ([]client.Memory, error) {
+			return nil, client.NewAPIError("not found", 404, "")
+		},
+		DeleteFunc: func(ctx context.Context, memoryID string) (*client.MessageResponse, error) {
+			return nil, client.NewAPIError("rate limited", 429, "")
+		},
+	}
+	var dropped []outbox.Operation
+	box := outbox.New(mock, newStore(t), outbox.Options{
+		MaxAttempts: 2,
+		OnDrop:      func(op outbox.Operation, err error) { dropped = append(dropped, op) },
+	})
+	box.Update("mem-missing", "text")
+	box.Delete("mem-1")
+
+	box.Flush(context.Background())
+	if len(dropped) != 1 || dropped[0].Kind != outbox.KindUpdate {
+		t.Fatalf("dropped = %+v, want the rejected update", dropped)
+	}
+	if pending, _ := box.Pending(); len(pending) != 1 {
+		t.Fatalf("Pending() = %d, want the rate limited delete", len(pending))
+	}
+
+	box.Flush(context.Background())
+	if len(dropped) != 2 || dropped[1].Attempts != 2 {
+		t.Errorf("dropped = %+v, want delete dropped after 2 attempts", dropped)
+	}
+}
+
+func TestRunFlushesOnEnqueue(t *testing.T) {
+	added := make(chan struct{}, 1)
+	mock := &clienttest.MockClient{
+		AddFunc: func(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+			added <- struct{}{}
+			return nil, nil
+		},
+	}
+	box := outbox.New(mock, newStore(t), outbox.Options{Interval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- box.Run(ctx) }()
+
+	box.Add([]client.Message{{Role: "user", Content: "hi"}})
+	select {
+	case <-added:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not flush the queued operation")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+}
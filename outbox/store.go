@@ -0,0 +1,118 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Store persists queued operations. Implementations must survive process
+// restarts to make the outbox durable; FileStore is the built-in one, and a
+// bbolt or SQLite table keyed by Operation.ID works the same way.
+type Store interface {
+	// Put inserts or replaces the operation with op.ID
+	Put(op Operation) error
+	// List returns every stored operation ordered by ID
+	List() ([]Operation, error)
+	// Remove deletes the operation with id; removing a missing one is not an error
+	Remove(id string) error
+}
+
+// FileStore is a Store that keeps one JSON file per operation in a
+// directory. Writes go through a temporary file and a rename, so a crash
+// never leaves a partially written operation behind.
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+const fileExt = ".json"
+
+// NewFileStore creates a FileStore in dir, creating the directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Put writes op to its file
+func (s *FileStore) Put(op Operation) error {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("failed to encode operation %s: %w", op.ID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write operation %s: %w", op.ID, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write operation %s: %w", op.ID, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write operation %s: %w", op.ID, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write operation %s: %w", op.ID, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(op.ID)); err != nil {
+		return fmt.Errorf("failed to write operation %s: %w", op.ID, err)
+	}
+	return nil
+}
+
+// List reads all operation files ordered by ID
+func (s *FileStore) List() ([]Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox directory: %w", err)
+	}
+
+	var ops []Operation
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, fileExt) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read operation %s: %w", name, err)
+		}
+		var op Operation
+		if err := json.Unmarshal(data, &op); err != nil {
+			return nil, fmt.Errorf("failed to decode operation %s: %w", name, err)
+		}
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].ID < ops[j].ID })
+	return ops, nil
+}
+
+// Remove deletes the operation file
+func (s *FileStore) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove operation %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+fileExt)
+}
@@ -0,0 +1,299 @@
+// Package proxy provides an http.Handler that exposes memory operations to
+// end-user clients such as browser and mobile apps. The handler holds the
+// org-wide API key server-side and scopes every operation to the user that
+// Authenticate returns, so one user can never read or change another user's
+// memories:
+//
+//	handler := proxy.NewHandler(memoryClient, func(r *http.Request) (string, error) {
+//		return sessionUserID(r) // your auth
+//	})
+//	mux.Handle("/mem0/", http.StripPrefix("/mem0", handler))
+//
+// Routes, relative to where the handler is mounted:
+//
+//	POST   /memories               add memories ({"messages": [...], "metadata": {...}})
+//	GET    /memories               list the user's memories
+//	DELETE /memories               delete all of the user's memories
+//	POST   /memories/search        search ({"query": "...", "limit": 5, "threshold": 0.5})
+//	GET    /memories/{id}          get a memory
+//	PUT    /memories/{id}          update a memory ({"text": "..."})
+//	DELETE /memories/{id}          delete a memory
+//	GET    /memories/{id}/history  get a memory's history
+//
+// Memories owned by another user are reported as not found. Request bodies
+// larger than MaxBodySize are rejected with 413 Request Entity Too Large.
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// MaxBodySize bounds the request bodies the handler reads
+const MaxBodySize = 1 << 20
+
+// ErrUnauthenticated may be returned by an Authenticator for requests
+// without valid credentials
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Authenticator returns the ID of the end user making r. Any error rejects
+// the request with 401 Unauthorized.
+type Authenticator func(r *http.Request) (userID string, err error)
+
+// Handler proxies memory operations for authenticated end users
+type Handler struct {
+	client       client.Client
+	authenticate Authenticator
+	mux          *http.ServeMux
+}
+
+// NewHandler creates a Handler that forwards to c on behalf of the users
+// authenticate identifies
+func NewHandler(c client.Client, authenticate Authenticator) *Handler {
+	h := &Handler{client: c, authenticate: authenticate, mux: http.NewServeMux()}
+	h.mux.HandleFunc("POST /memories", h.withUser(h.add))
+	h.mux.HandleFunc("GET /memories", h.withUser(h.list))
+	h.mux.HandleFunc("DELETE /memories", h.withUser(h.deleteAll))
+	h.mux.HandleFunc("POST /memories/search", h.withUser(h.search))
+	h.mux.HandleFunc("GET /memories/{id}", h.withUser(h.get))
+	h.mux.HandleFunc("PUT /memories/{id}", h.withUser(h.update))
+	h.mux.HandleFunc("DELETE /memories/{id}", h.withUser(h.delete))
+	h.mux.HandleFunc("GET /memories/{id}/history", h.withUser(h.history))
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// userHandler handles a request on behalf of userID
+type userHandler func(w http.ResponseWriter, r *http.Request, userID string)
+
+// withUser authenticates the request before calling next
+func (h *Handler) withUser(next userHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := h.authenticate(r)
+		if err != nil || userID == "" {
+			writeError(w, http.StatusUnauthorized, "unauthenticated")
+			return
+		}
+		next(w, r, userID)
+	}
+}
+
+// addRequest is the body of POST /memories
+type addRequest struct {
+	Messages []client.Message       `json:"messages"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (h *Handler) add(w http.ResponseWriter, r *http.Request, userID string) {
+	var body addRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "messages is required")
+		return
+	}
+
+	memories, err := h.client.Add(r.Context(), body.Messages, client.MemoryOptions{UserID: &userID, Metadata: body.Metadata})
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, memories)
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request, userID string) {
+	memories, err := h.client.GetAll(r.Context(), client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID}})
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, memories)
+}
+
+func (h *Handler) deleteAll(w http.ResponseWriter, r *http.Request, userID string) {
+	resp, err := h.client.DeleteAll(r.Context(), client.MemoryOptions{UserID: &userID})
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// searchRequest is the body of POST /memories/search
+type searchRequest struct {
+	Query     string   `json:"query"`
+	Limit     *int     `json:"limit,omitempty"`
+	Threshold *float64 `json:"threshold,omitempty"`
+}
+
+func (h *Handler) search(w http.ResponseWriter, r *http.Request, userID string) {
+	var body searchRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Query == "" {
+		writeError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	memories, err := h.client.Search(r.Context(), body.Query, client.SearchOptions{
+		MemoryOptions: client.MemoryOptions{UserID: &userID},
+		Limit:         body.Limit,
+		Threshold:     body.Threshold,
+	})
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, memories)
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request, userID string) {
+	memory, ok := h.owned(w, r, userID)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, memory)
+}
+
+// updateRequest is the body of PUT /memories/{id}
+type updateRequest struct {
+	Text string `json:"text"`
+}
+
+func (h *Handler) update(w http.ResponseWriter, r *http.Request, userID string) {
+	var body updateRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Text == "" {
+		writeError(w, http.StatusBadRequest, "text is required")
+		return
+	}
+	memory, ok := h.owned(w, r, userID)
+	if !ok {
+		return
+	}
+
+	memories, err := h.client.Update(r.Context(), memory.ID, body.Text)
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, memories)
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request, userID string) {
+	memory, ok := h.owned(w, r, userID)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.Delete(r.Context(), memory.ID)
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) history(w http.ResponseWriter, r *http.Request, userID string) {
+	memory, ok := h.owned(w, r, userID)
+	if !ok {
+		return
+	}
+
+	history, err := h.client.History(r.Context(), memory.ID)
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, history)
+}
+
+// owned fetches the memory named in the path and checks that it belongs to
+// userID. Memories of other users are reported as not found so their IDs
+// cannot be probed. The returned memory's ID is the one in the path, so
+// later calls act on the memory that was checked.
+func (h *Handler) owned(w http.ResponseWriter, r *http.Request, userID string) (*client.Memory, bool) {
+	id := r.PathValue("id")
+	// The ID is placed in upstream paths; an escaped slash or dot segment
+	// could address another endpoint
+	if id == "" || id == "." || id == ".." || url.PathEscape(id) != id {
+		writeError(w, http.StatusBadRequest, "invalid memory ID")
+		return nil, false
+	}
+	memory, err := h.client.Get(r.Context(), id)
+	if err != nil {
+		writeClientError(w, err)
+		return nil, false
+	}
+	if memory.ID != id || memory.UserID == nil || *memory.UserID != userID {
+		writeError(w, http.StatusNotFound, "memory not found")
+		return nil, false
+	}
+	return memory, true
+}
+
+// decodeBody decodes the JSON request body into v, writing 413 if it is
+// larger than MaxBodySize and 400 on other failures
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodySize)).Decode(v)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+		return false
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return false
+	}
+	return true
+}
+
+// writeClientError maps a client error to a response. Upstream details are
+// not forwarded, since they may mention other users.
+func writeClientError(w http.ResponseWriter, err error) {
+	var validationErr *client.ValidationError
+	if errors.As(err, &validationErr) {
+		writeError(w, http.StatusBadRequest, validationErr.Error())
+		return
+	}
+
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			writeError(w, http.StatusBadRequest, "bad request")
+		case http.StatusNotFound:
+			writeError(w, http.StatusNotFound, "memory not found")
+		case http.StatusTooManyRequests:
+			writeError(w, http.StatusTooManyRequests, "rate limited")
+		default:
+			writeError(w, http.StatusBadGateway, "upstream error")
+		}
+		return
+	}
+	writeError(w, http.StatusBadGateway, "upstream error")
+}
+
+// writeJSON writes body as a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
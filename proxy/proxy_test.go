@@ -0,0 +1,151 @@
+package proxy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/mem0test"
+	"github.com/murilopl/go-mem0/proxy"
+)
+
+// newProxy serves a proxy over a fake API. Requests are authenticated by the
+// X-User header.
+func newProxy(t *testing.T) (*mem0test.Server, *httptest.Server) {
+	t.Helper()
+	fake := mem0test.NewServer()
+	t.Cleanup(fake.Close)
+	memoryClient, err := fake.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	handler := proxy.NewHandler(memoryClient, func(r *http.Request) (string, error) {
+		if user := r.Header.Get("X-User"); user != "" {
+			return user, nil
+		}
+		return "", proxy.ErrUnauthenticated
+	})
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return fake, srv
+}
+
+// do sends a request as user and decodes the JSON response into out
+func do(t *testing.T, srv *httptest.Server, user, method, path string, body, out interface{}) int {
+	t.Helper()
+	var reader *bytes.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req, _ := http.NewRequest(method, srv.URL+path, reader)
+	if user != "" {
+		req.Header.Set("X-User", user)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s error = %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: invalid JSON response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestProxyScopesToUser(t *testing.T) {
+	fake, srv := newProxy(t)
+
+	var added []client.Memory
+	body := map[string]interface{}{
+		"messages": []client.Message{{Role: "user", Content: "I am a vegetarian"}},
+		"user_id":  "mallory",
+	}
+	if code := do(t, srv, "alex", "POST", "/memories", body, &added); code != http.StatusOK {
+		t.Fatalf("POST /memories status = %d", code)
+	}
+	if stored := fake.Memories(); len(stored) != 1 || *stored[0].UserID != "alex" {
+		t.Fatalf("stored = %+v, want one memory for alex", stored)
+	}
+	id := added[0].ID
+
+	var found []client.Memory
+	do(t, srv, "alex", "POST", "/memories/search", map[string]interface{}{"query": "vegetarian"}, &found)
+	if len(found) != 1 {
+		t.Errorf("alex search = %+v", found)
+	}
+	found = nil
+	do(t, srv, "sam", "POST", "/memories/search", map[string]interface{}{"query": "vegetarian"}, &found)
+	if len(found) != 0 {
+		t.Errorf("sam search = %+v, want none", found)
+	}
+
+	for _, tt := range []struct{ method, path string }{
+		{"GET", "/memories/" + id},
+		{"PUT", "/memories/" + id},
+		{"DELETE", "/memories/" + id},
+		{"GET", "/memories/" + id + "/history"},
+	} {
+		if code := do(t, srv, "sam", tt.method, tt.path, map[string]string{"text": "hacked"}, nil); code != http.StatusNotFound {
+			t.Errorf("sam %s %s status = %d, want 404", tt.method, tt.path, code)
+		}
+	}
+
+	var memory client.Memory
+	if code := do(t, srv, "alex", "GET", "/memories/"+id, nil, &memory); code != http.StatusOK || memory.Text() != "I am a vegetarian" {
+		t.Errorf("alex GET = %d %+v", code, memory)
+	}
+	if code := do(t, srv, "alex", "PUT", "/memories/"+id, map[string]string{"text": "I am vegan"}, nil); code != http.StatusOK {
+		t.Errorf("alex PUT status = %d", code)
+	}
+
+	do(t, srv, "sam", "DELETE", "/memories", nil, nil)
+	if len(fake.Memories()) != 1 {
+		t.Error("sam's delete all removed alex's memories")
+	}
+	do(t, srv, "alex", "DELETE", "/memories", nil, nil)
+	if len(fake.Memories()) != 0 {
+		t.Error("alex's delete all left memories behind")
+	}
+}
+
+func TestProxyRejects(t *testing.T) {
+	_, srv := newProxy(t)
+
+	tests := []struct {
+		name   string
+		user   string
+		method string
+		path   string
+		body   interface{}
+		want   int
+	}{
+		{"unauthenticated", "", "GET", "/memories", nil, http.StatusUnauthorized},
+		{"no messages", "alex", "POST", "/memories", map[string]interface{}{}, http.StatusBadRequest},
+		{"no query", "alex", "POST", "/memories/search", map[string]interface{}{}, http.StatusBadRequest},
+		{"bad threshold", "alex", "POST", "/memories/search", map[string]interface{}{"query": "q", "threshold": 3}, http.StatusBadRequest},
+		{"missing memory", "alex", "GET", "/memories/nope", nil, http.StatusNotFound},
+		{"escaped slash in ID", "alex", "GET", "/memories/x%2F..%2F..%2Fentities", nil, http.StatusBadRequest},
+		{"body too large", "alex", "POST", "/memories/search", map[string]interface{}{"query": strings.Repeat("q", proxy.MaxBodySize)}, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp map[string]string
+			if code := do(t, srv, tt.user, tt.method, tt.path, tt.body, &resp); code != tt.want {
+				t.Errorf("status = %d, want %d", code, tt.want)
+			}
+			if resp["error"] == "" {
+				t.Error("response has no error message")
+			}
+		})
+	}
+}
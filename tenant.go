@@ -0,0 +1,22 @@
+package mem0
+
+import (
+	"context"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// Tenant is the organization, project and entity a request acts for
+type Tenant = client.Tenant
+
+// WithTenant returns a context whose memory calls inherit tenant's
+// organization, project and entity IDs unless a call sets its own. Set it
+// once per request, e.g. in HTTP middleware, and pass the context down.
+func WithTenant(ctx context.Context, tenant Tenant) context.Context {
+	return client.WithTenant(ctx, tenant)
+}
+
+// TenantFrom returns the tenant set on ctx with WithTenant
+func TenantFrom(ctx context.Context) (Tenant, bool) {
+	return client.TenantFrom(ctx)
+}
@@ -0,0 +1,81 @@
+// Package tools provides maintenance utilities that operate on many memories
+// at once, such as backfilling metadata.
+package tools
+
+import (
+	"context"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// BackfillOptions configures BackfillMetadata
+type BackfillOptions struct {
+	PageSize    int                        // Optional: memories per GetAll page, DefaultPageSize when zero
+	Concurrency int                        // Optional: updates in flight, client.DefaultBatchConcurrency when zero
+	RateLimit   float64                    // Optional: updates started per second, unlimited when zero
+	Progress    func(updated, pending int) // Optional: called after each update, serialized
+}
+
+// BackfillReport is the outcome of BackfillMetadata
+type BackfillReport struct {
+	Scanned int                        // Memories matching the filters
+	Skipped int                        // Memories the transform returned no keys for
+	Updated int                        // Memories updated
+	Failed  int                        // Memories whose update failed
+	Results []client.UpdateBatchResult // One per attempted update, in scan order
+}
+
+// BackfillMetadata pages through the memories matching filters, calls
+// transform on each and merges the keys it returns into the memory's
+// metadata. Memories for which transform returns no keys are left alone.
+// All pages are read before the first update. The error joins the errors of
+// the failed updates.
+func BackfillMetadata(ctx context.Context, c client.Client, filters client.SearchOptions, transform func(client.Memory) map[string]any, options BackfillOptions) (*BackfillReport, error) {
+	memories, err := listAll(ctx, c, filters, options.PageSize)
+	if err != nil {
+		return &BackfillReport{}, err
+	}
+
+	report := &BackfillReport{Scanned: len(memories)}
+	var updates []client.MemoryUpdate
+	for _, memory := range memories {
+		patch := transform(memory)
+		if len(patch) == 0 {
+			report.Skipped++
+			continue
+		}
+		updates = append(updates, client.MemoryUpdate{
+			MemoryID: memory.ID,
+			Request:  client.UpdateRequest{Metadata: mergeMetadata(memory.Metadata, patch)},
+		})
+	}
+
+	batch := []client.BatchOption{client.WithConcurrency(options.Concurrency), client.WithRateLimit(options.RateLimit)}
+	if options.Progress != nil {
+		batch = append(batch, client.WithProgress(options.Progress))
+	}
+	results, err := client.UpdateBatch(ctx, c, updates, batch...)
+	report.Results = results
+	for _, result := range results {
+		if result.Err != nil {
+			report.Failed++
+		} else {
+			report.Updated++
+		}
+	}
+	return report, err
+}
+
+// mergeMetadata returns a copy of existing with the keys of patch set
+func mergeMetadata(existing interface{}, patch map[string]any) map[string]interface{} {
+	metadata := map[string]interface{}{}
+	if existing, ok := existing.(map[string]interface{}); ok {
+		for key, value := range existing {
+			metadata[key] = value
+		}
+	}
+	for key, value := range patch {
+		metadata[key] = value
+	}
+	return metadata
+}
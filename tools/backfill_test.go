@@ -0,0 +1,68 @@
+package tools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/mem0test"
+	"github.com/murilopl/go-mem0/tools"
+)
+
+func TestBackfillMetadata(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := context.Background()
+	userID := "alice"
+	texts := []string{"Likes tea", "Likes coffee", "Lives in Lisbon", "Likes jazz", "Plays chess"}
+	for _, text := range texts {
+		_, err := c.Add(ctx, []client.Message{{Role: "user", Content: text}}, client.MemoryOptions{
+			UserID:   &userID,
+			Metadata: map[string]interface{}{"source": "chat"},
+		})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	var calls, lastDone, lastTotal int
+	report, err := tools.BackfillMetadata(ctx, c, client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID}}, func(m client.Memory) map[string]any {
+		if m.Memory == nil || !strings.HasPrefix(*m.Memory, "Likes") {
+			return nil
+		}
+		return map[string]any{"topic": "preference"}
+	}, tools.BackfillOptions{
+		PageSize:    2,
+		Concurrency: 2,
+		Progress: func(done, total int) {
+			calls++
+			lastDone, lastTotal = done, total
+		},
+	})
+	if err != nil {
+		t.Fatalf("BackfillMetadata() error = %v", err)
+	}
+	if report.Scanned != 5 || report.Skipped != 2 || report.Updated != 3 || report.Failed != 0 {
+		t.Errorf("report = %+v, want 5 scanned, 2 skipped, 3 updated", report)
+	}
+	if calls != 3 || lastDone != 3 || lastTotal != 3 {
+		t.Errorf("progress called %d times, last (%d, %d), want 3 times ending at (3, 3)", calls, lastDone, lastTotal)
+	}
+
+	for _, m := range srv.Memories() {
+		metadata, _ := m.Metadata.(map[string]interface{})
+		if metadata["source"] != "chat" {
+			t.Errorf("%s: source = %v, want existing key kept", *m.Memory, metadata["source"])
+		}
+		want := strings.HasPrefix(*m.Memory, "Likes")
+		if got := metadata["topic"] == "preference"; got != want {
+			t.Errorf("%s: topic = %v, want set %v", *m.Memory, metadata["topic"], want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+const (
+	// DefaultCompactBatchSize is the number of memories passed to each
+	// Consolidate call
+	DefaultCompactBatchSize = 50
+	// DefaultCompactInterval is how often a Compactor runs
+	DefaultCompactInterval = 24 * time.Hour
+	// ConsolidatedFromKey is the metadata key under which a replacement
+	// memory lists the memories it consolidated
+	ConsolidatedFromKey = "consolidated_from"
+)
+
+// Consolidator condenses memories into fewer, higher-level memory texts,
+// typically by prompting an LLM
+type Consolidator interface {
+	Consolidate(ctx context.Context, memories []client.Memory) ([]string, error)
+}
+
+// ConsolidatorFunc adapts a function to the Consolidator interface
+type ConsolidatorFunc func(ctx context.Context, memories []client.Memory) ([]string, error)
+
+// Consolidate implements Consolidator
+func (f ConsolidatorFunc) Consolidate(ctx context.Context, memories []client.Memory) ([]string, error) {
+	return f(ctx, memories)
+}
+
+// CompactOptions configures Compact and Compactor
+type CompactOptions struct {
+	OlderThan    time.Duration // Required: memories created longer ago than this are compacted, e.g. 30 * 24 * time.Hour
+	Consolidator Consolidator  // Required
+	BatchSize    int           // Optional: memories per Consolidate call, DefaultCompactBatchSize when zero
+	PageSize     int           // Optional: memories per GetAll page, DefaultPageSize when zero
+	Interval     time.Duration // Optional: Compactor run interval, DefaultCompactInterval when zero
+	OnError      func(error)   // Optional: called with the errors of Compactor runs
+}
+
+// ConsolidatedMemory is the record a replacement keeps of one original
+// under ConsolidatedFromKey
+type ConsolidatedMemory struct {
+	ID        string     `json:"id"`
+	Memory    string     `json:"memory"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// CompactReport is the outcome of Compact
+type CompactReport struct {
+	Scanned  int             // Memories matching the filters
+	Selected int             // Memories older than OlderThan
+	Added    []client.Memory // Replacement memories written
+	Deleted  int             // Originals deleted
+}
+
+// Compact consolidates the memories matching filters that were created more
+// than OlderThan ago. Each batch of them is passed to the Consolidator, its
+// texts are added as new memories, without inference, in the scope of
+// filters, and the batch's originals are then deleted. Each replacement
+// lists the IDs, texts and creation times of the originals under
+// ConsolidatedFromKey.
+//
+// The API has no transactions, so a batch is made atomic by compensation:
+// if a replacement cannot be written, the replacements already written are
+// deleted and the originals are kept. Originals that fail to delete are
+// reported in the error and remain alongside their replacements.
+func Compact(ctx context.Context, c client.Client, filters client.SearchOptions, options CompactOptions) (*CompactReport, error) {
+	if err := options.validate(filters); err != nil {
+		return nil, err
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultCompactBatchSize
+	}
+
+	memories, err := listAll(ctx, c, filters, options.PageSize)
+	if err != nil {
+		return &CompactReport{}, err
+	}
+	report := &CompactReport{Scanned: len(memories)}
+	cutoff := time.Now().Add(-options.OlderThan)
+	var selected []client.Memory
+	for _, memory := range memories {
+		if memory.CreatedAt != nil && memory.CreatedAt.Before(cutoff) {
+			selected = append(selected, memory)
+		}
+	}
+	report.Selected = len(selected)
+
+	for start := 0; start < len(selected); start += batchSize {
+		batch := selected[start:min(start+batchSize, len(selected))]
+		if len(batch) < 2 {
+			break
+		}
+		batchCtx := client.WithIdempotencySubKey(ctx, strconv.Itoa(start))
+		if err := compactBatch(batchCtx, c, filters.MemoryOptions, batch, options.Consolidator, report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// compactBatch replaces originals with their consolidation
+func compactBatch(ctx context.Context, c client.Client, scope client.MemoryOptions, originals []client.Memory, consolidator Consolidator, report *CompactReport) error {
+	texts, err := consolidator.Consolidate(ctx, originals)
+	if err != nil {
+		return fmt.Errorf("consolidating memories: %w", err)
+	}
+	if len(texts) == 0 {
+		return nil
+	}
+
+	from := make([]ConsolidatedMemory, len(originals))
+	for i, original := range originals {
+		from[i] = ConsolidatedMemory{ID: original.ID, Memory: original.Text(), CreatedAt: original.CreatedAt}
+	}
+	infer := false
+	options := client.MemoryOptions{
+		UserID:   scope.UserID,
+		AgentID:  scope.AgentID,
+		AppID:    scope.AppID,
+		RunID:    scope.RunID,
+		Infer:    &infer,
+		Metadata: map[string]interface{}{ConsolidatedFromKey: from},
+	}
+
+	var added []client.Memory
+	for i, text := range texts {
+		memories, err := c.Add(client.WithIdempotencySubKey(ctx, "add-"+strconv.Itoa(i)), []client.Message{{Role: "user", Content: text}}, options)
+		if err != nil {
+			return errors.Join(fmt.Errorf("adding consolidated memory: %w", err), rollback(ctx, c, added))
+		}
+		added = append(added, memories...)
+	}
+	report.Added = append(report.Added, added...)
+
+	ids := make([]string, len(originals))
+	for i, original := range originals {
+		ids[i] = original.ID
+	}
+	result, err := c.BatchDelete(client.WithIdempotencySubKey(ctx, "delete"), ids)
+	if result != nil {
+		for _, item := range result.Items {
+			if item.Err == nil {
+				report.Deleted++
+			}
+		}
+		err = result.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("deleting consolidated memories: %w", err)
+	}
+	return nil
+}
+
+// rollback deletes the replacements of a batch that could not be completed
+func rollback(ctx context.Context, c client.Client, added []client.Memory) error {
+	if len(added) == 0 {
+		return nil
+	}
+	ids := make([]string, len(added))
+	for i, memory := range added {
+		ids[i] = memory.ID
+	}
+	result, err := c.BatchDelete(client.WithIdempotencySubKey(context.WithoutCancel(ctx), "rollback"), ids)
+	if result != nil {
+		err = result.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("rolling back consolidated memories: %w", err)
+	}
+	return nil
+}
+
+// validate checks the options and that filters name an entity to compact
+func (o CompactOptions) validate(filters client.SearchOptions) error {
+	if o.OlderThan <= 0 {
+		return client.NewValidationError("older_than", "older_than must be positive")
+	}
+	if o.Consolidator == nil {
+		return client.NewValidationError("consolidator", "consolidator is required")
+	}
+	if filters.UserID == nil && filters.AgentID == nil && filters.AppID == nil && filters.RunID == nil {
+		return client.NewValidationError("user_id", "user_id, agent_id, app_id or run_id is required")
+	}
+	return nil
+}
+
+// Compactor runs Compact on a schedule
+type Compactor struct {
+	client  client.Client
+	filters client.SearchOptions
+	options CompactOptions
+}
+
+// NewCompactor returns a Compactor of the memories matching filters
+func NewCompactor(c client.Client, filters client.SearchOptions, options CompactOptions) *Compactor {
+	if options.Interval <= 0 {
+		options.Interval = DefaultCompactInterval
+	}
+	return &Compactor{client: c, filters: filters, options: options}
+}
+
+// Run compacts right away and then every Interval, until ctx is cancelled.
+// Errors are passed to OnError and the next run goes ahead. It returns
+// ctx.Err().
+func (k *Compactor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(k.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := Compact(ctx, k.client, k.filters, k.options); err != nil && ctx.Err() == nil && k.options.OnError != nil {
+			k.options.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
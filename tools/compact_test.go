@@ -0,0 +1,171 @@
+package tools_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/mem0test"
+	"github.com/murilopl/go-mem0/tools"
+)
+
+// seedAged adds one memory per text for userID, created age ago
+func seedAged(t *testing.T, c client.Client, userID string, age time.Duration, texts ...string) {
+	t.Helper()
+	timestamp := time.Now().Add(-age).Unix()
+	for _, text := range texts {
+		_, err := c.Add(context.Background(), []client.Message{{Role: "user", Content: text}}, client.MemoryOptions{UserID: &userID, Timestamp: &timestamp})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+}
+
+var joinTexts = tools.ConsolidatorFunc(func(ctx context.Context, memories []client.Memory) ([]string, error) {
+	texts := make([]string, len(memories))
+	for i, memory := range memories {
+		texts[i] = memory.Text()
+	}
+	return []string{strings.Join(texts, "; ")}, nil
+})
+
+func TestCompact(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	userID := "alice"
+	seedAged(t, c, userID, 90*24*time.Hour, "Likes tea", "Likes green tea", "Drinks tea daily")
+	seedAged(t, c, userID, time.Hour, "Started learning Go")
+
+	filters := client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID}}
+	report, err := tools.Compact(context.Background(), c, filters, tools.CompactOptions{
+		OlderThan:    30 * 24 * time.Hour,
+		Consolidator: joinTexts,
+	})
+	if err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+	if report.Scanned != 4 || report.Selected != 3 || len(report.Added) != 1 || report.Deleted != 3 {
+		t.Errorf("report = %+v, want 4 scanned, 3 selected, 1 added, 3 deleted", report)
+	}
+
+	memories := srv.Memories()
+	if len(memories) != 2 {
+		t.Fatalf("%d memories left, want the recent one and the replacement", len(memories))
+	}
+	replacement := memories[1]
+	if got := replacement.Text(); got != "Likes tea; Likes green tea; Drinks tea daily" {
+		t.Errorf("replacement = %q", got)
+	}
+	metadata, _ := replacement.Metadata.(map[string]interface{})
+	from, _ := metadata[tools.ConsolidatedFromKey].([]interface{})
+	if len(from) != 3 {
+		t.Fatalf("%s = %v, want the three originals", tools.ConsolidatedFromKey, metadata[tools.ConsolidatedFromKey])
+	}
+	if first, _ := from[0].(map[string]interface{}); first["memory"] != "Likes tea" || first["created_at"] == nil {
+		t.Errorf("first original = %v, want its text and creation time", first)
+	}
+}
+
+// failingAdds fails every Add after the first ok
+type failingAdds struct {
+	client.Client
+	ok int
+}
+
+func (f *failingAdds) Add(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+	if f.ok == 0 {
+		return nil, errors.New("add failed")
+	}
+	f.ok--
+	return f.Client.Add(ctx, messages, options...)
+}
+
+func TestCompactRollsBack(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	userID := "alice"
+	seedAged(t, c, userID, 90*24*time.Hour, "Likes tea", "Likes coffee")
+
+	filters := client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID}}
+	_, err = tools.Compact(context.Background(), &failingAdds{Client: c, ok: 1}, filters, tools.CompactOptions{
+		OlderThan: 24 * time.Hour,
+		Consolidator: tools.ConsolidatorFunc(func(ctx context.Context, memories []client.Memory) ([]string, error) {
+			return []string{"Likes hot drinks", "Drinks something every morning"}, nil
+		}),
+	})
+	if err == nil {
+		t.Fatal("Compact() error = nil, want the Add failure")
+	}
+
+	var texts []string
+	for _, memory := range srv.Memories() {
+		texts = append(texts, memory.Text())
+	}
+	if strings.Join(texts, ",") != "Likes tea,Likes coffee" {
+		t.Errorf("memories = %q, want the originals only", texts)
+	}
+}
+
+func TestCompactRequiresScope(t *testing.T) {
+	_, err := tools.Compact(context.Background(), nil, client.SearchOptions{}, tools.CompactOptions{OlderThan: time.Hour, Consolidator: joinTexts})
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Compact() error = %v, want a ValidationError", err)
+	}
+}
+
+// recordingKeys records the idempotency key of every write
+type recordingKeys struct {
+	client.Client
+	keys []string
+}
+
+func (r *recordingKeys) Add(ctx context.Context, messages []client.Message, options ...client.MemoryOptions) ([]client.Memory, error) {
+	key, _ := client.IdempotencyKey(ctx)
+	r.keys = append(r.keys, key)
+	return r.Client.Add(ctx, messages, options...)
+}
+
+func (r *recordingKeys) BatchDelete(ctx context.Context, memoryIDs []string, opts ...client.BatchOption) (*client.BatchResult, error) {
+	key, _ := client.IdempotencyKey(ctx)
+	r.keys = append(r.keys, key)
+	return r.Client.BatchDelete(ctx, memoryIDs, opts...)
+}
+
+func TestCompactIdempotencyKeys(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	userID := "alice"
+	seedAged(t, c, userID, 90*24*time.Hour, "Likes tea", "Likes green tea", "Likes coffee", "Likes black coffee")
+
+	recorder := &recordingKeys{Client: c}
+	ctx := client.WithIdempotencyKey(context.Background(), "compact-1")
+	filters := client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID}}
+	if _, err := tools.Compact(ctx, recorder, filters, tools.CompactOptions{
+		OlderThan:    30 * 24 * time.Hour,
+		Consolidator: joinTexts,
+		BatchSize:    2,
+	}); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+
+	want := []string{"compact-1-0-add-0", "compact-1-0-delete", "compact-1-2-add-0", "compact-1-2-delete"}
+	if strings.Join(recorder.keys, ",") != strings.Join(want, ",") {
+		t.Errorf("keys = %v, want %v", recorder.keys, want)
+	}
+}
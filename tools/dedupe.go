@@ -0,0 +1,317 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// DefaultDuplicateThreshold is the similarity at or above which two memories
+// are duplicates
+const DefaultDuplicateThreshold = 0.85
+
+// Comparator scores how alike two memories are, from 0 (unrelated) to 1
+// (identical)
+type Comparator interface {
+	Similarity(ctx context.Context, a, b client.Memory) (float64, error)
+}
+
+// ComparatorFunc adapts a function to the Comparator interface
+type ComparatorFunc func(ctx context.Context, a, b client.Memory) (float64, error)
+
+// Similarity implements Comparator
+func (f ComparatorFunc) Similarity(ctx context.Context, a, b client.Memory) (float64, error) {
+	return f(ctx, a, b)
+}
+
+// TextSimilarity is a fuzzy Comparator scoring the overlap (Jaccard index) of
+// the lower-cased words of the two memories' texts, ignoring punctuation
+var TextSimilarity Comparator = ComparatorFunc(func(ctx context.Context, a, b client.Memory) (float64, error) {
+	wordsA, wordsB := words(a.Text()), words(b.Text())
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0, nil
+	}
+	shared := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared), nil
+})
+
+// words returns the set of lower-cased words of text
+func words(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		set[word] = true
+	}
+	return set
+}
+
+// EmbeddingSimilarity returns a Comparator scoring the cosine similarity of
+// the embeddings of the two memories' texts. Embeddings are computed once per
+// memory ID and kept for the life of the Comparator.
+func EmbeddingSimilarity(embed func(ctx context.Context, text string) ([]float64, error)) Comparator {
+	e := &embeddings{embed: embed, vectors: make(map[string][]float64)}
+	return ComparatorFunc(func(ctx context.Context, a, b client.Memory) (float64, error) {
+		va, err := e.vector(ctx, a)
+		if err != nil {
+			return 0, err
+		}
+		vb, err := e.vector(ctx, b)
+		if err != nil {
+			return 0, err
+		}
+		return cosine(va, vb), nil
+	})
+}
+
+type embeddings struct {
+	embed   func(ctx context.Context, text string) ([]float64, error)
+	mu      sync.Mutex // guards vectors
+	vectors map[string][]float64
+}
+
+// vector returns the embedding of memory, computing it on first use
+func (e *embeddings) vector(ctx context.Context, memory client.Memory) ([]float64, error) {
+	e.mu.Lock()
+	vector, ok := e.vectors[memory.ID]
+	e.mu.Unlock()
+	if ok {
+		return vector, nil
+	}
+	vector, err := e.embed(ctx, memory.Text())
+	if err != nil {
+		return nil, fmt.Errorf("embedding memory %s: %w", memory.ID, err)
+	}
+	e.mu.Lock()
+	e.vectors[memory.ID] = vector
+	e.mu.Unlock()
+	return vector, nil
+}
+
+// cosine returns the cosine similarity of a and b, or 0 when their lengths
+// differ or either is zero
+func cosine(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
+
+// DedupeAction is what Dedupe does with the duplicates it finds
+type DedupeAction int
+
+const (
+	// DedupeDryRun only reports the duplicate groups
+	DedupeDryRun DedupeAction = iota
+	// DedupeDelete deletes the duplicates of each group, keeping the oldest
+	// memory
+	DedupeDelete
+	// DedupeMerge copies the metadata keys of the duplicates that the kept
+	// memory lacks into it, then deletes the duplicates
+	DedupeMerge
+)
+
+// DedupeOptions configures Dedupe
+type DedupeOptions struct {
+	Action      DedupeAction // Optional: DedupeDryRun when zero
+	Comparator  Comparator   // Optional: TextSimilarity when nil
+	Threshold   float64      // Optional: DefaultDuplicateThreshold when zero
+	PageSize    int          // Optional: memories per GetAll page, DefaultPageSize when zero
+	Concurrency int          // Optional: writes in flight, client.DefaultBatchConcurrency when zero
+	RateLimit   float64      // Optional: writes started per second, unlimited when zero
+}
+
+// DuplicateGroup is a memory and the memories found to duplicate it
+type DuplicateGroup struct {
+	Keep       client.Memory   // The oldest memory of the group
+	Duplicates []client.Memory // The others, in scan order
+}
+
+// DedupeReport is the outcome of Dedupe
+type DedupeReport struct {
+	Scanned int              // Memories matching the filters
+	Groups  []DuplicateGroup // Groups of two or more memories, in scan order of Keep
+	Merged  int              // Kept memories whose metadata was updated
+	Deleted int              // Duplicates deleted
+}
+
+// Dedupe pages through the memories matching filters, typically those of
+// one user, and groups the memories whose pairwise similarity reaches the
+// threshold, transitively. With the default DedupeDryRun action it only
+// reports the groups; see DedupeAction for the others. Duplicates of a group
+// whose merge fails are not deleted. The error joins the errors of the
+// failed writes.
+func Dedupe(ctx context.Context, c client.Client, filters client.SearchOptions, options DedupeOptions) (*DedupeReport, error) {
+	comparator := options.Comparator
+	if comparator == nil {
+		comparator = TextSimilarity
+	}
+	threshold := options.Threshold
+	if threshold <= 0 {
+		threshold = DefaultDuplicateThreshold
+	}
+
+	memories, err := listAll(ctx, c, filters, options.PageSize)
+	if err != nil {
+		return &DedupeReport{}, err
+	}
+	report := &DedupeReport{Scanned: len(memories)}
+	report.Groups, err = group(ctx, memories, comparator, threshold)
+	if err != nil || options.Action == DedupeDryRun || len(report.Groups) == 0 {
+		return report, err
+	}
+
+	batch := []client.BatchOption{client.WithConcurrency(options.Concurrency), client.WithRateLimit(options.RateLimit)}
+	var errs []error
+	failed := make(map[string]bool)
+	if options.Action == DedupeMerge {
+		var updates []client.MemoryUpdate
+		for _, g := range report.Groups {
+			if metadata, changed := mergeDuplicates(g); changed {
+				updates = append(updates, client.MemoryUpdate{MemoryID: g.Keep.ID, Request: client.UpdateRequest{Metadata: metadata}})
+			}
+		}
+		results, err := client.UpdateBatch(ctx, c, updates, batch...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, result := range results {
+			if result.Err != nil {
+				failed[result.MemoryID] = true
+			} else {
+				report.Merged++
+			}
+		}
+	}
+
+	var ids []string
+	for _, g := range report.Groups {
+		if failed[g.Keep.ID] {
+			continue
+		}
+		for _, duplicate := range g.Duplicates {
+			ids = append(ids, duplicate.ID)
+		}
+	}
+	if len(ids) > 0 {
+		result, err := c.BatchDelete(ctx, ids, batch...)
+		if result != nil {
+			for _, item := range result.Items {
+				if item.Err == nil {
+					report.Deleted++
+				}
+			}
+			err = result.Err()
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// group links every pair of memories at or above threshold and returns the
+// connected components of two or more memories
+func group(ctx context.Context, memories []client.Memory, comparator Comparator, threshold float64) ([]DuplicateGroup, error) {
+	parent := make([]int, len(memories))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range memories {
+		for j := i + 1; j < len(memories); j++ {
+			if find(i) == find(j) {
+				continue
+			}
+			similarity, err := comparator.Similarity(ctx, memories[i], memories[j])
+			if err != nil {
+				return nil, err
+			}
+			if similarity >= threshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	var roots []int
+	for i := range memories {
+		root := find(i)
+		if members[root] == nil {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], i)
+	}
+
+	var groups []DuplicateGroup
+	for _, root := range roots {
+		indexes := members[root]
+		if len(indexes) < 2 {
+			continue
+		}
+		keep := indexes[0]
+		for _, i := range indexes[1:] {
+			if older(memories[i], memories[keep]) {
+				keep = i
+			}
+		}
+		g := DuplicateGroup{Keep: memories[keep]}
+		for _, i := range indexes {
+			if i != keep {
+				g.Duplicates = append(g.Duplicates, memories[i])
+			}
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// older reports whether a was created before b; memories without a creation
+// time are never older
+func older(a, b client.Memory) bool {
+	return a.CreatedAt != nil && (b.CreatedAt == nil || a.CreatedAt.Before(*b.CreatedAt))
+}
+
+// mergeDuplicates returns the kept memory's metadata with the keys of its
+// duplicates that it lacks, and whether any were added
+func mergeDuplicates(g DuplicateGroup) (map[string]interface{}, bool) {
+	metadata := mergeMetadata(g.Keep.Metadata, nil)
+	changed := false
+	for _, duplicate := range g.Duplicates {
+		extra, _ := duplicate.Metadata.(map[string]interface{})
+		for key, value := range extra {
+			if _, ok := metadata[key]; !ok {
+				metadata[key] = value
+				changed = true
+			}
+		}
+	}
+	return metadata, changed
+}
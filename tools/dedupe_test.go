@@ -0,0 +1,90 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/murilopl/go-mem0/client"
+	"github.com/murilopl/go-mem0/mem0test"
+	"github.com/murilopl/go-mem0/tools"
+)
+
+func TestDedupe(t *testing.T) {
+	srv := mem0test.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := context.Background()
+	userID := "alice"
+	for _, m := range []struct {
+		text     string
+		metadata map[string]interface{}
+	}{
+		{"Likes green tea", map[string]interface{}{"source": "chat"}},
+		{"Lives in Lisbon", nil},
+		{"likes green tea!", map[string]interface{}{"source": "email", "confidence": 0.9}},
+		{"Likes  Green Tea", nil},
+		{"Plays chess", nil},
+	} {
+		_, err := c.Add(ctx, []client.Message{{Role: "user", Content: m.text}}, client.MemoryOptions{UserID: &userID, Metadata: m.metadata})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	filters := client.SearchOptions{MemoryOptions: client.MemoryOptions{UserID: &userID}}
+
+	report, err := tools.Dedupe(ctx, c, filters, tools.DedupeOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("Dedupe() dry run error = %v", err)
+	}
+	if report.Scanned != 5 || len(report.Groups) != 1 || len(report.Groups[0].Duplicates) != 2 {
+		t.Fatalf("report = %+v, want one group of three among five", report)
+	}
+	if got := *report.Groups[0].Keep.Memory; got != "Likes green tea" {
+		t.Errorf("Keep = %q, want the oldest", got)
+	}
+	if len(srv.Memories()) != 5 {
+		t.Errorf("dry run changed the server")
+	}
+
+	report, err = tools.Dedupe(ctx, c, filters, tools.DedupeOptions{Action: tools.DedupeMerge})
+	if err != nil {
+		t.Fatalf("Dedupe() merge error = %v", err)
+	}
+	if report.Merged != 1 || report.Deleted != 2 {
+		t.Errorf("Merged, Deleted = %d, %d, want 1, 2", report.Merged, report.Deleted)
+	}
+	memories := srv.Memories()
+	if len(memories) != 3 {
+		t.Fatalf("%d memories left, want 3", len(memories))
+	}
+	metadata, _ := memories[0].Metadata.(map[string]interface{})
+	if metadata["source"] != "chat" || metadata["confidence"] != 0.9 {
+		t.Errorf("kept metadata = %v, want own source and merged confidence", metadata)
+	}
+}
+
+func TestEmbeddingSimilarity(t *testing.T) {
+	vectors := map[string][]float64{"a": {1, 0}, "b": {1, 1}, "c": {0, 1}}
+	calls := 0
+	comparator := tools.EmbeddingSimilarity(func(ctx context.Context, text string) ([]float64, error) {
+		calls++
+		return vectors[text], nil
+	})
+	memory := func(id, text string) client.Memory { return client.Memory{ID: id, Memory: &text} }
+
+	ctx := context.Background()
+	if got, _ := comparator.Similarity(ctx, memory("1", "a"), memory("3", "c")); got != 0 {
+		t.Errorf("orthogonal similarity = %v, want 0", got)
+	}
+	got, _ := comparator.Similarity(ctx, memory("1", "a"), memory("2", "b"))
+	if got < 0.707 || got > 0.708 {
+		t.Errorf("similarity = %v, want ~0.7071", got)
+	}
+	if calls != 3 {
+		t.Errorf("embed called %d times, want 3 (once per memory)", calls)
+	}
+}
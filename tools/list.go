@@ -0,0 +1,32 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/murilopl/go-mem0/client"
+)
+
+// DefaultPageSize is the number of memories fetched per GetAll request
+const DefaultPageSize = 100
+
+// listAll pages through the memories matching filters until a short page.
+// Every page is read before the caller changes anything, so writes cannot
+// shift the pages of filters on the fields being written.
+func listAll(ctx context.Context, c client.Client, filters client.SearchOptions, pageSize int) ([]client.Memory, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	var all []client.Memory
+	for page := 1; ; page++ {
+		opts := filters
+		opts.Page, opts.PageSize = &page, &pageSize
+		memories, err := c.GetAll(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, memories...)
+		if len(memories) < pageSize {
+			return all, nil
+		}
+	}
+}